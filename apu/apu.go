@@ -0,0 +1,621 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the Game Boy APU (Audio Processing Unit)
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+)
+
+// APU memory map
+const (
+	APU_REGISTERS      = 0xff10
+	APU_REGISTERS_SIZE = 0x30
+	APU_WAVE_RAM       = 0x20
+	APU_WAVE_RAM_SIZE  = 0x10
+)
+
+// APU registers (offset from 0xff10)
+const (
+	REG_NR10 = 0x00
+	REG_NR11 = 0x01
+	REG_NR12 = 0x02
+	REG_NR13 = 0x03
+	REG_NR14 = 0x04
+	REG_NR21 = 0x06
+	REG_NR22 = 0x07
+	REG_NR23 = 0x08
+	REG_NR24 = 0x09
+	REG_NR30 = 0x0a
+	REG_NR31 = 0x0b
+	REG_NR32 = 0x0c
+	REG_NR33 = 0x0d
+	REG_NR34 = 0x0e
+	REG_NR41 = 0x10
+	REG_NR42 = 0x11
+	REG_NR43 = 0x12
+	REG_NR44 = 0x13
+	REG_NR50 = 0x14
+	REG_NR51 = 0x15
+	REG_NR52 = 0x16
+)
+
+// CGB PCM registers (offset from 0xff76)
+const (
+	APU_PCM_REGISTERS      = 0xff76
+	APU_PCM_REGISTERS_SIZE = 0x02
+	REG_PCM12              = 0x00
+	REG_PCM34              = 0x01
+)
+
+// bits always read as 1 in the APU registers (0xff10 - 0xff2f)
+var apuRegisterReadMask = [APU_WAVE_RAM]uint8{
+	0x80, 0x3f, 0x00, 0xff, 0xbf,
+	0xff, 0x3f, 0x00, 0xff, 0xbf,
+	0x7f, 0xff, 0x9f, 0xff, 0xbf,
+	0xff, 0xff, 0x00, 0x00, 0xbf,
+	0x00, 0x00, 0x70,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// APU timing
+const (
+	APU_SAMPLE_RATE       = cpu.CPU_CLOCK_RATE / 4
+	FRAME_SEQUENCER_CYCLE = 8192
+	APU_CHANNELS          = 4
+)
+
+// NRx4 and NR52 flags
+const (
+	NRX4_TRIGGER        = uint8(0x80)
+	NRX4_LENGTH_ENABLE  = uint8(0x40)
+	NR52_POWER          = uint8(0x80)
+	NR30_DAC_ENABLE     = uint8(0x80)
+	NRX2_DAC_ENABLE_BIT = uint8(0xf8)
+)
+
+// receiver of the stereo samples produced by the APU (one sample per M-cycle, range -1.0 to 1.0)
+type AudioSink interface {
+	PushSample(left float32, right float32)
+}
+
+// receiver of the stereo contribution of each channel to the mixed sample
+type ChannelSink interface {
+	PushChannelSamples(samples [APU_CHANNELS][2]float32)
+}
+
+// list of audio sinks receiving the same samples
+type multiAudioSink []AudioSink
+
+// send one sample to all sinks
+func (m multiAudioSink) PushSample(left float32, right float32) {
+	for _, sink := range m {
+		sink.PushSample(left, right)
+	}
+}
+
+// combine several audio sinks into one
+func MultiAudioSink(sinks ...AudioSink) AudioSink {
+	return multiAudioSink(sinks)
+}
+
+// APU internal registers and channels
+type APU struct {
+	cgbMode bool
+	powered bool
+
+	registers []uint8
+
+	channel1 squareChannel
+	channel2 squareChannel
+	channel3 waveChannel
+	channel4 noiseChannel
+
+	divCounter         uint16
+	frameSequencerStep uint8
+	sampleCycles       uint8
+
+	sink        AudioSink
+	channelSink ChannelSink
+}
+
+// create a new APU
+func NewAPU(cgbMode bool) *APU {
+
+	return &APU{
+		cgbMode: cgbMode,
+		powered: true,
+
+		registers: make([]uint8, APU_REGISTERS_SIZE),
+
+		channel1: newSquareChannel(true),
+		channel2: newSquareChannel(false),
+		channel3: newWaveChannel(),
+		channel4: newNoiseChannel(),
+	}
+}
+
+// connect the receiver of the APU samples
+func (a *APU) ConnectSink(sink AudioSink) {
+	a.sink = sink
+}
+
+// connect the receiver of the separate channel samples
+func (a *APU) ConnectChannelSink(channelSink ChannelSink) {
+	a.channelSink = channelSink
+}
+
+// return the APU registers and wave RAM as a memory bank (0xff10 - 0xff3f)
+func (a *APU) Registers() memory.Memory {
+	return &apuRegisters{apu: a}
+}
+
+// run the APU for a number of T-cycles
+func (a *APU) Step(cycles int) {
+
+	for range cycles {
+		if a.powered {
+			a.channel1.tick()
+			a.channel2.tick()
+			a.channel3.tick()
+			a.channel4.tick()
+		}
+
+		//	the frame sequencer is clocked by the falling edge of DIV bit 4 (512 Hz)
+		a.divCounter++
+		if a.divCounter%FRAME_SEQUENCER_CYCLE == 0 {
+			a.ClockFrameSequencer()
+		}
+
+		a.sampleCycles++
+		if a.sampleCycles == 4 {
+			a.sampleCycles = 0
+			if a.sink != nil || a.channelSink != nil {
+				a.pushSamples()
+			}
+		}
+	}
+}
+
+// the timer reset DIV: a set DIV bit 4 falling to zero also clocks the frame sequencer
+func (a *APU) ResetDIV() {
+	if a.divCounter&(FRAME_SEQUENCER_CYCLE/2) != 0 {
+		a.ClockFrameSequencer()
+	}
+
+	a.divCounter = 0
+}
+
+// advance the frame sequencer: length at 256 Hz, sweep at 128 Hz and envelope at 64 Hz
+func (a *APU) ClockFrameSequencer() {
+	if !a.powered {
+		return
+	}
+
+	switch a.frameSequencerStep {
+	case 0, 4:
+		a.clockLengthCounters()
+
+	case 2, 6:
+		a.clockLengthCounters()
+		a.channel1.clockSweep()
+
+	case 7:
+		a.channel1.envelope.clock()
+		a.channel2.envelope.clock()
+		a.channel4.envelope.clock()
+	}
+
+	a.frameSequencerStep = (a.frameSequencerStep + 1) & 0x07
+}
+
+// clock all channels length counters
+func (a *APU) clockLengthCounters() {
+	if a.channel1.length.clock() {
+		a.channel1.enabled = false
+	}
+	if a.channel2.length.clock() {
+		a.channel2.enabled = false
+	}
+	if a.channel3.length.clock() {
+		a.channel3.enabled = false
+	}
+	if a.channel4.length.clock() {
+		a.channel4.enabled = false
+	}
+}
+
+// digital outputs (0 - 15) and DAC states of the four channels
+func (a *APU) channelOutputs() ([APU_CHANNELS]uint8, [APU_CHANNELS]bool) {
+
+	return [APU_CHANNELS]uint8{
+		a.channel1.output(),
+		a.channel2.output(),
+		a.channel3.output(),
+		a.channel4.output(),
+	}, [APU_CHANNELS]bool{
+		a.channel1.dacEnabled,
+		a.channel2.dacEnabled,
+		a.channel3.dacEnabled,
+		a.channel4.dacEnabled,
+	}
+}
+
+// convert a channel digital output into an analog value (-1.0 to 1.0)
+func dacOutput(value uint8, dacEnabled bool) float32 {
+	if !dacEnabled {
+		return 0
+	}
+
+	return 1.0 - float32(value)/7.5
+}
+
+// stereo contribution of each channel using NR50 and NR51
+func (a *APU) channelSamples() [APU_CHANNELS][2]float32 {
+	var samples [APU_CHANNELS][2]float32
+
+	if !a.powered {
+		return samples
+	}
+
+	outputs, dacs := a.channelOutputs()
+	panning := a.registers[REG_NR51]
+	volume := a.registers[REG_NR50]
+	leftVolume := float32(volume>>4&0x07+1) / 8 / APU_CHANNELS
+	rightVolume := float32(volume&0x07+1) / 8 / APU_CHANNELS
+
+	for i := range APU_CHANNELS {
+		value := dacOutput(outputs[i], dacs[i])
+
+		if panning&(0x10<<i) != 0 {
+			samples[i][0] = value * leftVolume
+		}
+		if panning&(0x01<<i) != 0 {
+			samples[i][1] = value * rightVolume
+		}
+	}
+
+	return samples
+}
+
+// mix the channels into a stereo sample
+func (a *APU) mix() (float32, float32) {
+	return mixChannelSamples(a.channelSamples())
+}
+
+// sum the channels contributions
+func mixChannelSamples(samples [APU_CHANNELS][2]float32) (float32, float32) {
+	var left, right float32
+
+	for i := range APU_CHANNELS {
+		left += samples[i][0]
+		right += samples[i][1]
+	}
+
+	return left, right
+}
+
+// send the current sample to the connected sinks
+func (a *APU) pushSamples() {
+	samples := a.channelSamples()
+
+	if a.channelSink != nil {
+		a.channelSink.PushChannelSamples(samples)
+	}
+	if a.sink != nil {
+		a.sink.PushSample(mixChannelSamples(samples))
+	}
+}
+
+// read an APU register
+func (a *APU) readRegister(register uint16) uint8 {
+
+	if register >= APU_WAVE_RAM {
+		return a.channel3.waveRAM[register-APU_WAVE_RAM]
+	}
+
+	if register == REG_NR52 {
+		var value = apuRegisterReadMask[REG_NR52]
+
+		if a.powered {
+			value |= NR52_POWER
+		}
+
+		if a.channel1.enabled {
+			value |= 0x01
+		}
+		if a.channel2.enabled {
+			value |= 0x02
+		}
+		if a.channel3.enabled {
+			value |= 0x04
+		}
+		if a.channel4.enabled {
+			value |= 0x08
+		}
+
+		return value
+	}
+
+	return a.registers[register] | apuRegisterReadMask[register]
+}
+
+// write an APU register
+func (a *APU) writeRegister(register uint16, value uint8) {
+
+	if register >= APU_WAVE_RAM {
+		a.channel3.waveRAM[register-APU_WAVE_RAM] = value
+		return
+	}
+
+	if register == REG_NR52 {
+		a.writePower(value)
+		return
+	}
+
+	//	registers are read only while the APU is off, except the DMG length counters
+	if !a.powered {
+		if !a.cgbMode {
+			a.writeLengthWhileOff(register, value)
+		}
+		return
+	}
+
+	a.registers[register] = value
+
+	switch register {
+	case REG_NR10:
+		a.channel1.sweepPeriod = value >> 4 & 0x07
+		a.channel1.sweepNegate = value&0x08 != 0
+		a.channel1.sweepShift = value & 0x07
+
+	case REG_NR11:
+		a.channel1.duty = value >> 6
+		a.channel1.length.load(uint16(value & 0x3f))
+
+	case REG_NR12:
+		a.channel1.envelope.write(value)
+		a.channel1.dacEnabled = value&NRX2_DAC_ENABLE_BIT != 0
+		if !a.channel1.dacEnabled {
+			a.channel1.enabled = false
+		}
+
+	case REG_NR13:
+		a.channel1.frequency = a.channel1.frequency&0x0700 | uint16(value)
+
+	case REG_NR14:
+		a.channel1.frequency = a.channel1.frequency&0x00ff | uint16(value&0x07)<<8
+		a.channel1.length.enabled = value&NRX4_LENGTH_ENABLE != 0
+		if value&NRX4_TRIGGER != 0 {
+			a.channel1.trigger()
+		}
+
+	case REG_NR21:
+		a.channel2.duty = value >> 6
+		a.channel2.length.load(uint16(value & 0x3f))
+
+	case REG_NR22:
+		a.channel2.envelope.write(value)
+		a.channel2.dacEnabled = value&NRX2_DAC_ENABLE_BIT != 0
+		if !a.channel2.dacEnabled {
+			a.channel2.enabled = false
+		}
+
+	case REG_NR23:
+		a.channel2.frequency = a.channel2.frequency&0x0700 | uint16(value)
+
+	case REG_NR24:
+		a.channel2.frequency = a.channel2.frequency&0x00ff | uint16(value&0x07)<<8
+		a.channel2.length.enabled = value&NRX4_LENGTH_ENABLE != 0
+		if value&NRX4_TRIGGER != 0 {
+			a.channel2.trigger()
+		}
+
+	case REG_NR30:
+		a.channel3.dacEnabled = value&NR30_DAC_ENABLE != 0
+		if !a.channel3.dacEnabled {
+			a.channel3.enabled = false
+		}
+
+	case REG_NR31:
+		a.channel3.length.load(uint16(value))
+
+	case REG_NR32:
+		a.channel3.volumeCode = value >> 5 & 0x03
+
+	case REG_NR33:
+		a.channel3.frequency = a.channel3.frequency&0x0700 | uint16(value)
+
+	case REG_NR34:
+		a.channel3.frequency = a.channel3.frequency&0x00ff | uint16(value&0x07)<<8
+		a.channel3.length.enabled = value&NRX4_LENGTH_ENABLE != 0
+		if value&NRX4_TRIGGER != 0 {
+			a.channel3.trigger()
+		}
+
+	case REG_NR41:
+		a.channel4.length.load(uint16(value & 0x3f))
+
+	case REG_NR42:
+		a.channel4.envelope.write(value)
+		a.channel4.dacEnabled = value&NRX2_DAC_ENABLE_BIT != 0
+		if !a.channel4.dacEnabled {
+			a.channel4.enabled = false
+		}
+
+	case REG_NR43:
+		a.channel4.clockShift = value >> 4
+		a.channel4.widthMode7 = value&0x08 != 0
+		a.channel4.divisorCode = value & 0x07
+
+	case REG_NR44:
+		a.channel4.length.enabled = value&NRX4_LENGTH_ENABLE != 0
+		if value&NRX4_TRIGGER != 0 {
+			a.channel4.trigger()
+		}
+	}
+}
+
+// write NR52 turning the APU on or off
+func (a *APU) writePower(value uint8) {
+	var powered = value&NR52_POWER != 0
+
+	if a.powered && !powered {
+		//	powering off clears all registers but keeps the wave RAM (and the length counters on DMG)
+		for register := uint16(0); register < REG_NR52; register++ {
+			a.registers[register] = 0x00
+		}
+
+		lengths := [APU_CHANNELS]uint16{
+			a.channel1.length.counter,
+			a.channel2.length.counter,
+			a.channel3.length.counter,
+			a.channel4.length.counter,
+		}
+		waveRAM := a.channel3.waveRAM
+
+		a.channel1 = newSquareChannel(true)
+		a.channel2 = newSquareChannel(false)
+		a.channel3 = newWaveChannel()
+		a.channel3.waveRAM = waveRAM
+		a.channel4 = newNoiseChannel()
+
+		if !a.cgbMode {
+			a.channel1.length.counter = lengths[0]
+			a.channel2.length.counter = lengths[1]
+			a.channel3.length.counter = lengths[2]
+			a.channel4.length.counter = lengths[3]
+		}
+	}
+
+	//	powering on resets the frame sequencer, so the next step is 0
+	if !a.powered && powered {
+		a.frameSequencerStep = 0
+	}
+
+	a.powered = powered
+}
+
+// write the length part of NRx1 while the APU is off (DMG only)
+func (a *APU) writeLengthWhileOff(register uint16, value uint8) {
+
+	switch register {
+	case REG_NR11:
+		a.channel1.length.load(uint16(value & 0x3f))
+
+	case REG_NR21:
+		a.channel2.length.load(uint16(value & 0x3f))
+
+	case REG_NR31:
+		a.channel3.length.load(uint16(value))
+
+	case REG_NR41:
+		a.channel4.length.load(uint16(value & 0x3f))
+	}
+}
+
+// read the CGB PCM registers: current digital amplitude of channels 1/2 (PCM12) and 3/4 (PCM34)
+func (a *APU) readPCMRegister(register uint16) uint8 {
+	if !a.cgbMode {
+		return 0xff
+	}
+
+	outputs, _ := a.channelOutputs()
+
+	switch register {
+	case REG_PCM12:
+		return outputs[1]<<4 | outputs[0]
+
+	case REG_PCM34:
+		return outputs[3]<<4 | outputs[2]
+	}
+
+	return 0xff
+}
+
+// return the CGB PCM registers as a memory bank (0xff76 - 0xff77)
+func (a *APU) PCMRegisters() memory.Memory {
+	return &apuPCMRegisters{apu: a}
+}
+
+// APU registers view
+type apuRegisters struct {
+	apu *APU
+}
+
+// return memory bank size
+func (m *apuRegisters) Len() uint16 {
+	return APU_REGISTERS_SIZE
+}
+
+// write an APU register
+func (m *apuRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= APU_REGISTERS_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.apu.writeRegister(address, value)
+
+	return nil
+}
+
+// read an APU register
+func (m *apuRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= APU_REGISTERS_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.apu.readRegister(address), nil
+}
+
+// write a word into APU registers
+func (m *apuRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from APU registers
+func (m *apuRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
+
+// CGB PCM registers view (read only)
+type apuPCMRegisters struct {
+	apu *APU
+}
+
+// return memory bank size
+func (m *apuPCMRegisters) Len() uint16 {
+	return APU_PCM_REGISTERS_SIZE
+}
+
+// writes to the PCM registers are ignored
+func (m *apuPCMRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= APU_PCM_REGISTERS_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	return nil
+}
+
+// read a PCM register
+func (m *apuPCMRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= APU_PCM_REGISTERS_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.apu.readPCMRegister(address), nil
+}
+
+// write a word into PCM registers
+func (m *apuPCMRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from PCM registers
+func (m *apuPCMRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
@@ -0,0 +1,328 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_channels.go - Oct-18-2026 by aldebap
+//
+//	APU sound channels: square (with sweep), wave and noise
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+// square channel duty cycles (12.5%, 25%, 50% and 75%)
+var squareDutyTable = [4][8]uint8{
+	{0, 0, 0, 0, 0, 0, 0, 1},
+	{1, 0, 0, 0, 0, 0, 0, 1},
+	{1, 0, 0, 0, 0, 1, 1, 1},
+	{0, 1, 1, 1, 1, 1, 1, 0},
+}
+
+// noise channel divisors
+var noiseDivisorTable = [8]int{8, 16, 32, 48, 64, 80, 96, 112}
+
+// length counter shared by all channels
+type lengthCounter struct {
+	maximum uint16
+	counter uint16
+	enabled bool
+}
+
+// load the length counter (NRx1)
+func (l *lengthCounter) load(value uint16) {
+	l.counter = l.maximum - value
+}
+
+// reload an expired length counter on trigger
+func (l *lengthCounter) trigger() {
+	if l.counter == 0 {
+		l.counter = l.maximum
+	}
+}
+
+// clock the length counter returning true when it expires
+func (l *lengthCounter) clock() bool {
+	if !l.enabled || l.counter == 0 {
+		return false
+	}
+
+	l.counter--
+
+	return l.counter == 0
+}
+
+// volume envelope shared by square and noise channels
+type volumeEnvelope struct {
+	initialVolume uint8
+	increase      bool
+	period        uint8
+	volume        uint8
+	timer         uint8
+}
+
+// write the envelope register (NRx2)
+func (e *volumeEnvelope) write(value uint8) {
+	e.initialVolume = value >> 4
+	e.increase = value&0x08 != 0
+	e.period = value & 0x07
+}
+
+// reload the envelope on trigger
+func (e *volumeEnvelope) trigger() {
+	e.volume = e.initialVolume
+	e.timer = e.period
+	if e.timer == 0 {
+		e.timer = 8
+	}
+}
+
+// clock the envelope (64 Hz)
+func (e *volumeEnvelope) clock() {
+	if e.period == 0 {
+		return
+	}
+
+	e.timer--
+	if e.timer > 0 {
+		return
+	}
+	e.timer = e.period
+
+	if e.increase && e.volume < 15 {
+		e.volume++
+	} else if !e.increase && e.volume > 0 {
+		e.volume--
+	}
+}
+
+// square channel (channel 1 has a frequency sweep)
+type squareChannel struct {
+	enabled    bool
+	dacEnabled bool
+	length     lengthCounter
+	envelope   volumeEnvelope
+
+	duty         uint8
+	dutyPosition uint8
+	frequency    uint16
+	timer        int
+
+	hasSweep        bool
+	sweepPeriod     uint8
+	sweepNegate     bool
+	sweepShift      uint8
+	sweepTimer      uint8
+	sweepEnabled    bool
+	shadowFrequency uint16
+}
+
+// create a new square channel
+func newSquareChannel(hasSweep bool) squareChannel {
+
+	return squareChannel{
+		length:   lengthCounter{maximum: 64},
+		hasSweep: hasSweep,
+	}
+}
+
+// trigger the channel (NRx4 bit 7)
+func (c *squareChannel) trigger() {
+	c.enabled = c.dacEnabled
+	c.length.trigger()
+	c.timer = (2048 - int(c.frequency)) * 4
+	c.envelope.trigger()
+
+	if c.hasSweep {
+		c.shadowFrequency = c.frequency
+		c.sweepTimer = c.sweepPeriod
+		if c.sweepTimer == 0 {
+			c.sweepTimer = 8
+		}
+		c.sweepEnabled = c.sweepPeriod != 0 || c.sweepShift != 0
+
+		//	the overflow check is done immediately when shift is not zero
+		if c.sweepShift != 0 && c.sweepFrequency() > 2047 {
+			c.enabled = false
+		}
+	}
+}
+
+// calculate the next sweep frequency
+func (c *squareChannel) sweepFrequency() uint16 {
+	delta := c.shadowFrequency >> c.sweepShift
+
+	if c.sweepNegate {
+		return c.shadowFrequency - delta
+	}
+
+	return c.shadowFrequency + delta
+}
+
+// clock the frequency sweep (128 Hz)
+func (c *squareChannel) clockSweep() {
+	if c.sweepTimer > 0 {
+		c.sweepTimer--
+	}
+	if c.sweepTimer > 0 {
+		return
+	}
+
+	c.sweepTimer = c.sweepPeriod
+	if c.sweepTimer == 0 {
+		c.sweepTimer = 8
+	}
+
+	if !c.sweepEnabled || c.sweepPeriod == 0 {
+		return
+	}
+
+	frequency := c.sweepFrequency()
+	if frequency > 2047 {
+		c.enabled = false
+		return
+	}
+
+	if c.sweepShift != 0 {
+		c.shadowFrequency = frequency
+		c.frequency = frequency
+
+		//	a second overflow check is done with the new frequency
+		if c.sweepFrequency() > 2047 {
+			c.enabled = false
+		}
+	}
+}
+
+// advance the channel by one T-cycle
+func (c *squareChannel) tick() {
+	c.timer--
+	if c.timer <= 0 {
+		c.timer = (2048 - int(c.frequency)) * 4
+		c.dutyPosition = (c.dutyPosition + 1) & 0x07
+	}
+}
+
+// digital output of the channel (0 - 15)
+func (c *squareChannel) output() uint8 {
+	if !c.enabled {
+		return 0
+	}
+
+	return squareDutyTable[c.duty][c.dutyPosition] * c.envelope.volume
+}
+
+// wave channel
+type waveChannel struct {
+	enabled    bool
+	dacEnabled bool
+	length     lengthCounter
+
+	volumeCode uint8
+	frequency  uint16
+	timer      int
+	position   uint8
+	sample     uint8
+	waveRAM    []uint8
+}
+
+// create a new wave channel
+func newWaveChannel() waveChannel {
+
+	return waveChannel{
+		length:  lengthCounter{maximum: 256},
+		waveRAM: make([]uint8, APU_WAVE_RAM_SIZE),
+	}
+}
+
+// trigger the channel (NR34 bit 7)
+func (c *waveChannel) trigger() {
+	c.enabled = c.dacEnabled
+	c.length.trigger()
+	c.timer = (2048 - int(c.frequency)) * 2
+	c.position = 0
+}
+
+// advance the channel by one T-cycle
+func (c *waveChannel) tick() {
+	c.timer--
+	if c.timer > 0 {
+		return
+	}
+
+	c.timer = (2048 - int(c.frequency)) * 2
+	c.position = (c.position + 1) & 0x1f
+
+	//	high nibble first
+	value := c.waveRAM[c.position/2]
+	if c.position&0x01 == 0 {
+		c.sample = value >> 4
+	} else {
+		c.sample = value & 0x0f
+	}
+}
+
+// digital output of the channel (0 - 15)
+func (c *waveChannel) output() uint8 {
+	if !c.enabled || c.volumeCode == 0 {
+		return 0
+	}
+
+	return c.sample >> (c.volumeCode - 1)
+}
+
+// noise channel
+type noiseChannel struct {
+	enabled    bool
+	dacEnabled bool
+	length     lengthCounter
+	envelope   volumeEnvelope
+
+	clockShift    uint8
+	widthMode7    bool
+	divisorCode   uint8
+	timer         int
+	shiftRegister uint16
+}
+
+// create a new noise channel
+func newNoiseChannel() noiseChannel {
+
+	return noiseChannel{
+		length: lengthCounter{maximum: 64},
+	}
+}
+
+// noise channel timer period
+func (c *noiseChannel) period() int {
+	return noiseDivisorTable[c.divisorCode] << c.clockShift
+}
+
+// trigger the channel (NR44 bit 7)
+func (c *noiseChannel) trigger() {
+	c.enabled = c.dacEnabled
+	c.length.trigger()
+	c.timer = c.period()
+	c.envelope.trigger()
+	c.shiftRegister = 0x7fff
+}
+
+// advance the channel by one T-cycle
+func (c *noiseChannel) tick() {
+	c.timer--
+	if c.timer > 0 {
+		return
+	}
+	c.timer = c.period()
+
+	//	15 bit LFSR (7 bit in width mode)
+	xor := (c.shiftRegister & 0x01) ^ (c.shiftRegister >> 1 & 0x01)
+	c.shiftRegister = c.shiftRegister>>1 | xor<<14
+	if c.widthMode7 {
+		c.shiftRegister = c.shiftRegister&^0x0040 | xor<<6
+	}
+}
+
+// digital output of the channel (0 - 15)
+func (c *noiseChannel) output() uint8 {
+	if !c.enabled || c.shiftRegister&0x01 != 0 {
+		return 0
+	}
+
+	return c.envelope.volume
+}
@@ -0,0 +1,254 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_resampler.go - Oct-18-2026 by aldebap
+//
+//	resample the APU output to a host sample rate
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// resampler settings
+const (
+	RESAMPLER_DECIMATION  = 8
+	RESAMPLER_HALF_TAPS   = 24
+	RESAMPLER_PHASES      = 256
+	RESAMPLER_CUTOFF      = 0.45
+	DMG_CAPACITOR_CHARGE  = 0.999958
+	CGB_CAPACITOR_CHARGE  = 0.998943
+	RESAMPLER_MIN_LATENCY = 5 * time.Millisecond
+)
+
+// convert the APU samples (one per M-cycle) into 16 bit stereo PCM at a host rate:
+// a high pass filter mimics the output capacitor, a box filter decimates the input by 8
+// and a windowed sinc filter band limits and resamples it to the output rate
+type AudioResampler struct {
+	outputRate int
+
+	capacitorCharge float64
+	capacitorLeft   float64
+	capacitorRight  float64
+
+	decimated    int
+	accumulatedL float64
+	accumulatedR float64
+
+	history      [][2]float64
+	historyIndex int
+	inputIndex   int64
+	outputTime   float64
+	outputStep   float64
+	kernel       [][]float64
+
+	mutex     sync.Mutex
+	buffer    []int16
+	head      int
+	count     int
+	lastLeft  int16
+	lastRight int16
+	underruns int
+	overruns  int
+}
+
+// create a new resampler to an output rate (e.g. 44100 or 48000) keeping at most latency of buffered audio
+func NewAudioResampler(outputRate int, latency time.Duration, cgbMode bool) (*AudioResampler, error) {
+	const inputRate = float64(APU_SAMPLE_RATE) / RESAMPLER_DECIMATION
+
+	if outputRate < 8000 || float64(outputRate) > inputRate/2 {
+		return nil, fmt.Errorf("invalid output sample rate: %d", outputRate)
+	}
+	if latency < RESAMPLER_MIN_LATENCY {
+		return nil, fmt.Errorf("latency target too small: %s", latency)
+	}
+
+	//	the capacitor charge factor is given per T-cycle
+	charge := DMG_CAPACITOR_CHARGE
+	if cgbMode {
+		charge = CGB_CAPACITOR_CHARGE
+	}
+
+	frames := int(latency.Seconds() * float64(outputRate))
+
+	r := &AudioResampler{
+		outputRate:      outputRate,
+		capacitorCharge: math.Pow(charge, 4),
+		history:         make([][2]float64, 2*RESAMPLER_HALF_TAPS),
+		outputStep:      inputRate / float64(outputRate),
+		kernel:          windowedSincKernel(RESAMPLER_CUTOFF * float64(outputRate) / inputRate),
+		buffer:          make([]int16, 2*frames),
+	}
+
+	return r, nil
+}
+
+// build the polyphase windowed sinc (Blackman) low pass kernel, cutoff normalized to the input rate
+func windowedSincKernel(cutoff float64) [][]float64 {
+	const taps = 2 * RESAMPLER_HALF_TAPS
+
+	kernel := make([][]float64, RESAMPLER_PHASES)
+
+	for phase := range RESAMPLER_PHASES {
+		var sum float64
+
+		kernel[phase] = make([]float64, taps)
+
+		for j := range taps {
+			//	distance between the output time and input sample j
+			d := float64(phase)/RESAMPLER_PHASES + float64(RESAMPLER_HALF_TAPS-1-j)
+
+			value := 2 * cutoff
+			if d != 0 {
+				value = math.Sin(2*math.Pi*cutoff*d) / (math.Pi * d)
+			}
+
+			n := (d + RESAMPLER_HALF_TAPS) / (2 * RESAMPLER_HALF_TAPS)
+			window := 0.42 - 0.5*math.Cos(2*math.Pi*n) + 0.08*math.Cos(4*math.Pi*n)
+
+			kernel[phase][j] = value * window
+			sum += kernel[phase][j]
+		}
+
+		//	unity gain at DC for every phase
+		for j := range taps {
+			kernel[phase][j] /= sum
+		}
+	}
+
+	return kernel
+}
+
+// receive one APU sample (AudioSink interface)
+func (r *AudioResampler) PushSample(left float32, right float32) {
+
+	//	high pass filter (output capacitor)
+	outLeft := float64(left) - r.capacitorLeft
+	r.capacitorLeft = float64(left) - outLeft*r.capacitorCharge
+	outRight := float64(right) - r.capacitorRight
+	r.capacitorRight = float64(right) - outRight*r.capacitorCharge
+
+	//	box filter decimation
+	r.accumulatedL += outLeft
+	r.accumulatedR += outRight
+	r.decimated++
+	if r.decimated < RESAMPLER_DECIMATION {
+		return
+	}
+
+	r.pushDecimated(r.accumulatedL/RESAMPLER_DECIMATION, r.accumulatedR/RESAMPLER_DECIMATION)
+	r.decimated = 0
+	r.accumulatedL = 0
+	r.accumulatedR = 0
+}
+
+// add a decimated sample to the sinc filter history and produce the output samples it completes
+func (r *AudioResampler) pushDecimated(left float64, right float64) {
+	const taps = 2 * RESAMPLER_HALF_TAPS
+
+	r.history[r.historyIndex] = [2]float64{left, right}
+	r.historyIndex = (r.historyIndex + 1) % taps
+	r.inputIndex++
+
+	//	an output sample at time t needs input samples up to floor(t) + half taps
+	for int64(r.outputTime)+RESAMPLER_HALF_TAPS < r.inputIndex {
+		base := int64(r.outputTime)
+		phase := int((r.outputTime - float64(base)) * RESAMPLER_PHASES)
+		coefficients := r.kernel[phase]
+
+		var outLeft, outRight float64
+
+		for j := range taps {
+			//	input sample index base - half + 1 + j, located in the history ring
+			k := base - RESAMPLER_HALF_TAPS + 1 + int64(j)
+			offset := int(r.inputIndex - 1 - k)
+			if offset < 0 || offset >= taps {
+				continue
+			}
+
+			sample := r.history[(r.historyIndex-1-offset+taps)%taps]
+			outLeft += sample[0] * coefficients[j]
+			outRight += sample[1] * coefficients[j]
+		}
+
+		r.write(toPCM16(outLeft), toPCM16(outRight))
+		r.outputTime += r.outputStep
+	}
+}
+
+// convert an analog value into a 16 bit sample
+func toPCM16(value float64) int16 {
+	return int16(math.Max(-1, math.Min(1, value)) * math.MaxInt16)
+}
+
+// write a stereo frame into the output buffer dropping the oldest frame when the latency target is exceeded
+func (r *AudioResampler) write(left int16, right int16) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.count == len(r.buffer) {
+		r.head = (r.head + 2) % len(r.buffer)
+		r.count -= 2
+		r.overruns++
+	}
+
+	tail := (r.head + r.count) % len(r.buffer)
+	r.buffer[tail] = left
+	r.buffer[tail+1] = right
+	r.count += 2
+}
+
+// return the output sample rate
+func (r *AudioResampler) SampleRate() int {
+	return r.outputRate
+}
+
+// return the number of buffered stereo frames
+func (r *AudioResampler) Buffered() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.count / 2
+}
+
+// return the number of underruns (frames read with an empty buffer) and overruns (frames dropped)
+func (r *AudioResampler) Stats() (int, int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.underruns, r.overruns
+}
+
+// read interleaved stereo samples (left, right, ...), returning how many were available;
+// on underrun the remaining samples repeat the last frame to avoid clicks
+func (r *AudioResampler) ReadSamples(samples []int16) int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var read int
+
+	for read+1 < len(samples) && r.count > 0 {
+		r.lastLeft = r.buffer[r.head]
+		r.lastRight = r.buffer[r.head+1]
+		samples[read] = r.lastLeft
+		samples[read+1] = r.lastRight
+
+		r.head = (r.head + 2) % len(r.buffer)
+		r.count -= 2
+		read += 2
+	}
+
+	if read+1 < len(samples) {
+		r.underruns++
+	}
+
+	for i := read; i+1 < len(samples); i += 2 {
+		samples[i] = r.lastLeft
+		samples[i+1] = r.lastRight
+	}
+
+	return read
+}
@@ -0,0 +1,116 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_resampler_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the APU output resampler
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// feed a sine wave into the resampler
+func pushSine(r *AudioResampler, frequency float64, amplitude float64, seconds float64) {
+	for i := range int(seconds * APU_SAMPLE_RATE) {
+		value := float32(amplitude * math.Sin(2*math.Pi*frequency*float64(i)/APU_SAMPLE_RATE))
+		r.PushSample(value, value)
+	}
+}
+
+// audio resampler unit tests
+func Test_AudioResampler(t *testing.T) {
+
+	t.Run(">>> audio resampler: scenario 1 - a 1 kHz tone keeps its frequency and amplitude", func(t *testing.T) {
+
+		r, err := NewAudioResampler(48000, 200*time.Millisecond, false)
+		if err != nil {
+			t.Errorf("fail creating resampler: %s", err.Error())
+		}
+
+		pushSine(r, 1000, 0.5, 0.1)
+
+		samples := make([]int16, 2*r.Buffered())
+		read := r.ReadSamples(samples)
+		if read < 2*4700 || read > 2*4900 {
+			t.Errorf("failed resampling: expected about 4800 frames\n\tresult: %d", read/2)
+		}
+
+		//	skip the filter warm up and check the last 2400 frames (50 ms)
+		var crossings int
+		var peak int16
+		for i := read - 2*2400; i < read-2; i += 2 {
+			if (samples[i] < 0) != (samples[i+2] < 0) {
+				crossings++
+			}
+			peak = max(peak, samples[i])
+		}
+
+		if crossings < 98 || crossings > 102 {
+			t.Errorf("failed resampling frequency: expected 100 zero crossings\n\tresult: %d", crossings)
+		}
+		if math.Abs(float64(peak)/math.MaxInt16-0.5) > 0.02 {
+			t.Errorf("failed resampling amplitude: expected 0.5\n\tresult: %f", float64(peak)/math.MaxInt16)
+		}
+	})
+
+	t.Run(">>> audio resampler: scenario 2 - high pass filter removes DC offset", func(t *testing.T) {
+
+		r, _ := NewAudioResampler(44100, 100*time.Millisecond, true)
+
+		for range APU_SAMPLE_RATE / 10 {
+			r.PushSample(1.0, 1.0)
+		}
+
+		samples := make([]int16, 2*r.Buffered())
+		read := r.ReadSamples(samples)
+		if read == 0 || samples[read-2] > 100 {
+			t.Errorf("failed removing DC offset: last sample %d", samples[read-2])
+		}
+	})
+
+	t.Run(">>> audio resampler: scenario 3 - latency target bounds the buffer", func(t *testing.T) {
+
+		r, _ := NewAudioResampler(48000, 20*time.Millisecond, false)
+
+		pushSine(r, 440, 0.5, 0.2)
+
+		if r.Buffered() > 960 {
+			t.Errorf("failed bounding latency: expected at most 960 frames\n\tresult: %d", r.Buffered())
+		}
+		if _, overruns := r.Stats(); overruns == 0 {
+			t.Errorf("failed counting overruns")
+		}
+	})
+
+	t.Run(">>> audio resampler: scenario 4 - underrun repeats the last frame", func(t *testing.T) {
+
+		r, _ := NewAudioResampler(48000, 20*time.Millisecond, false)
+		r.write(100, -100)
+
+		samples := make([]int16, 6)
+		read := r.ReadSamples(samples)
+
+		if read != 2 || samples[4] != 100 || samples[5] != -100 {
+			t.Errorf("failed handling underrun: %v", samples)
+		}
+		if underruns, _ := r.Stats(); underruns != 1 {
+			t.Errorf("failed counting underruns: expected: 1\n\tresult: %d", underruns)
+		}
+	})
+
+	t.Run(">>> audio resampler: scenario 5 - invalid settings", func(t *testing.T) {
+
+		_, err := NewAudioResampler(1000000, 20*time.Millisecond, false)
+		if err == nil {
+			t.Errorf("expected error for invalid sample rate")
+		}
+
+		_, err = NewAudioResampler(48000, time.Millisecond, false)
+		if err == nil {
+			t.Errorf("expected error for invalid latency")
+		}
+	})
+}
@@ -0,0 +1,173 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_savestate.go - Oct-19-2026 by aldebap
+//
+//	APU save state: registers, channels and frame sequencer
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// write a length counter (its maximum is fixed by the channel)
+func (l *lengthCounter) saveState(w *savestate.Writer) {
+	w.Uint16(l.counter)
+	w.Bool(l.enabled)
+}
+
+// read a length counter
+func (l *lengthCounter) loadState(r *savestate.Reader) {
+	l.counter = min(r.Uint16(), l.maximum)
+	l.enabled = r.Bool()
+}
+
+// write a volume envelope
+func (e *volumeEnvelope) saveState(w *savestate.Writer) {
+	w.Uint8(e.initialVolume)
+	w.Bool(e.increase)
+	w.Uint8(e.period)
+	w.Uint8(e.volume)
+	w.Uint8(e.timer)
+}
+
+// read a volume envelope
+func (e *volumeEnvelope) loadState(r *savestate.Reader) {
+	e.initialVolume = r.Uint8()
+	e.increase = r.Bool()
+	e.period = r.Uint8()
+	e.volume = r.Uint8()
+	e.timer = r.Uint8()
+}
+
+// write a square channel (whether it has a sweep unit is fixed by the channel)
+func (c *squareChannel) saveState(w *savestate.Writer) {
+	w.Bool(c.enabled)
+	w.Bool(c.dacEnabled)
+	c.length.saveState(w)
+	c.envelope.saveState(w)
+
+	w.Uint8(c.duty)
+	w.Uint8(c.dutyPosition)
+	w.Uint16(c.frequency)
+	w.Int(c.timer)
+
+	w.Uint8(c.sweepPeriod)
+	w.Bool(c.sweepNegate)
+	w.Uint8(c.sweepShift)
+	w.Uint8(c.sweepTimer)
+	w.Bool(c.sweepEnabled)
+	w.Uint16(c.shadowFrequency)
+}
+
+// read a square channel
+func (c *squareChannel) loadState(r *savestate.Reader) {
+	c.enabled = r.Bool()
+	c.dacEnabled = r.Bool()
+	c.length.loadState(r)
+	c.envelope.loadState(r)
+
+	c.duty = r.Uint8() & 0x03
+	c.dutyPosition = r.Uint8() & 0x07
+	c.frequency = r.Uint16()
+	c.timer = r.Int()
+
+	c.sweepPeriod = r.Uint8()
+	c.sweepNegate = r.Bool()
+	c.sweepShift = r.Uint8()
+	c.sweepTimer = r.Uint8()
+	c.sweepEnabled = r.Bool()
+	c.shadowFrequency = r.Uint16()
+}
+
+// write the wave channel
+func (c *waveChannel) saveState(w *savestate.Writer) {
+	w.Bool(c.enabled)
+	w.Bool(c.dacEnabled)
+	c.length.saveState(w)
+
+	w.Uint8(c.volumeCode)
+	w.Uint16(c.frequency)
+	w.Int(c.timer)
+	w.Uint8(c.position)
+	w.Uint8(c.sample)
+	w.Bytes(c.waveRAM)
+}
+
+// read the wave channel
+func (c *waveChannel) loadState(r *savestate.Reader) {
+	c.enabled = r.Bool()
+	c.dacEnabled = r.Bool()
+	c.length.loadState(r)
+
+	c.volumeCode = r.Uint8() & 0x03
+	c.frequency = r.Uint16()
+	c.timer = r.Int()
+	c.position = r.Uint8() % uint8(2*len(c.waveRAM))
+	c.sample = r.Uint8()
+	r.Bytes(c.waveRAM)
+}
+
+// write the noise channel
+func (c *noiseChannel) saveState(w *savestate.Writer) {
+	w.Bool(c.enabled)
+	w.Bool(c.dacEnabled)
+	c.length.saveState(w)
+	c.envelope.saveState(w)
+
+	w.Uint8(c.clockShift)
+	w.Bool(c.widthMode7)
+	w.Uint8(c.divisorCode)
+	w.Int(c.timer)
+	w.Uint16(c.shiftRegister)
+}
+
+// read the noise channel
+func (c *noiseChannel) loadState(r *savestate.Reader) {
+	c.enabled = r.Bool()
+	c.dacEnabled = r.Bool()
+	c.length.loadState(r)
+	c.envelope.loadState(r)
+
+	c.clockShift = r.Uint8()
+	c.widthMode7 = r.Bool()
+	c.divisorCode = r.Uint8() & 0x07
+	c.timer = r.Int()
+	c.shiftRegister = r.Uint16()
+}
+
+// write the APU state
+func (a *APU) SaveState(w *savestate.Writer) {
+	w.Section("APU")
+
+	w.Bool(a.powered)
+	w.Bytes(a.registers)
+
+	a.channel1.saveState(w)
+	a.channel2.saveState(w)
+	a.channel3.saveState(w)
+	a.channel4.saveState(w)
+
+	w.Uint16(a.divCounter)
+	w.Uint8(a.frameSequencerStep)
+	w.Uint8(a.sampleCycles)
+}
+
+// read the APU state
+func (a *APU) LoadState(r *savestate.Reader) error {
+	r.Section("APU")
+
+	a.powered = r.Bool()
+	r.Bytes(a.registers)
+
+	a.channel1.loadState(r)
+	a.channel2.loadState(r)
+	a.channel3.loadState(r)
+	a.channel4.loadState(r)
+
+	a.divCounter = r.Uint16()
+	a.frameSequencerStep = r.Uint8() & 0x07
+	a.sampleCycles = r.Uint8() & 0x03
+
+	return r.Err()
+}
@@ -0,0 +1,291 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy APU
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"testing"
+)
+
+// audio sink keeping the last sample
+type lastSampleSink struct {
+	samples int
+	left    float32
+	right   float32
+}
+
+// keep the last sample
+func (s *lastSampleSink) PushSample(left float32, right float32) {
+	s.samples++
+	s.left = left
+	s.right = right
+}
+
+// write a sequence of APU registers
+func writeAPURegisters(apu *APU, values ...[2]uint8) {
+	registers := apu.Registers()
+
+	for _, value := range values {
+		registers.WriteByte(uint16(value[0]), value[1])
+	}
+}
+
+// APU channels unit tests
+func Test_APUChannels(t *testing.T) {
+
+	t.Run(">>> APU channels: scenario 1 - length counter disables the channel", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		//	channel 2 with length 62 (64 - 2 clocks)
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR22, 0xf0},
+			[2]uint8{REG_NR21, 62},
+			[2]uint8{REG_NR24, NRX4_TRIGGER | NRX4_LENGTH_ENABLE})
+
+		status, _ := apu.Registers().ReadByte(REG_NR52)
+		if status&0x02 == 0 {
+			t.Errorf("failed triggering channel 2: NR52 = 0x%02x", status)
+		}
+
+		//	steps 0 and 2 clock the length counters
+		for range 3 {
+			apu.ClockFrameSequencer()
+		}
+
+		status, _ = apu.Registers().ReadByte(REG_NR52)
+		if status&0x02 != 0 {
+			t.Errorf("failed expiring channel 2 length: NR52 = 0x%02x", status)
+		}
+	})
+
+	t.Run(">>> APU channels: scenario 2 - volume envelope", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		//	channel 1 volume 15 decreasing every envelope clock
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR12, 0xf1},
+			[2]uint8{REG_NR14, NRX4_TRIGGER})
+
+		for range 16 {
+			apu.ClockFrameSequencer()
+		}
+
+		if apu.channel1.envelope.volume != 13 {
+			t.Errorf("failed clocking envelope: expected volume: 13\n\tresult: %d", apu.channel1.envelope.volume)
+		}
+	})
+
+	t.Run(">>> APU channels: scenario 3 - sweep overflow disables channel 1", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		//	frequency 0x500 sweeps to 0x780, then the second overflow check fails
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR10, 0x11},
+			[2]uint8{REG_NR12, 0xf0},
+			[2]uint8{REG_NR13, 0x00},
+			[2]uint8{REG_NR14, NRX4_TRIGGER | 0x05})
+
+		if !apu.channel1.enabled {
+			t.Errorf("failed triggering channel 1: disabled on trigger")
+		}
+
+		apu.ClockFrameSequencer()
+		apu.ClockFrameSequencer()
+		apu.ClockFrameSequencer()
+
+		if apu.channel1.enabled {
+			t.Errorf("failed sweeping channel 1: expected overflow to disable the channel")
+		}
+	})
+
+	t.Run(">>> APU channels: scenario 4 - 7 bit LFSR repeats every 127 clocks", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR42, 0xf0},
+			[2]uint8{REG_NR43, 0x08},
+			[2]uint8{REG_NR44, NRX4_TRIGGER})
+
+		for range 8 * 10 {
+			apu.channel4.tick()
+		}
+		start := apu.channel4.shiftRegister & 0x7f
+
+		for range 8 * 127 {
+			apu.channel4.tick()
+		}
+
+		if apu.channel4.shiftRegister&0x7f != start {
+			t.Errorf("failed LFSR period: expected: 0x%02x\n\tresult: 0x%02x", start, apu.channel4.shiftRegister&0x7f)
+		}
+	})
+
+	t.Run(">>> APU channels: scenario 5 - wave channel reads wave RAM", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		writeAPURegisters(apu,
+			[2]uint8{APU_WAVE_RAM, 0xab},
+			[2]uint8{REG_NR30, NR30_DAC_ENABLE},
+			[2]uint8{REG_NR32, 0x20},
+			[2]uint8{REG_NR33, 0xff},
+			[2]uint8{REG_NR34, NRX4_TRIGGER | 0x07})
+
+		//	period is (2048 - 2047) * 2 T-cycles per sample
+		apu.Step(2)
+		if apu.channel3.output() != 0x0b {
+			t.Errorf("failed reading wave RAM: expected: 0x0b\n\tresult: 0x%02x", apu.channel3.output())
+		}
+	})
+}
+
+// APU frame sequencer and mixing unit tests
+func Test_APUMixing(t *testing.T) {
+
+	t.Run(">>> APU mixing: scenario 1 - frame sequencer is clocked every 8192 cycles", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		apu.Step(FRAME_SEQUENCER_CYCLE * 3)
+		if apu.frameSequencerStep != 3 {
+			t.Errorf("failed clocking frame sequencer: expected step: 3\n\tresult: %d", apu.frameSequencerStep)
+		}
+	})
+
+	t.Run(">>> APU mixing: scenario 2 - NR51 panning and one sample per M-cycle", func(t *testing.T) {
+
+		apu := NewAPU(false)
+		sink := &lastSampleSink{}
+		apu.ConnectSink(sink)
+
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR50, 0x77},
+			[2]uint8{REG_NR51, 0x20},
+			[2]uint8{REG_NR22, 0xf0},
+			[2]uint8{REG_NR24, NRX4_TRIGGER})
+
+		apu.Step(40)
+
+		if sink.samples != 10 {
+			t.Errorf("failed producing samples: expected: 10\n\tresult: %d", sink.samples)
+		}
+		if sink.left == 0 || sink.right != 0 {
+			t.Errorf("failed panning channel 2: expected left only\n\tresult: %f, %f", sink.left, sink.right)
+		}
+	})
+
+	t.Run(">>> APU mixing: scenario 3 - power off clears registers and keeps wave RAM", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		writeAPURegisters(apu,
+			[2]uint8{APU_WAVE_RAM + 1, 0x5a},
+			[2]uint8{REG_NR50, 0x77},
+			[2]uint8{REG_NR52, 0x00},
+			[2]uint8{REG_NR50, 0x33})
+
+		nr50, _ := apu.Registers().ReadByte(REG_NR50)
+		wave, _ := apu.Registers().ReadByte(APU_WAVE_RAM + 1)
+
+		if nr50 != 0x00 || wave != 0x5a {
+			t.Errorf("failed powering off: expected NR50 0x00 and wave RAM 0x5a\n\tresult: 0x%02x and 0x%02x", nr50, wave)
+		}
+	})
+}
+
+// APU register semantics unit tests
+func Test_APURegisterSemantics(t *testing.T) {
+
+	t.Run(">>> APU registers: scenario 1 - read OR masks", func(t *testing.T) {
+
+		apu := NewAPU(false)
+		registers := apu.Registers()
+
+		for register := uint16(0); register < REG_NR52; register++ {
+			registers.WriteByte(register, 0x00)
+
+			got, _ := registers.ReadByte(register)
+			if got != apuRegisterReadMask[register] {
+				t.Errorf("failed reading register 0x%04x: expected: 0x%02x\n\tresult: 0x%02x",
+					APU_REGISTERS+register, apuRegisterReadMask[register], got)
+			}
+		}
+
+		got, _ := registers.ReadByte(REG_NR52)
+		if got != 0xf0 {
+			t.Errorf("failed reading NR52: expected: 0xf0\n\tresult: 0x%02x", got)
+		}
+	})
+
+	t.Run(">>> APU registers: scenario 2 - DMG power off keeps length counters", func(t *testing.T) {
+
+		apu := NewAPU(false)
+
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR11, 0x3e},
+			[2]uint8{REG_NR52, 0x00},
+			[2]uint8{REG_NR21, 0xc8})
+
+		if apu.channel1.length.counter != 2 {
+			t.Errorf("failed keeping channel 1 length: expected: 2\n\tresult: %d", apu.channel1.length.counter)
+		}
+		if apu.channel2.length.counter != 56 {
+			t.Errorf("failed writing channel 2 length while off: expected: 56\n\tresult: %d", apu.channel2.length.counter)
+		}
+
+		//	the duty part of NR21 is not written while off
+		got, _ := apu.Registers().ReadByte(REG_NR21)
+		if got != 0x3f {
+			t.Errorf("failed reading NR21 while off: expected: 0x3f\n\tresult: 0x%02x", got)
+		}
+	})
+
+	t.Run(">>> APU registers: scenario 3 - CGB power off clears length counters", func(t *testing.T) {
+
+		apu := NewAPU(true)
+
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR11, 0x3e},
+			[2]uint8{REG_NR52, 0x00},
+			[2]uint8{REG_NR21, 0x08})
+
+		if apu.channel1.length.counter != 0 || apu.channel2.length.counter != 0 {
+			t.Errorf("failed clearing length counters: %d and %d", apu.channel1.length.counter, apu.channel2.length.counter)
+		}
+	})
+
+	t.Run(">>> APU registers: scenario 4 - CGB PCM12 and PCM34 amplitudes", func(t *testing.T) {
+
+		apu := NewAPU(true)
+
+		//	channel 2 with 75% duty (first step high) and volume 9
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR21, 0xc0},
+			[2]uint8{REG_NR22, 0x90},
+			[2]uint8{REG_NR24, NRX4_TRIGGER},
+			[2]uint8{REG_NR42, 0x50},
+			[2]uint8{REG_NR44, NRX4_TRIGGER})
+		apu.channel2.dutyPosition = 1
+		apu.channel4.shiftRegister = 0x7ffe
+
+		pcm := apu.PCMRegisters()
+
+		pcm12, _ := pcm.ReadByte(REG_PCM12)
+		pcm34, _ := pcm.ReadByte(REG_PCM34)
+		if pcm12 != 0x90 || pcm34 != 0x50 {
+			t.Errorf("failed reading PCM registers: expected: 0x90 and 0x50\n\tresult: 0x%02x and 0x%02x", pcm12, pcm34)
+		}
+
+		pcm12, _ = NewAPU(false).PCMRegisters().ReadByte(REG_PCM12)
+		if pcm12 != 0xff {
+			t.Errorf("failed reading PCM12 on DMG: expected: 0xff\n\tresult: 0x%02x", pcm12)
+		}
+	})
+}
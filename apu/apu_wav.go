@@ -0,0 +1,277 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_wav.go - Oct-18-2026 by aldebap
+//
+//	record the APU output into 16 bit PCM WAV files
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WAV file settings
+const (
+	WAV_HEADER_SIZE      = 44
+	WAV_BITS_PER_SAMPLE  = 16
+	WAV_FORMAT_PCM       = 1
+	WAV_RECORDER_LATENCY = 250 * time.Millisecond
+	WAV_RECORD_FOREVER   = -1
+)
+
+// 16 bit PCM WAV file writer
+type WAVWriter struct {
+	writer     io.WriteSeeker
+	sampleRate int
+	channels   int
+	dataSize   uint32
+}
+
+// create a new WAV writer (the header sizes are updated on Close)
+func NewWAVWriter(writer io.WriteSeeker, sampleRate int, channels int) (*WAVWriter, error) {
+	if channels < 1 || channels > 2 {
+		return nil, fmt.Errorf("invalid number of WAV channels: %d", channels)
+	}
+
+	w := &WAVWriter{
+		writer:     writer,
+		sampleRate: sampleRate,
+		channels:   channels,
+	}
+
+	err := w.writeHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// write the RIFF/WAVE header with the current data size
+func (w *WAVWriter) writeHeader() error {
+	var header [WAV_HEADER_SIZE]uint8
+
+	blockAlign := w.channels * WAV_BITS_PER_SAMPLE / 8
+
+	copy(header[0:], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:], WAV_HEADER_SIZE-8+w.dataSize)
+	copy(header[8:], "WAVE")
+	copy(header[12:], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:], 16)
+	binary.LittleEndian.PutUint16(header[20:], WAV_FORMAT_PCM)
+	binary.LittleEndian.PutUint16(header[22:], uint16(w.channels))
+	binary.LittleEndian.PutUint32(header[24:], uint32(w.sampleRate))
+	binary.LittleEndian.PutUint32(header[28:], uint32(w.sampleRate*blockAlign))
+	binary.LittleEndian.PutUint16(header[32:], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:], WAV_BITS_PER_SAMPLE)
+	copy(header[36:], "data")
+	binary.LittleEndian.PutUint32(header[40:], w.dataSize)
+
+	_, err := w.writer.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.writer.Write(header[:])
+
+	return err
+}
+
+// append interleaved samples
+func (w *WAVWriter) WriteSamples(samples []int16) error {
+
+	_, err := w.writer.Seek(int64(WAV_HEADER_SIZE+w.dataSize), io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = binary.Write(w.writer, binary.LittleEndian, samples)
+	if err != nil {
+		return err
+	}
+
+	w.dataSize += uint32(len(samples) * 2)
+
+	return nil
+}
+
+// update the header sizes (and close the writer if it is a file)
+func (w *WAVWriter) Close() error {
+
+	//	the writer is closed even when the header can't be updated
+	err := w.writeHeader()
+
+	if closer, ok := w.writer.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
+
+// create a WAV file
+func CreateWAVFile(fileName string, sampleRate int, channels int) (*WAVWriter, error) {
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := NewWAVWriter(file, sampleRate, channels)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// resampled audio stream written into a WAV file
+type wavTrack struct {
+	resampler *AudioResampler
+	writer    *WAVWriter
+	samples   []int16
+}
+
+// create a new WAV track
+func newWAVTrack(fileName string, sampleRate int, cgbMode bool) (*wavTrack, error) {
+
+	resampler, err := NewAudioResampler(sampleRate, WAV_RECORDER_LATENCY, cgbMode)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := CreateWAVFile(fileName, sampleRate, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wavTrack{
+		resampler: resampler,
+		writer:    writer,
+	}, nil
+}
+
+// move the resampled frames into the WAV file
+func (t *wavTrack) flush() error {
+
+	size := 2 * t.resampler.Buffered()
+	if cap(t.samples) < size {
+		t.samples = make([]int16, size)
+	}
+
+	read := t.resampler.ReadSamples(t.samples[:size])
+
+	return t.writer.WriteSamples(t.samples[:read])
+}
+
+// record the APU output (mixed and optionally per channel) between two frame numbers
+type AudioRecorder struct {
+	startFrame int
+	stopFrame  int
+	frame      int
+
+	mixed    *wavTrack
+	channels []*wavTrack
+}
+
+// create a new audio recorder: per channel files are named after the mixed file
+// (e.g. audio.wav, audio_ch1.wav ... audio_ch4.wav); stopFrame WAV_RECORD_FOREVER records until closed
+func NewAudioRecorder(fileName string, sampleRate int, perChannel bool, startFrame int, stopFrame int, cgbMode bool) (*AudioRecorder, error) {
+	if startFrame < 0 || (stopFrame != WAV_RECORD_FOREVER && stopFrame <= startFrame) {
+		return nil, fmt.Errorf("invalid recording frames: %d to %d", startFrame, stopFrame)
+	}
+
+	mixed, err := newWAVTrack(fileName, sampleRate, cgbMode)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &AudioRecorder{
+		startFrame: startFrame,
+		stopFrame:  stopFrame,
+		mixed:      mixed,
+	}
+
+	if perChannel {
+		extension := filepath.Ext(fileName)
+		baseName := strings.TrimSuffix(fileName, extension)
+
+		for i := range APU_CHANNELS {
+			channel, err := newWAVTrack(fmt.Sprintf("%s_ch%d%s", baseName, i+1, extension), sampleRate, cgbMode)
+			if err != nil {
+				r.Close()
+				return nil, err
+			}
+			r.channels = append(r.channels, channel)
+		}
+	}
+
+	return r, nil
+}
+
+// check if the current frame is recorded
+func (r *AudioRecorder) recording() bool {
+	return r.frame >= r.startFrame && (r.stopFrame == WAV_RECORD_FOREVER || r.frame < r.stopFrame)
+}
+
+// receive the mixed sample (AudioSink interface)
+func (r *AudioRecorder) PushSample(left float32, right float32) {
+	if r.recording() {
+		r.mixed.resampler.PushSample(left, right)
+	}
+}
+
+// receive the channels samples (ChannelSink interface)
+func (r *AudioRecorder) PushChannelSamples(samples [APU_CHANNELS][2]float32) {
+	if !r.recording() {
+		return
+	}
+
+	for i, channel := range r.channels {
+		channel.resampler.PushSample(samples[i][0], samples[i][1])
+	}
+}
+
+// signal the end of an emulated frame, writing the recorded audio into the files
+func (r *AudioRecorder) EndFrame() error {
+
+	if r.recording() {
+		for _, track := range append([]*wavTrack{r.mixed}, r.channels...) {
+			err := track.flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	r.frame++
+
+	return nil
+}
+
+// return true when the stop frame was reached
+func (r *AudioRecorder) Done() bool {
+	return r.stopFrame != WAV_RECORD_FOREVER && r.frame >= r.stopFrame
+}
+
+// write the remaining audio and close the files
+func (r *AudioRecorder) Close() error {
+	var result error
+
+	//	every file is closed, even after a failed flush
+	for _, track := range append([]*wavTrack{r.mixed}, r.channels...) {
+		flushErr := track.flush()
+		closeErr := track.writer.Close()
+
+		result = errors.Join(result, flushErr, closeErr)
+	}
+
+	return result
+}
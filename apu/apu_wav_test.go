@@ -0,0 +1,188 @@
+////////////////////////////////////////////////////////////////////////////////
+//	apu_wav_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for WAV recording of the APU output
+////////////////////////////////////////////////////////////////////////////////
+
+package apu
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// T-cycles of one frame used by the recorder tests
+const (
+	testFrameCycles = 70224
+)
+
+// in memory file failing the writes once failing is set
+type failingFile struct {
+	failing bool
+	closed  bool
+}
+
+// write the bytes, or fail
+func (f *failingFile) Write(p []uint8) (int, error) {
+	if f.failing {
+		return 0, errors.New("disk full")
+	}
+
+	return len(p), nil
+}
+
+// seeking always succeeds
+func (f *failingFile) Seek(offset int64, whence int) (int64, error) {
+	return 0, nil
+}
+
+// record that the file was closed
+func (f *failingFile) Close() error {
+	f.closed = true
+
+	return nil
+}
+
+// WAV writer unit tests
+func Test_WAVWriter(t *testing.T) {
+
+	t.Run(">>> WAV writer: scenario 1 - header and samples", func(t *testing.T) {
+
+		fileName := filepath.Join(t.TempDir(), "test.wav")
+
+		w, err := CreateWAVFile(fileName, 48000, 2)
+		if err != nil {
+			t.Fatalf("fail creating WAV file: %s", err.Error())
+		}
+		w.WriteSamples([]int16{1, -1, 2, -2})
+		err = w.Close()
+		if err != nil {
+			t.Errorf("fail closing WAV file: %s", err.Error())
+		}
+
+		data, _ := os.ReadFile(fileName)
+		if len(data) != WAV_HEADER_SIZE+8 {
+			t.Fatalf("failed writing WAV file: expected: %d bytes\n\tresult: %d", WAV_HEADER_SIZE+8, len(data))
+		}
+
+		if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" || string(data[36:40]) != "data" {
+			t.Errorf("failed writing WAV header chunks")
+		}
+		if binary.LittleEndian.Uint32(data[4:]) != 44 || binary.LittleEndian.Uint32(data[40:]) != 8 {
+			t.Errorf("failed writing WAV sizes")
+		}
+		if binary.LittleEndian.Uint32(data[24:]) != 48000 || binary.LittleEndian.Uint16(data[32:]) != 4 {
+			t.Errorf("failed writing WAV format")
+		}
+		if int16(binary.LittleEndian.Uint16(data[46:])) != -1 {
+			t.Errorf("failed writing WAV samples")
+		}
+	})
+
+	t.Run(">>> WAV writer: scenario 2 - close after a write error", func(t *testing.T) {
+
+		var file = failingFile{}
+
+		w, _ := NewWAVWriter(&file, 48000, 2)
+		file.failing = true
+
+		err := w.Close()
+		if err == nil || !file.closed {
+			t.Errorf("failed closing WAV writer: expected an error and a closed file\n\tresult: %v (closed: %v)", err, file.closed)
+		}
+	})
+
+	t.Run(">>> WAV writer: scenario 3 - invalid channels", func(t *testing.T) {
+
+		_, err := CreateWAVFile(filepath.Join(t.TempDir(), "test.wav"), 48000, 3)
+		if err == nil {
+			t.Errorf("expected error for invalid number of channels")
+		}
+	})
+}
+
+// audio recorder unit tests
+func Test_AudioRecorder(t *testing.T) {
+
+	t.Run(">>> audio recorder: scenario 1 - record frames 1 and 2 per channel", func(t *testing.T) {
+
+		directory := t.TempDir()
+		fileName := filepath.Join(directory, "audio.wav")
+
+		recorder, err := NewAudioRecorder(fileName, 48000, true, 1, 3, false)
+		if err != nil {
+			t.Fatalf("fail creating recorder: %s", err.Error())
+		}
+
+		apu := NewAPU(false)
+		apu.ConnectSink(recorder)
+		apu.ConnectChannelSink(recorder)
+		writeAPURegisters(apu,
+			[2]uint8{REG_NR50, 0x77},
+			[2]uint8{REG_NR51, 0xff},
+			[2]uint8{REG_NR12, 0xf0},
+			[2]uint8{REG_NR14, NRX4_TRIGGER | 0x06})
+
+		for !recorder.Done() {
+			apu.Step(testFrameCycles)
+			recorder.EndFrame()
+		}
+
+		err = recorder.Close()
+		if err != nil {
+			t.Errorf("fail closing recorder: %s", err.Error())
+		}
+
+		//	two frames at 48 kHz are about 1607 stereo frames
+		info, err := os.Stat(fileName)
+		if err != nil {
+			t.Fatalf("fail reading mixed file: %s", err.Error())
+		}
+		frames := (info.Size() - WAV_HEADER_SIZE) / 4
+		if frames < 1550 || frames > 1610 {
+			t.Errorf("failed recording frames 1 and 2: expected about 1607 stereo frames\n\tresult: %d", frames)
+		}
+
+		for _, channel := range []string{"audio_ch1.wav", "audio_ch2.wav", "audio_ch3.wav", "audio_ch4.wav"} {
+			_, err = os.Stat(filepath.Join(directory, channel))
+			if err != nil {
+				t.Errorf("missing channel file: %s", channel)
+			}
+		}
+	})
+
+	t.Run(">>> audio recorder: scenario 2 - invalid frames", func(t *testing.T) {
+
+		_, err := NewAudioRecorder(filepath.Join(t.TempDir(), "audio.wav"), 48000, false, 5, 5, false)
+		if err == nil {
+			t.Errorf("expected error for invalid recording frames")
+		}
+	})
+
+	t.Run(">>> audio recorder: scenario 3 - every file is closed after a write error", func(t *testing.T) {
+
+		var files [2]failingFile
+		var recorder AudioRecorder
+
+		for i := range files {
+			resampler, _ := NewAudioResampler(48000, WAV_RECORDER_LATENCY, false)
+			writer, _ := NewWAVWriter(&files[i], 48000, 2)
+			files[i].failing = true
+
+			track := &wavTrack{resampler: resampler, writer: writer}
+			if i == 0 {
+				recorder.mixed = track
+			} else {
+				recorder.channels = append(recorder.channels, track)
+			}
+		}
+
+		err := recorder.Close()
+		if err == nil || !files[0].closed || !files[1].closed {
+			t.Errorf("failed closing recorder: expected an error and every file closed\n\tresult: %v (closed: %v, %v)", err, files[0].closed, files[1].closed)
+		}
+	})
+}
@@ -0,0 +1,529 @@
+////////////////////////////////////////////////////////////////////////////////
+//	cartridge.go - Oct-18-2026 by aldebap
+//
+//	Game Boy cartridge: header, memory bank controllers and battery RAM
+////////////////////////////////////////////////////////////////////////////////
+
+package cartridge
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// cartridge memory map
+const (
+	CARTRIDGE_ROM_ADDRESS = 0x0000
+	CARTRIDGE_ROM_SIZE    = 0x8000
+	CARTRIDGE_RAM_ADDRESS = 0xa000
+	CARTRIDGE_RAM_SIZE    = 0x2000
+	ROM_BANK_SIZE         = 0x4000
+	RAM_BANK_SIZE         = 0x2000
+	MBC2_RAM_SIZE         = 0x0200
+)
+
+// cartridge header addresses
+const (
+	CARTRIDGE_TITLE_ADDRESS         = 0x0134
+	CARTRIDGE_TITLE_LENGTH          = 16
+	CARTRIDGE_CGB_FLAG_ADDRESS      = 0x0143
+	CARTRIDGE_NEW_LICENSEE_ADDRESS  = 0x0144
+	CARTRIDGE_NEW_LICENSEE_LENGTH   = 2
+	CARTRIDGE_TYPE_ADDRESS          = 0x0147
+	CARTRIDGE_ROM_SIZE_ADDRESS      = 0x0148
+	CARTRIDGE_RAM_SIZE_ADDRESS      = 0x0149
+	CARTRIDGE_OLD_LICENSEE_ADDRESS  = 0x014b
+	CARTRIDGE_HEADER_END_ADDRESS    = 0x0150
+	CARTRIDGE_CGB_SUPPORT           = uint8(0x80)
+	CARTRIDGE_CGB_ONLY              = uint8(0xc0)
+	CARTRIDGE_NINTENDO_LICENSEE     = uint8(0x01)
+	CARTRIDGE_USE_NEW_LICENSEE_CODE = uint8(0x33)
+	CARTRIDGE_NINTENDO_NEW_LICENSEE = "01"
+)
+
+// memory bank controllers
+const (
+	MBC_NONE = uint8(0)
+	MBC_1    = uint8(1)
+	MBC_2    = uint8(2)
+	MBC_3    = uint8(3)
+	MBC_5    = uint8(5)
+)
+
+// MBC3 real time clock registers (selected with RAM bank numbers 0x08 - 0x0c)
+const (
+	MBC3_RTC_FIRST_REGISTER = uint8(0x08)
+	MBC3_RTC_REGISTERS      = 5
+)
+
+// cartridge type byte: memory bank controller, external RAM and battery
+type cartridgeType struct {
+	name    string
+	mbc     uint8
+	ram     bool
+	battery bool
+	rtc     bool
+}
+
+// supported cartridge types
+var cartridgeTypeTable = map[uint8]cartridgeType{
+	0x00: {name: "ROM ONLY", mbc: MBC_NONE},
+	0x01: {name: "MBC1", mbc: MBC_1},
+	0x02: {name: "MBC1+RAM", mbc: MBC_1, ram: true},
+	0x03: {name: "MBC1+RAM+BATTERY", mbc: MBC_1, ram: true, battery: true},
+	0x05: {name: "MBC2", mbc: MBC_2, ram: true},
+	0x06: {name: "MBC2+BATTERY", mbc: MBC_2, ram: true, battery: true},
+	0x08: {name: "ROM+RAM", mbc: MBC_NONE, ram: true},
+	0x09: {name: "ROM+RAM+BATTERY", mbc: MBC_NONE, ram: true, battery: true},
+	0x0f: {name: "MBC3+TIMER+BATTERY", mbc: MBC_3, battery: true, rtc: true},
+	0x10: {name: "MBC3+TIMER+RAM+BATTERY", mbc: MBC_3, ram: true, battery: true, rtc: true},
+	0x11: {name: "MBC3", mbc: MBC_3},
+	0x12: {name: "MBC3+RAM", mbc: MBC_3, ram: true},
+	0x13: {name: "MBC3+RAM+BATTERY", mbc: MBC_3, ram: true, battery: true},
+	0x19: {name: "MBC5", mbc: MBC_5},
+	0x1a: {name: "MBC5+RAM", mbc: MBC_5, ram: true},
+	0x1b: {name: "MBC5+RAM+BATTERY", mbc: MBC_5, ram: true, battery: true},
+	0x1c: {name: "MBC5+RUMBLE", mbc: MBC_5},
+	0x1d: {name: "MBC5+RUMBLE+RAM", mbc: MBC_5, ram: true},
+	0x1e: {name: "MBC5+RUMBLE+RAM+BATTERY", mbc: MBC_5, ram: true, battery: true},
+}
+
+// external RAM size for every header code
+var cartridgeRAMSizeTable = map[uint8]int{
+	0x00: 0,
+	0x01: 0x0800,
+	0x02: 0x2000,
+	0x03: 0x8000,
+	0x04: 0x20000,
+	0x05: 0x10000,
+}
+
+// cartridge ROM, external RAM and memory bank controller registers
+type Cartridge struct {
+	rom       []uint8
+	ram       []uint8
+	cartridge cartridgeType
+
+	ramEnabled  bool
+	romBank     uint16
+	ramBank     uint8
+	bankingMode uint8
+
+	rtcRegisters [MBC3_RTC_REGISTERS]uint8
+	rtcLatched   [MBC3_RTC_REGISTERS]uint8
+	latchWrite   uint8
+}
+
+// create a new cartridge from a ROM image
+func NewCartridge(rom []uint8) (*Cartridge, error) {
+
+	if len(rom) < CARTRIDGE_HEADER_END_ADDRESS {
+		return nil, fmt.Errorf("ROM too small for a cartridge header: %d bytes", len(rom))
+	}
+
+	cartridge, ok := cartridgeTypeTable[rom[CARTRIDGE_TYPE_ADDRESS]]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cartridge type: 0x%02x", rom[CARTRIDGE_TYPE_ADDRESS])
+	}
+
+	ramSize, ok := cartridgeRAMSizeTable[rom[CARTRIDGE_RAM_SIZE_ADDRESS]]
+	if !ok {
+		return nil, fmt.Errorf("invalid cartridge RAM size: 0x%02x", rom[CARTRIDGE_RAM_SIZE_ADDRESS])
+	}
+	if cartridge.mbc == MBC_2 {
+		ramSize = MBC2_RAM_SIZE
+	}
+	if !cartridge.ram {
+		ramSize = 0
+	}
+
+	//	pad the ROM to whole banks
+	banks := max(2, (len(rom)+ROM_BANK_SIZE-1)/ROM_BANK_SIZE)
+	image := make([]uint8, banks*ROM_BANK_SIZE)
+	copy(image, rom)
+
+	return &Cartridge{
+		rom:       image,
+		ram:       make([]uint8, ramSize),
+		cartridge: cartridge,
+		romBank:   1,
+	}, nil
+}
+
+// return the cartridge title from the header
+func (c *Cartridge) Title() string {
+	var title = c.rom[CARTRIDGE_TITLE_ADDRESS : CARTRIDGE_TITLE_ADDRESS+CARTRIDGE_TITLE_LENGTH]
+
+	//	CGB titles are shorter, the last bytes hold the manufacturer code and the CGB flag
+	if title[CARTRIDGE_TITLE_LENGTH-1]&CARTRIDGE_CGB_SUPPORT != 0 {
+		title = title[:CARTRIDGE_TITLE_LENGTH-1]
+	}
+
+	return strings.TrimRight(string(title), "\x00 ")
+}
+
+// return the cartridge type name
+func (c *Cartridge) TypeName() string {
+	return c.cartridge.name
+}
+
+// return true if the cartridge supports CGB features
+func (c *Cartridge) CGBSupport() bool {
+	return c.rom[CARTRIDGE_CGB_FLAG_ADDRESS]&CARTRIDGE_CGB_SUPPORT != 0
+}
+
+// return true if the external RAM is kept by a battery
+func (c *Cartridge) HasBattery() bool {
+	return c.cartridge.battery && len(c.ram) > 0
+}
+
+// return the ROM image
+func (c *Cartridge) ROMImage() []uint8 {
+	return c.rom
+}
+
+// load the battery RAM from a save file (a missing file keeps the RAM cleared)
+func (c *Cartridge) LoadRAM(fileName string) error {
+
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.LoadRAMImage(data)
+}
+
+// load the battery RAM from an image (e.g. a save kept by a frontend)
+func (c *Cartridge) LoadRAMImage(data []uint8) error {
+
+	if len(data) != len(c.ram) {
+		return fmt.Errorf("invalid save file size: expected %d bytes, found %d", len(c.ram), len(data))
+	}
+	copy(c.ram, data)
+
+	return nil
+}
+
+// return a copy of the battery RAM
+func (c *Cartridge) RAMImage() []uint8 {
+	return append([]uint8(nil), c.ram...)
+}
+
+// write the battery RAM into a save file
+func (c *Cartridge) SaveRAM(fileName string) error {
+	if !c.HasBattery() {
+		return nil
+	}
+
+	return os.WriteFile(fileName, c.ram, 0644)
+}
+
+// write the external RAM and the memory bank controller registers
+func (c *Cartridge) SaveState(w *savestate.Writer) {
+	w.Section("MBC")
+
+	w.Bytes(c.ram)
+	w.Bool(c.ramEnabled)
+	w.Uint16(c.romBank)
+	w.Uint8(c.ramBank)
+	w.Uint8(c.bankingMode)
+	w.Bytes(c.rtcRegisters[:])
+	w.Bytes(c.rtcLatched[:])
+	w.Uint8(c.latchWrite)
+}
+
+// read the external RAM and the memory bank controller registers
+func (c *Cartridge) LoadState(r *savestate.Reader) error {
+	r.Section("MBC")
+
+	r.Bytes(c.ram)
+	c.ramEnabled = r.Bool()
+	c.romBank = r.Uint16()
+	c.ramBank = r.Uint8()
+	c.bankingMode = r.Uint8()
+	r.Bytes(c.rtcRegisters[:])
+	r.Bytes(c.rtcLatched[:])
+	c.latchWrite = r.Uint8()
+
+	return r.Err()
+}
+
+// number of ROM banks
+func (c *Cartridge) romBanks() int {
+	return len(c.rom) / ROM_BANK_SIZE
+}
+
+// ROM bank mapped at 0x0000 - 0x3fff (MBC1 mode 1 also maps the upper bank bits there)
+func (c *Cartridge) lowROMBank() int {
+	if c.cartridge.mbc == MBC_1 && c.bankingMode == 1 {
+		return (int(c.ramBank&0x03) << 5) % c.romBanks()
+	}
+
+	return 0
+}
+
+// ROM bank mapped at 0x4000 - 0x7fff
+func (c *Cartridge) highROMBank() int {
+	var bank = int(c.romBank)
+
+	if c.cartridge.mbc == MBC_1 {
+		bank = int(c.ramBank&0x03)<<5 | int(c.romBank)
+	}
+
+	return bank % c.romBanks()
+}
+
+// offset of the external RAM bank
+func (c *Cartridge) ramOffset(address uint16) int {
+	var bank = int(c.ramBank)
+
+	switch c.cartridge.mbc {
+	case MBC_1:
+		bank = 0
+		if c.bankingMode == 1 {
+			bank = int(c.ramBank & 0x03)
+		}
+	case MBC_2:
+		return int(address) % MBC2_RAM_SIZE
+	case MBC_NONE:
+		bank = 0
+	}
+
+	return (bank*RAM_BANK_SIZE + int(address)) % len(c.ram)
+}
+
+// read the cartridge ROM (0x0000 - 0x7fff)
+func (c *Cartridge) readROM(address uint16) uint8 {
+	if address < ROM_BANK_SIZE {
+		return c.rom[c.lowROMBank()*ROM_BANK_SIZE+int(address)]
+	}
+
+	return c.rom[c.highROMBank()*ROM_BANK_SIZE+int(address-ROM_BANK_SIZE)]
+}
+
+// write the memory bank controller registers (0x0000 - 0x7fff)
+func (c *Cartridge) writeROM(address uint16, value uint8) {
+
+	switch c.cartridge.mbc {
+	case MBC_1:
+		switch {
+		case address < 0x2000:
+			c.ramEnabled = value&0x0f == 0x0a
+		case address < 0x4000:
+			c.romBank = uint16(max(value&0x1f, 1))
+		case address < 0x6000:
+			c.ramBank = value & 0x03
+		default:
+			c.bankingMode = value & 0x01
+		}
+
+	case MBC_2:
+		if address >= 0x4000 {
+			return
+		}
+		//	address bit 8 selects between RAM enable and ROM bank
+		if address&0x0100 == 0 {
+			c.ramEnabled = value&0x0f == 0x0a
+		} else {
+			c.romBank = uint16(max(value&0x0f, 1))
+		}
+
+	case MBC_3:
+		switch {
+		case address < 0x2000:
+			c.ramEnabled = value&0x0f == 0x0a
+		case address < 0x4000:
+			c.romBank = uint16(max(value&0x7f, 1))
+		case address < 0x6000:
+			c.ramBank = value & 0x0f
+		default:
+			//	writing 0 then 1 latches the clock registers
+			if c.latchWrite == 0x00 && value == 0x01 {
+				c.rtcLatched = c.rtcRegisters
+			}
+			c.latchWrite = value
+		}
+
+	case MBC_5:
+		switch {
+		case address < 0x2000:
+			c.ramEnabled = value&0x0f == 0x0a
+		case address < 0x3000:
+			c.romBank = c.romBank&0x100 | uint16(value)
+		case address < 0x4000:
+			c.romBank = c.romBank&0x0ff | uint16(value&0x01)<<8
+		case address < 0x6000:
+			c.ramBank = value & 0x0f
+		}
+	}
+}
+
+// return true when the MBC3 clock registers are mapped into the RAM area
+func (c *Cartridge) rtcSelected() bool {
+	return c.cartridge.mbc == MBC_3 && c.cartridge.rtc && c.ramBank >= MBC3_RTC_FIRST_REGISTER
+}
+
+// read the external RAM (0xa000 - 0xbfff)
+func (c *Cartridge) readRAM(address uint16) uint8 {
+
+	if c.cartridge.mbc != MBC_NONE && !c.ramEnabled {
+		return 0xff
+	}
+
+	if c.rtcSelected() {
+		if int(c.ramBank-MBC3_RTC_FIRST_REGISTER) < MBC3_RTC_REGISTERS {
+			return c.rtcLatched[c.ramBank-MBC3_RTC_FIRST_REGISTER]
+		}
+		return 0xff
+	}
+
+	if len(c.ram) == 0 {
+		return 0xff
+	}
+
+	//	MBC2 RAM is made of 4 bit cells
+	if c.cartridge.mbc == MBC_2 {
+		return c.ram[c.ramOffset(address)] | 0xf0
+	}
+
+	return c.ram[c.ramOffset(address)]
+}
+
+// write the external RAM (0xa000 - 0xbfff)
+func (c *Cartridge) writeRAM(address uint16, value uint8) {
+
+	if c.cartridge.mbc != MBC_NONE && !c.ramEnabled {
+		return
+	}
+
+	if c.rtcSelected() {
+		if int(c.ramBank-MBC3_RTC_FIRST_REGISTER) < MBC3_RTC_REGISTERS {
+			c.rtcRegisters[c.ramBank-MBC3_RTC_FIRST_REGISTER] = value
+		}
+		return
+	}
+
+	if len(c.ram) == 0 {
+		return
+	}
+
+	if c.cartridge.mbc == MBC_2 {
+		value &= 0x0f
+	}
+
+	c.ram[c.ramOffset(address)] = value
+}
+
+// write a byte into an external RAM bank whatever the mapped bank and the RAM enable (address relative to 0xa000)
+func (c *Cartridge) WriteRAMBank(bank int, address uint16, value uint8) {
+
+	if len(c.ram) == 0 {
+		return
+	}
+
+	if c.cartridge.mbc == MBC_2 {
+		c.ram[int(address)%MBC2_RAM_SIZE] = value & 0x0f
+		return
+	}
+
+	c.ram[(bank*RAM_BANK_SIZE+int(address%RAM_BANK_SIZE))%len(c.ram)] = value
+}
+
+// return the cartridge ROM area as a memory bank (0x0000 - 0x7fff)
+func (c *Cartridge) ROM() memory.Memory {
+	return &cartridgeROM{cartridge: c}
+}
+
+// return the cartridge RAM area as a memory bank (0xa000 - 0xbfff)
+func (c *Cartridge) RAM() memory.Memory {
+	return &cartridgeRAM{cartridge: c}
+}
+
+// cartridge ROM area view: writes go to the memory bank controller
+type cartridgeROM struct {
+	cartridge *Cartridge
+}
+
+// return memory bank size
+func (m *cartridgeROM) Len() uint16 {
+	return CARTRIDGE_ROM_SIZE
+}
+
+// write a memory bank controller register
+func (m *cartridgeROM) WriteByte(address uint16, value uint8) error {
+	if address >= CARTRIDGE_ROM_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.cartridge.writeROM(address, value)
+
+	return nil
+}
+
+// read the cartridge ROM
+func (m *cartridgeROM) ReadByte(address uint16) (uint8, error) {
+	if address >= CARTRIDGE_ROM_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.cartridge.readROM(address), nil
+}
+
+// write a word into the cartridge ROM area
+func (m *cartridgeROM) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from the cartridge ROM
+func (m *cartridgeROM) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
+
+// cartridge RAM area view
+type cartridgeRAM struct {
+	cartridge *Cartridge
+}
+
+// return memory bank size
+func (m *cartridgeRAM) Len() uint16 {
+	return CARTRIDGE_RAM_SIZE
+}
+
+// write the cartridge RAM
+func (m *cartridgeRAM) WriteByte(address uint16, value uint8) error {
+	if address >= CARTRIDGE_RAM_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.cartridge.writeRAM(address, value)
+
+	return nil
+}
+
+// read the cartridge RAM
+func (m *cartridgeRAM) ReadByte(address uint16) (uint8, error) {
+	if address >= CARTRIDGE_RAM_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.cartridge.readRAM(address), nil
+}
+
+// write a word into the cartridge RAM
+func (m *cartridgeRAM) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from the cartridge RAM
+func (m *cartridgeRAM) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
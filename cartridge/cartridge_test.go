@@ -0,0 +1,227 @@
+////////////////////////////////////////////////////////////////////////////////
+//	cartridge_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy cartridge and memory bank controllers
+////////////////////////////////////////////////////////////////////////////////
+
+package cartridge
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// build a ROM image with the bank number written at the start of every bank
+func buildCartridgeROM(cartridgeType uint8, ramSize uint8, banks int) []uint8 {
+	rom := make([]uint8, banks*ROM_BANK_SIZE)
+
+	for bank := range banks {
+		rom[bank*ROM_BANK_SIZE] = uint8(bank)
+		rom[bank*ROM_BANK_SIZE+1] = uint8(bank >> 8)
+	}
+	copy(rom[CARTRIDGE_TITLE_ADDRESS:], "TEST")
+	rom[CARTRIDGE_TYPE_ADDRESS] = cartridgeType
+	rom[CARTRIDGE_RAM_SIZE_ADDRESS] = ramSize
+
+	return rom
+}
+
+// cartridge unit tests
+func Test_Cartridge(t *testing.T) {
+
+	t.Run(">>> cartridge: scenario 1 - header", func(t *testing.T) {
+
+		cartridge, err := NewCartridge(buildCartridgeROM(0x1b, 0x03, 4))
+		if err != nil {
+			t.Fatalf("failed creating cartridge: %v", err)
+		}
+
+		if cartridge.Title() != "TEST" || cartridge.TypeName() != "MBC5+RAM+BATTERY" || !cartridge.HasBattery() {
+			t.Errorf("failed reading header: expected: TEST, MBC5+RAM+BATTERY and battery\n\tresult: %s, %s and %v",
+				cartridge.Title(), cartridge.TypeName(), cartridge.HasBattery())
+		}
+
+		_, err = NewCartridge(buildCartridgeROM(0xfc, 0x00, 2))
+		if err == nil {
+			t.Errorf("failed rejecting an unsupported cartridge type")
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 2 - MBC1 ROM banks", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x01, 0x00, 64))
+		rom := cartridge.ROM()
+
+		for _, test := range []struct {
+			romBank  uint8
+			ramBank  uint8
+			expected uint8
+		}{
+			{romBank: 0x00, ramBank: 0x00, expected: 0x01},
+			{romBank: 0x05, ramBank: 0x00, expected: 0x05},
+			{romBank: 0x1f, ramBank: 0x01, expected: 0x3f},
+			{romBank: 0x20, ramBank: 0x00, expected: 0x01},
+		} {
+			rom.WriteByte(0x2000, test.romBank)
+			rom.WriteByte(0x4000, test.ramBank)
+
+			bank, _ := rom.ReadByte(ROM_BANK_SIZE)
+			if bank != test.expected {
+				t.Errorf("failed selecting ROM bank 0x%02x/0x%02x: expected: 0x%02x\n\tresult: 0x%02x",
+					test.romBank, test.ramBank, test.expected, bank)
+			}
+		}
+
+		bank, _ := rom.ReadByte(0x0000)
+		if bank != 0x00 {
+			t.Errorf("failed reading bank 0: expected: 0x00\n\tresult: 0x%02x", bank)
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 3 - MBC5 9 bit ROM bank", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x19, 0x00, 512))
+		rom := cartridge.ROM()
+
+		rom.WriteByte(0x2000, 0x23)
+		rom.WriteByte(0x3000, 0x01)
+
+		low, _ := rom.ReadByte(ROM_BANK_SIZE)
+		high, _ := rom.ReadByte(ROM_BANK_SIZE + 1)
+		if low != 0x23 || high != 0x01 {
+			t.Errorf("failed selecting ROM bank 0x123: expected: 0x23 0x01\n\tresult: 0x%02x 0x%02x", low, high)
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 4 - RAM enable and banks", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x1a, 0x03, 4))
+		rom := cartridge.ROM()
+		ram := cartridge.RAM()
+
+		ram.WriteByte(0x0000, 0x42)
+		value, _ := ram.ReadByte(0x0000)
+		if value != 0xff {
+			t.Errorf("failed reading disabled RAM: expected: 0xff\n\tresult: 0x%02x", value)
+		}
+
+		rom.WriteByte(0x0000, 0x0a)
+		for bank := range uint8(4) {
+			rom.WriteByte(0x4000, bank)
+			ram.WriteByte(0x0000, 0x10+bank)
+		}
+
+		rom.WriteByte(0x4000, 0x02)
+		value, _ = ram.ReadByte(0x0000)
+		if value != 0x12 {
+			t.Errorf("failed reading RAM bank 2: expected: 0x12\n\tresult: 0x%02x", value)
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 5 - MBC2 4 bit RAM", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x06, 0x00, 4))
+		rom := cartridge.ROM()
+		ram := cartridge.RAM()
+
+		rom.WriteByte(0x0000, 0x0a)
+		ram.WriteByte(0x0201, 0x5a)
+
+		value, _ := ram.ReadByte(0x0001)
+		if value != 0xfa {
+			t.Errorf("failed reading MBC2 RAM: expected: 0xfa\n\tresult: 0x%02x", value)
+		}
+
+		rom.WriteByte(0x0100, 0x03)
+		bank, _ := rom.ReadByte(ROM_BANK_SIZE)
+		if bank != 0x03 {
+			t.Errorf("failed selecting MBC2 ROM bank: expected: 0x03\n\tresult: 0x%02x", bank)
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 6 - MBC3 clock latch", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x10, 0x03, 4))
+		rom := cartridge.ROM()
+		ram := cartridge.RAM()
+
+		rom.WriteByte(0x0000, 0x0a)
+		rom.WriteByte(0x4000, MBC3_RTC_FIRST_REGISTER+2)
+		ram.WriteByte(0x0000, 0x17)
+
+		value, _ := ram.ReadByte(0x0000)
+		if value != 0x00 {
+			t.Errorf("failed reading unlatched clock: expected: 0x00\n\tresult: 0x%02x", value)
+		}
+
+		rom.WriteByte(0x6000, 0x00)
+		rom.WriteByte(0x6000, 0x01)
+		value, _ = ram.ReadByte(0x0000)
+		if value != 0x17 {
+			t.Errorf("failed reading latched clock: expected: 0x17\n\tresult: 0x%02x", value)
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 7 - battery RAM save file", func(t *testing.T) {
+
+		saveFile := filepath.Join(t.TempDir(), "test.sav")
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x03, 0x02, 4))
+		err := cartridge.LoadRAM(saveFile)
+		if err != nil {
+			t.Errorf("failed loading a missing save file: %v", err)
+		}
+
+		cartridge.ROM().WriteByte(0x0000, 0x0a)
+		cartridge.RAM().WriteByte(0x1234, 0x99)
+		err = cartridge.SaveRAM(saveFile)
+		if err != nil {
+			t.Fatalf("failed saving RAM: %v", err)
+		}
+
+		restored, _ := NewCartridge(buildCartridgeROM(0x03, 0x02, 4))
+		err = restored.LoadRAM(saveFile)
+		if err != nil {
+			t.Fatalf("failed loading RAM: %v", err)
+		}
+
+		restored.ROM().WriteByte(0x0000, 0x0a)
+		value, _ := restored.RAM().ReadByte(0x1234)
+		if value != 0x99 {
+			t.Errorf("failed restoring RAM: expected: 0x99\n\tresult: 0x%02x", value)
+		}
+
+		small, _ := NewCartridge(buildCartridgeROM(0x03, 0x01, 4))
+		err = small.LoadRAM(saveFile)
+		if err == nil {
+			t.Errorf("failed rejecting a save file of a different size")
+		}
+	})
+
+	t.Run(">>> cartridge: scenario 8 - battery RAM image", func(t *testing.T) {
+
+		cartridge, _ := NewCartridge(buildCartridgeROM(0x1b, 0x02, 4))
+		image := make([]uint8, RAM_BANK_SIZE)
+		image[0x0010] = 0x77
+
+		err := cartridge.LoadRAMImage(image)
+		if err != nil {
+			t.Fatalf("failed loading RAM image: %v", err)
+		}
+
+		//	the returned image is a copy of the RAM
+		saved := cartridge.RAMImage()
+		saved[0x0010] = 0x00
+
+		cartridge.ROM().WriteByte(0x0000, 0x0a)
+		value, _ := cartridge.RAM().ReadByte(0x0010)
+		if value != 0x77 || len(saved) != RAM_BANK_SIZE {
+			t.Errorf("failed reading RAM image: expected: 0x77 and %d bytes\n\tresult: 0x%02x and %d bytes", RAM_BANK_SIZE, value, len(saved))
+		}
+
+		err = cartridge.LoadRAMImage(image[:0x0800])
+		if err == nil {
+			t.Errorf("failed rejecting a RAM image of a different size")
+		}
+	})
+}
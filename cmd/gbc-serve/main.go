@@ -0,0 +1,111 @@
+////////////////////////////////////////////////////////////////////////////////
+//	main.go - Oct-19-2026 by aldebap
+//
+//	static server for the gbc WebAssembly frontend
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// server defaults
+const (
+	DEFAULT_ADDRESS   = "localhost:8080"
+	DEFAULT_WASM_FILE = "gbc.wasm"
+	WASM_EXEC_FILE    = "wasm_exec.js"
+)
+
+// host page
+//
+//go:embed web
+var webFiles embed.FS
+
+// locate wasm_exec.js in the Go installation (lib/wasm since Go 1.24, misc/wasm before)
+func wasmExecFile() (string, error) {
+
+	for _, directory := range []string{"lib", "misc"} {
+		fileName := filepath.Join(runtime.GOROOT(), directory, "wasm", WASM_EXEC_FILE)
+		if _, err := os.Stat(fileName); err == nil {
+			return fileName, nil
+		}
+	}
+
+	return "", fmt.Errorf("%s not found in %s", WASM_EXEC_FILE, runtime.GOROOT())
+}
+
+// create the handler serving the host page, the frontend and the Go WebAssembly support script
+func newHandler(wasmFile string, wasmExec string) (http.Handler, error) {
+
+	page, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServerFS(page))
+	mux.HandleFunc("/gbc.wasm", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/wasm")
+		http.ServeFile(w, r, wasmFile)
+	})
+	mux.HandleFunc("/"+WASM_EXEC_FILE, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, wasmExec)
+	})
+
+	return mux, nil
+}
+
+// parse the command line and serve the frontend
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+	var address, wasmFile string
+
+	flags := flag.NewFlagSet("gbc-serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&address, "addr", DEFAULT_ADDRESS, "listen address (host:port)")
+	flags.StringVar(&wasmFile, "wasm", DEFAULT_WASM_FILE, "WebAssembly frontend (GOOS=js GOARCH=wasm go build -o gbc.wasm ./cmd/gbc-wasm)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(wasmFile); errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s not found: build it with GOOS=js GOARCH=wasm go build -o %s ./cmd/gbc-wasm", wasmFile, wasmFile)
+	}
+
+	wasmExec, err := wasmExecFile()
+	if err != nil {
+		return err
+	}
+
+	handler, err := newHandler(wasmFile, wasmExec)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "serving gbc on http://%s\n", address)
+
+	return http.ListenAndServe(address, handler)
+}
+
+func main() {
+
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,251 @@
+////////////////////////////////////////////////////////////////////////////////
+//	main.go - Oct-19-2026 by aldebap
+//
+//	gbc terminal frontend: run a ROM drawing the screen with ANSI half blocks
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aldebap/go_gbc/ppu"
+	"github.com/aldebap/go_gbc/system"
+	"github.com/aldebap/go_gbc/terminal"
+)
+
+// rewind settings: while the rewind key is held every tick runs a frame and rewinds two, going back one frame per tick
+const (
+	REWIND_SECONDS    = 10
+	REWIND_PER_TICK   = 2
+	FRAMES_PER_SECOND = 60
+)
+
+// command line options
+type commandLine struct {
+	romFile         string
+	model           string
+	saveDir         string
+	palette         string
+	colorCorrection string
+	speed           float64
+	rewind          int
+	cheats          string
+	movieRecord     string
+}
+
+// parse the command line arguments
+func parseCommandLine(args []string, output io.Writer) (*commandLine, error) {
+	var options commandLine
+
+	flags := flag.NewFlagSet("gbc-term", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprintf(output, "usage: gbc-term [options] <rom file>\n")
+		fmt.Fprintf(output, "keys: arrows, x (A), z (B), enter (Start), space or backspace (Select), r (rewind), q (quit)\n")
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&options.model, "model", "auto", "Game Boy model: auto, dmg or cgb")
+	flags.StringVar(&options.saveDir, "save-dir", "", "directory of the battery save files (default: the ROM directory)")
+	flags.StringVar(&options.palette, "palette", "green", "DMG palette: green, grayscale, pocket or four hex colors")
+	flags.StringVar(&options.colorCorrection, "color-correction", "gbc", "CGB color correction: none, gbc or gamma")
+	flags.Float64Var(&options.speed, "speed", system.SPEED_NORMAL, "speed multiplier (0 runs unthrottled)")
+	flags.StringVar(&options.cheats, "cheats", "", "load Game Genie and GameShark cheats from a text file")
+	flags.IntVar(&options.rewind, "rewind", REWIND_SECONDS, "seconds that can be rewound (0 disables rewind)")
+	flags.StringVar(&options.movieRecord, "movie-record", "", "record an input movie of the keyboard (disables rewind)")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return nil, fmt.Errorf("missing ROM file")
+	}
+	options.romFile = flags.Arg(0)
+
+	//	a movie replays the frames in order, so they can't be rewound
+	if options.movieRecord != "" {
+		options.rewind = 0
+	}
+
+	return &options, nil
+}
+
+// battery save file of a ROM
+func saveFileName(options *commandLine) string {
+	var directory = options.saveDir
+
+	if directory == "" {
+		directory = filepath.Dir(options.romFile)
+	}
+	baseName := filepath.Base(options.romFile)
+
+	return filepath.Join(directory, strings.TrimSuffix(baseName, filepath.Ext(baseName))+".sav")
+}
+
+// create the system for the ROM in the command line
+func newSystem(options *commandLine, rom []uint8) (*system.System, *ppu.PostProcessor, error) {
+
+	model, err := system.ParseModel(options.model)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gbc, err := system.NewSystem(rom, model, nil, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = gbc.SetSpeed(options.speed)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if options.rewind > 0 {
+		err = gbc.EnableRewind(system.REWIND_INTERVAL, options.rewind*FRAMES_PER_SECOND, system.REWIND_BUDGET)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	err = gbc.Cartridge().LoadRAM(saveFileName(options))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if options.cheats != "" {
+		cheats, err := system.LoadCheats(options.cheats)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, cheat := range cheats {
+			gbc.AddCheat(cheat)
+		}
+	}
+
+	colorCorrection, err := ppu.ParseColorCorrection(options.colorCorrection)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	palette, err := ppu.ParseDMG_palette(options.palette)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	postProcessor, err := gbc.NewPostProcessor(colorCorrection, palette)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gbc, postProcessor, nil
+}
+
+// run the system drawing every frame until the quit key is typed (the frames end in the movie when recording)
+func runFrames(gbc *system.System, postProcessor *ppu.PostProcessor, input *terminal.Input, movie *system.MovieSession,
+	renderer *terminal.Renderer) error {
+	var screen = image.NewRGBA(image.Rect(0, 0, ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	gbc.ConnectFrameHandler(func(uint64) error {
+		if movie != nil {
+			err := movie.EndFrame(gbc.PPU().Framebuffer())
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Quit() {
+			cancel()
+			return nil
+		}
+
+		if input.Rewinding() && gbc.RewindFrames() >= REWIND_PER_TICK {
+			err := gbc.Rewind(REWIND_PER_TICK)
+			if err != nil {
+				return err
+			}
+		}
+
+		postProcessor.ProcessInto(gbc.PPU().Framebuffer(), screen)
+		_, err := renderer.Draw(screen)
+
+		return err
+	})
+
+	return gbc.Run(ctx)
+}
+
+// run the terminal frontend
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+
+	options, err := parseCommandLine(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	rom, err := os.ReadFile(options.romFile)
+	if err != nil {
+		return err
+	}
+
+	gbc, postProcessor, err := newSystem(options, rom)
+	if err != nil {
+		return err
+	}
+
+	restore, err := terminal.MakeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	var movie *system.MovieSession
+
+	input := terminal.NewInput()
+	if options.movieRecord != "" {
+		movie = system.NewMovieRecorder(rom, nil, gbc.Cheats(), map[string]string{"model": options.model}, input)
+		gbc.Joypad().ConnectInput(movie)
+	} else {
+		gbc.Joypad().ConnectInput(input)
+	}
+	go input.Listen(os.Stdin)
+
+	renderer := terminal.NewRenderer(stdout, ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT)
+	err = renderer.Start()
+	if err == nil {
+		err = runFrames(gbc, postProcessor, input, movie, renderer)
+	}
+	err = errors.Join(err, renderer.Stop(), restore(), gbc.Cartridge().SaveRAM(saveFileName(options)))
+
+	//	the movie is saved even when the emulation stops with an error
+	if movie != nil {
+		err = errors.Join(err, movie.Movie().Save(options.movieRecord))
+	}
+
+	return err
+}
+
+func main() {
+
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %s\n", err.Error())
+		os.Exit(1)
+	}
+}
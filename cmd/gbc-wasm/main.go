@@ -0,0 +1,424 @@
+////////////////////////////////////////////////////////////////////////////////
+//	main.go - Oct-19-2026 by aldebap
+//
+//	gbc WebAssembly frontend: runs a ROM in the browser rendering into a canvas
+////////////////////////////////////////////////////////////////////////////////
+
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"math"
+	"syscall/js"
+	"time"
+
+	"github.com/aldebap/go_gbc/apu"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/ppu"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// frontend settings
+const (
+	FRAME_DURATION_MS    = 1000.0 * ppu.FRAME_CYCLES / cpu.CPU_CLOCK_RATE
+	MAX_FRAMES_PER_TICK  = 4
+	AUDIO_LATENCY        = 100 * time.Millisecond
+	AUDIO_SCHEDULE_AHEAD = 0.05
+	SAVE_INTERVAL_FRAMES = 60
+	SAVE_KEY_PREFIX      = "gbc-save-"
+)
+
+// host page elements
+const (
+	CANVAS_ID   = "screen"
+	ROM_FILE_ID = "rom-file"
+	STATUS_ID   = "status"
+)
+
+// keyboard codes (KeyboardEvent.code) mapped to the joypad buttons
+var keyboardButtons = map[string]uint8{
+	"ArrowRight": system.BUTTON_RIGHT,
+	"ArrowLeft":  system.BUTTON_LEFT,
+	"ArrowUp":    system.BUTTON_UP,
+	"ArrowDown":  system.BUTTON_DOWN,
+	"KeyX":       system.BUTTON_A,
+	"KeyZ":       system.BUTTON_B,
+	"Backspace":  system.BUTTON_SELECT,
+	"ShiftRight": system.BUTTON_SELECT,
+	"Enter":      system.BUTTON_START,
+}
+
+// browser frontend: the running system and the canvas, audio and storage it is connected to
+type frontend struct {
+	document js.Value
+	canvas   js.Value
+	context  js.Value
+	pixels   js.Value
+	status   js.Value
+
+	system        *system.System
+	postProcessor *ppu.PostProcessor
+	screen        *image.RGBA
+	buttons       uint8
+
+	audioContext js.Value
+	resampler    *apu.AudioResampler
+	samples      []int16
+	audioBytes   []uint8
+	audioTime    float64
+
+	saveKey      string
+	saveChecksum uint32
+
+	lastTime float64
+	elapsed  float64
+	tick     js.Func
+	running  bool
+}
+
+// create the frontend connected to the host page elements
+func newFrontend() (*frontend, error) {
+	var document = js.Global().Get("document")
+
+	canvas := document.Call("getElementById", CANVAS_ID)
+	if canvas.IsNull() {
+		return nil, fmt.Errorf("missing canvas element: %s", CANVAS_ID)
+	}
+	canvas.Set("width", ppu.SCREEN_WIDTH)
+	canvas.Set("height", ppu.SCREEN_HEIGHT)
+
+	context := canvas.Call("getContext", "2d")
+
+	f := &frontend{
+		document: document,
+		canvas:   canvas,
+		context:  context,
+		pixels:   context.Call("createImageData", ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT),
+		status:   document.Call("getElementById", STATUS_ID),
+		screen:   image.NewRGBA(image.Rect(0, 0, ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT)),
+	}
+
+	return f, nil
+}
+
+// show a message in the status element (and in the console)
+func (f *frontend) showStatus(message string) {
+	js.Global().Get("console").Call("log", "[gbc] "+message)
+
+	if !f.status.IsNull() {
+		f.status.Set("textContent", message)
+	}
+}
+
+// register the ROM file input, keyboard and page lifecycle listeners
+func (f *frontend) connectEvents() error {
+
+	romFile := f.document.Call("getElementById", ROM_FILE_ID)
+	if romFile.IsNull() {
+		return fmt.Errorf("missing ROM file input: %s", ROM_FILE_ID)
+	}
+
+	romFile.Call("addEventListener", "change", js.FuncOf(func(this js.Value, args []js.Value) any {
+		files := romFile.Get("files")
+		if files.Length() == 0 {
+			return nil
+		}
+
+		//	the promise callbacks are created for every ROM file and released once one of them runs
+		var loaded, failed js.Func
+		release := func() {
+			loaded.Release()
+			failed.Release()
+		}
+
+		file := files.Index(0)
+		loaded = js.FuncOf(func(this js.Value, args []js.Value) any {
+			defer release()
+
+			data := js.Global().Get("Uint8Array").New(args[0])
+			rom := make([]uint8, data.Length())
+			js.CopyBytesToGo(rom, data)
+
+			err := f.loadROM(rom)
+			if err != nil {
+				f.showStatus("error: " + err.Error())
+				return nil
+			}
+			f.showStatus("running " + file.Get("name").String())
+
+			return nil
+		})
+		failed = js.FuncOf(func(this js.Value, args []js.Value) any {
+			defer release()
+
+			f.showStatus("error: " + args[0].Call("toString").String())
+			return nil
+		})
+		file.Call("arrayBuffer").Call("then", loaded, failed)
+
+		return nil
+	}))
+
+	window := js.Global()
+	window.Call("addEventListener", "keydown", js.FuncOf(func(this js.Value, args []js.Value) any {
+		return f.keyEvent(args[0], true)
+	}))
+	window.Call("addEventListener", "keyup", js.FuncOf(func(this js.Value, args []js.Value) any {
+		return f.keyEvent(args[0], false)
+	}))
+
+	//	the page may be closed at any time: keep the battery RAM up to date
+	window.Call("addEventListener", "pagehide", js.FuncOf(func(this js.Value, args []js.Value) any {
+		f.storeBatteryRAM()
+		return nil
+	}))
+
+	return nil
+}
+
+// update the pressed buttons from a keyboard event
+func (f *frontend) keyEvent(event js.Value, pressed bool) any {
+
+	button, ok := keyboardButtons[event.Get("code").String()]
+	if !ok {
+		return nil
+	}
+	event.Call("preventDefault")
+
+	if pressed {
+		f.buttons |= button
+	} else {
+		f.buttons &^= button
+	}
+
+	//	browsers only start the audio after a user gesture
+	if !f.audioContext.IsUndefined() && f.audioContext.Get("state").String() == "suspended" {
+		f.audioContext.Call("resume")
+	}
+
+	return nil
+}
+
+// create a system for a ROM, restore its battery RAM and start the animation loop
+func (f *frontend) loadROM(rom []uint8) error {
+
+	f.storeBatteryRAM()
+
+	gbc, err := system.NewSystem(rom, system.MODEL_AUTO, nil, false)
+	if err != nil {
+		return err
+	}
+
+	palette, _ := ppu.NewDMG_palettePreset(ppu.DMG_PALETTE_PRESET_GREEN)
+	f.postProcessor, err = gbc.NewPostProcessor(ppu.COLOR_CORRECTION_GBC_LCD, palette)
+	if err != nil {
+		return err
+	}
+
+	gbc.Joypad().ConnectInput(system.InputFunc(func(uint64) uint8 { return f.buttons }))
+
+	f.system = gbc
+	f.saveKey = fmt.Sprintf("%s%08x", SAVE_KEY_PREFIX, system.ROMChecksum(rom))
+	f.saveChecksum = crc32.ChecksumIEEE(gbc.Cartridge().RAMImage())
+	f.loadBatteryRAM()
+
+	err = f.connectAudio()
+	if err != nil {
+		f.showStatus("audio disabled: " + err.Error())
+	}
+
+	if f.tick.IsUndefined() {
+		f.tick = js.FuncOf(func(this js.Value, args []js.Value) any {
+			f.animationFrame(args[0].Float())
+			return nil
+		})
+	}
+	f.lastTime = 0
+	f.elapsed = 0
+
+	//	the loop stops after an error, so a new ROM starts it again
+	if !f.running {
+		f.running = true
+		js.Global().Call("requestAnimationFrame", f.tick)
+	}
+
+	return nil
+}
+
+// create the Web Audio context and a resampler to its sample rate
+func (f *frontend) connectAudio() error {
+	var err error
+
+	if f.audioContext.IsUndefined() {
+		audioContext := js.Global().Get("AudioContext")
+		if audioContext.IsUndefined() {
+			return fmt.Errorf("no Web Audio support")
+		}
+		f.audioContext = audioContext.New()
+	}
+
+	f.resampler, err = apu.NewAudioResampler(f.audioContext.Get("sampleRate").Int(), AUDIO_LATENCY, f.system.CGBMode())
+	if err != nil {
+		return err
+	}
+	f.system.APU().ConnectSink(f.resampler)
+	f.audioTime = 0
+
+	return nil
+}
+
+// restore the battery RAM kept in localStorage
+func (f *frontend) loadBatteryRAM() {
+
+	if !f.system.Cartridge().HasBattery() {
+		return
+	}
+
+	saved := js.Global().Get("localStorage").Call("getItem", f.saveKey)
+	if saved.IsNull() {
+		return
+	}
+
+	data, err := base64.StdEncoding.DecodeString(saved.String())
+	if err == nil {
+		err = f.system.Cartridge().LoadRAMImage(data)
+	}
+	if err != nil {
+		f.showStatus("battery RAM not restored: " + err.Error())
+		return
+	}
+
+	f.saveChecksum = crc32.ChecksumIEEE(data)
+}
+
+// keep the battery RAM in localStorage when it changed since the last store
+func (f *frontend) storeBatteryRAM() {
+
+	if f.system == nil || !f.system.Cartridge().HasBattery() {
+		return
+	}
+
+	data := f.system.Cartridge().RAMImage()
+	checksum := crc32.ChecksumIEEE(data)
+	if checksum == f.saveChecksum {
+		return
+	}
+
+	js.Global().Get("localStorage").Call("setItem", f.saveKey, base64.StdEncoding.EncodeToString(data))
+	f.saveChecksum = checksum
+}
+
+// requestAnimationFrame callback: run the frames due since the last callback (at most a few, so a hidden
+// tab does not have to catch up), then draw the screen and queue the audio; an emulation error stops the loop
+func (f *frontend) animationFrame(now float64) {
+
+	if f.lastTime != 0 {
+		f.elapsed += now - f.lastTime
+	}
+	f.lastTime = now
+
+	frames := 0
+	for f.elapsed >= FRAME_DURATION_MS && frames < MAX_FRAMES_PER_TICK {
+		err := f.system.RunFrame()
+		if err != nil {
+			f.running = false
+			f.draw()
+			f.showStatus("error: " + err.Error() + " (choose a ROM file to start again)")
+			return
+		}
+
+		f.elapsed -= FRAME_DURATION_MS
+		frames++
+
+		if f.system.Frame()%SAVE_INTERVAL_FRAMES == 0 {
+			f.storeBatteryRAM()
+		}
+	}
+	if frames == MAX_FRAMES_PER_TICK {
+		f.elapsed = 0
+	}
+
+	if frames > 0 {
+		f.draw()
+		f.queueAudio()
+	}
+
+	js.Global().Call("requestAnimationFrame", f.tick)
+}
+
+// copy the framebuffer into the canvas
+func (f *frontend) draw() {
+	f.postProcessor.ProcessInto(f.system.PPU().Framebuffer(), f.screen)
+
+	js.CopyBytesToJS(f.pixels.Get("data"), f.screen.Pix)
+	f.context.Call("putImageData", f.pixels, 0, 0)
+}
+
+// play the resampled audio as a buffer scheduled right after the previous one
+func (f *frontend) queueAudio() {
+
+	if f.resampler == nil {
+		return
+	}
+
+	frames := f.resampler.Buffered()
+	if frames == 0 {
+		return
+	}
+
+	if cap(f.samples) < 2*frames {
+		f.samples = make([]int16, 2*frames)
+		f.audioBytes = make([]uint8, 8*frames)
+	}
+	samples := f.samples[:2*frames]
+	f.resampler.ReadSamples(samples)
+
+	//	planar float32 channels: left samples followed by right samples
+	data := f.audioBytes[:8*frames]
+	for i := range frames {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(float32(samples[2*i])/32768))
+		binary.LittleEndian.PutUint32(data[4*(frames+i):], math.Float32bits(float32(samples[2*i+1])/32768))
+	}
+
+	bytes := js.Global().Get("Uint8Array").New(len(data))
+	js.CopyBytesToJS(bytes, data)
+	channels := js.Global().Get("Float32Array").New(bytes.Get("buffer"))
+
+	buffer := f.audioContext.Call("createBuffer", 2, frames, f.resampler.SampleRate())
+	buffer.Call("copyToChannel", channels.Call("subarray", 0, frames), 0)
+	buffer.Call("copyToChannel", channels.Call("subarray", frames, 2*frames), 1)
+
+	source := f.audioContext.Call("createBufferSource")
+	source.Set("buffer", buffer)
+	source.Call("connect", f.audioContext.Get("destination"))
+
+	currentTime := f.audioContext.Get("currentTime").Float()
+	if f.audioTime < currentTime {
+		f.audioTime = currentTime + AUDIO_SCHEDULE_AHEAD
+	}
+	source.Call("start", f.audioTime)
+	f.audioTime += buffer.Get("duration").Float()
+}
+
+func main() {
+
+	f, err := newFrontend()
+	if err == nil {
+		err = f.connectEvents()
+	}
+	if err != nil {
+		js.Global().Get("console").Call("error", "[gbc] "+err.Error())
+		return
+	}
+
+	f.showStatus("choose a ROM file")
+
+	//	keep the Go program alive for the callbacks
+	select {}
+}
@@ -0,0 +1,93 @@
+////////////////////////////////////////////////////////////////////////////////
+//	http.go - Oct-19-2026 by aldebap
+//
+//	gbc command line: HTTP server mode streaming the emulator to browsers
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/aldebap/go_gbc/ppu"
+	"github.com/aldebap/go_gbc/stream"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// serve the emulator over HTTP until interrupted, saving the battery RAM on reset and on exit
+// (the save state slots are files in the save directory) and the movie of the joypad events on exit
+func serveHTTP(options *commandLine, stdout io.Writer) error {
+	var current *session
+
+	newSystem := func() (*system.System, error) {
+		s, err := newSession(options, stdout)
+		if err != nil {
+			return nil, err
+		}
+
+		current = s
+		return s.system, nil
+	}
+
+	saveSystem := func(gbc *system.System) error {
+		if current.saveFile == "" {
+			return nil
+		}
+
+		return gbc.Cartridge().SaveRAM(current.saveFile)
+	}
+
+	colorCorrection, err := ppu.ParseColorCorrection(options.colorCorrection)
+	if err != nil {
+		return err
+	}
+
+	palette, err := ppu.ParseDMG_palette(options.palette)
+	if err != nil {
+		return err
+	}
+
+	server, err := stream.NewServer(newSystem, saveSystem, colorCorrection, palette, options.audioRate)
+	if err != nil {
+		return err
+	}
+
+	server.ConnectStateFiles(options.stateFile)
+
+	if options.movieRecord != "" {
+		err = server.RecordMovie(current.newMovieRecorder)
+		if err != nil {
+			return err
+		}
+	}
+
+	listener, err := net.Listen("tcp", options.httpAddress)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{Handler: server}
+	go httpServer.Serve(listener)
+
+	fmt.Fprintf(stdout, "serving gbc on http://%s\n", listener.Addr())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = server.Run(ctx)
+	err = errors.Join(err, httpServer.Shutdown(context.Background()))
+
+	//	the movie is saved even when the emulation stops with an error
+	if options.movieRecord != "" {
+		err = errors.Join(err, server.Movie().Save(options.movieRecord))
+	}
+
+	return err
+}
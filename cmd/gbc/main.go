@@ -0,0 +1,604 @@
+////////////////////////////////////////////////////////////////////////////////
+//	main.go - Oct-18-2026 by aldebap
+//
+//	gbc command line: load a ROM and run it headless
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aldebap/go_gbc/apu"
+	"github.com/aldebap/go_gbc/ppu"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// command line defaults
+const (
+	DEFAULT_FRAMES      = 600
+	DEFAULT_AUDIO_RATE  = 48000
+	SAVE_FILE_EXTENSION = ".sav"
+	NO_STATE_SLOT       = -1
+)
+
+// command line options
+type commandLine struct {
+	romFile   string
+	model     string
+	bootROM   string
+	trace     bool
+	saveDir   string
+	frames    int
+	untilPC   string
+	untilText string
+
+	serialOut   string
+	printerDir  string
+	linkListen  string
+	linkConnect string
+
+	screenshot      string
+	scale           int
+	palette         string
+	colorCorrection string
+	vramDebug       string
+
+	audioRecord   string
+	audioRate     int
+	audioChannels bool
+	audioStart    int
+	audioStop     int
+
+	movieRecord string
+	moviePlay   string
+	movieVerify string
+
+	stateLoad   int
+	stateSave   int
+	stateHashes string
+	cheats      string
+
+	httpAddress string
+}
+
+// parse the command line arguments
+func parseCommandLine(args []string, output io.Writer) (*commandLine, error) {
+	var options commandLine
+
+	flags := flag.NewFlagSet("gbc", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Usage = func() {
+		fmt.Fprintf(output, "usage: gbc [options] <rom file>\n")
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&options.model, "model", "auto", "Game Boy model: auto, dmg or cgb")
+	flags.StringVar(&options.bootROM, "boot-rom", "", "boot ROM file (the boot sequence is skipped when empty)")
+	flags.BoolVar(&options.trace, "trace", false, "trace the CPU instructions")
+	flags.StringVar(&options.saveDir, "save-dir", "", "directory of the battery save and save state files (default: the ROM directory)")
+	flags.IntVar(&options.frames, "frames", DEFAULT_FRAMES, "number of frames to run")
+	flags.StringVar(&options.untilPC, "until-pc", "", "stop when the program counter reaches an address (hex)")
+	flags.StringVar(&options.untilText, "until-serial", "", "stop when the serial output contains a text")
+
+	flags.StringVar(&options.serialOut, "serial-out", "", "write the serial output into a file (- for stdout)")
+	flags.StringVar(&options.printerDir, "printer", "", "connect a Game Boy Printer writing PNG files into a directory")
+	flags.StringVar(&options.linkListen, "link-listen", "", "wait for a link cable connection on an address (host:port)")
+	flags.StringVar(&options.linkConnect, "link-connect", "", "connect a link cable to an address (host:port)")
+
+	flags.StringVar(&options.screenshot, "screenshot", "", "save a PNG screenshot of the last frame")
+	flags.IntVar(&options.scale, "scale", 1, "screenshot scale factor")
+	flags.StringVar(&options.palette, "palette", "green", "DMG palette: green, grayscale, pocket or four hex colors")
+	flags.StringVar(&options.colorCorrection, "color-correction", "none", "CGB color correction: none, gbc or gamma")
+	flags.StringVar(&options.vramDebug, "vram-debug", "", "export the VRAM debug views into a directory")
+
+	flags.StringVar(&options.audioRecord, "audio-record", "", "record the audio output into a WAV file")
+	flags.IntVar(&options.audioRate, "audio-rate", DEFAULT_AUDIO_RATE, "sample rate of the recorded or streamed audio")
+	flags.BoolVar(&options.audioChannels, "audio-channels", false, "also record every channel into its own WAV file")
+	flags.IntVar(&options.audioStart, "audio-start", 0, "first recorded frame")
+	flags.IntVar(&options.audioStop, "audio-stop", apu.WAV_RECORD_FOREVER, "frame where the recording stops (-1 records until the end)")
+
+	flags.StringVar(&options.movieRecord, "movie-record", "", "record an input movie of the joypad events of the HTTP server")
+	flags.StringVar(&options.moviePlay, "movie-play", "", "replay an input movie")
+	flags.StringVar(&options.movieVerify, "movie-verify", "", "replay an input movie failing when a frame diverges")
+
+	flags.IntVar(&options.stateLoad, "state-load", NO_STATE_SLOT, fmt.Sprintf("load a save state slot (0-%d) before running", system.STATE_SLOTS-1))
+	flags.StringVar(&options.cheats, "cheats", "", "load Game Genie and GameShark cheats from a text file")
+	flags.StringVar(&options.stateHashes, "state-hashes", "", "write the state hash of every frame into a file (- for stdout)")
+	flags.IntVar(&options.stateSave, "state-save", NO_STATE_SLOT, fmt.Sprintf("save the state into a slot (0-%d) after running", system.STATE_SLOTS-1))
+
+	flags.StringVar(&options.httpAddress, "http", "", "stream the emulator to browsers on an address (host:port) until interrupted")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return nil, fmt.Errorf("missing ROM file")
+	}
+	options.romFile = flags.Arg(0)
+
+	//	the serial port has a single peer
+	peers := 0
+	for _, peer := range []bool{options.serialOut != "" || options.untilText != "", options.printerDir != "",
+		options.linkListen != "", options.linkConnect != ""} {
+		if peer {
+			peers++
+		}
+	}
+	if peers > 1 {
+		return nil, fmt.Errorf("only one of serial capture, printer and link cable can be connected")
+	}
+
+	movies := 0
+	for _, movie := range []string{options.movieRecord, options.moviePlay, options.movieVerify} {
+		if movie != "" {
+			movies++
+		}
+	}
+	if movies > 1 {
+		return nil, fmt.Errorf("only one of movie record, play and verify can be used")
+	}
+
+	for _, slot := range []int{options.stateLoad, options.stateSave} {
+		if slot != NO_STATE_SLOT && (slot < 0 || slot >= system.STATE_SLOTS) {
+			return nil, fmt.Errorf("invalid save state slot: %d (expected 0-%d)", slot, system.STATE_SLOTS-1)
+		}
+	}
+
+	//	a movie is recorded and replayed from the power on
+	if options.stateLoad != NO_STATE_SLOT && movies > 0 {
+		return nil, fmt.Errorf("a save state can't be loaded when recording or replaying a movie")
+	}
+
+	//	the headless mode has no joypad input to record
+	if options.movieRecord != "" && options.httpAddress == "" {
+		return nil, fmt.Errorf("a movie can only be recorded from the joypad events of the HTTP server")
+	}
+
+	//	the streaming server runs until interrupted with the joypad and the save state slots driven by the browsers
+	if options.httpAddress != "" && (peers > 0 || options.moviePlay != "" || options.movieVerify != "" || options.untilPC != "" ||
+		options.audioRecord != "" || options.stateLoad != NO_STATE_SLOT || options.stateSave != NO_STATE_SLOT || options.stateHashes != "") {
+		return nil, fmt.Errorf("the HTTP server can't be combined with serial peers, movie replays, stop conditions, audio recording, save state slots or state hashes")
+	}
+
+	return &options, nil
+}
+
+// emulator session: the system and everything connected to it
+type session struct {
+	options *commandLine
+	system  *system.System
+	rom     []uint8
+	bootROM []uint8
+
+	capture  *system.SerialCapture
+	printer  *system.GameBoyPrinter
+	link     *system.LinkCable
+	recorder *apu.AudioRecorder
+	movie    *system.MovieSession
+	hashes   *bufio.Writer
+
+	untilPC  uint16
+	saveFile string
+	closers  []io.Closer
+}
+
+// load the ROM and create the system with the peripherals requested in the command line
+func newSession(options *commandLine, stdout io.Writer) (*session, error) {
+	var bootROM []uint8
+	var movie *system.Movie
+
+	rom, err := os.ReadFile(options.romFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.bootROM != "" {
+		bootROM, err = os.ReadFile(options.bootROM)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	//	a movie is replayed with the model it was recorded with
+	moviePlayFile := options.moviePlay + options.movieVerify
+	if moviePlayFile != "" {
+		movie, err = system.LoadMovie(moviePlayFile)
+		if err != nil {
+			return nil, err
+		}
+		if model, ok := movie.Setting("model"); ok {
+			options.model = model
+		}
+	}
+
+	model, err := system.ParseModel(options.model)
+	if err != nil {
+		return nil, err
+	}
+
+	gbc, err := system.NewSystem(rom, model, bootROM, options.trace)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &session{
+		options: options,
+		system:  gbc,
+		rom:     rom,
+		bootROM: bootROM,
+	}
+
+	if options.untilPC != "" {
+		address, err := strconv.ParseUint(strings.TrimPrefix(options.untilPC, "0x"), 16, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %s", options.untilPC)
+		}
+		s.untilPC = uint16(address)
+	}
+
+	err = s.loadBatteryRAM()
+	if err == nil && options.cheats != "" {
+		err = s.loadCheats()
+	}
+	if err == nil && options.stateLoad != NO_STATE_SLOT {
+		err = gbc.LoadStateFile(options.stateFile(options.stateLoad))
+	}
+	if err == nil {
+		err = s.connectSerialPeer(stdout)
+	}
+	if err == nil {
+		err = s.connectAudioRecorder()
+	}
+	if err == nil {
+		err = s.openStateHashes(stdout)
+	}
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	if movie != nil {
+		s.movie, err = system.NewMoviePlayer(movie, rom, bootROM, gbc.Cheats(), options.movieVerify != "")
+		if err != nil {
+			s.Close()
+			return nil, err
+		}
+		gbc.Joypad().ConnectInput(s.movie)
+	}
+
+	return s, nil
+}
+
+// create a movie recorder of the session reading the buttons from an input
+func (s *session) newMovieRecorder(input system.InputSource) *system.MovieSession {
+	return system.NewMovieRecorder(s.rom, s.bootROM, s.system.Cheats(), map[string]string{"model": s.options.model}, input)
+}
+
+// return the name of a file in the save directory named after the ROM
+func (o *commandLine) saveDirFile(extension string) string {
+
+	directory := o.saveDir
+	if directory == "" {
+		directory = filepath.Dir(o.romFile)
+	}
+
+	baseName := filepath.Base(o.romFile)
+
+	return filepath.Join(directory, strings.TrimSuffix(baseName, filepath.Ext(baseName))+extension)
+}
+
+// return the file of a save state slot, e.g. game.ss1
+func (o *commandLine) stateFile(slot int) string {
+	return o.saveDirFile(fmt.Sprintf(".ss%d", slot))
+}
+
+// load the battery RAM from the save directory
+func (s *session) loadBatteryRAM() error {
+	if !s.system.Cartridge().HasBattery() {
+		return nil
+	}
+
+	s.saveFile = s.options.saveDirFile(SAVE_FILE_EXTENSION)
+
+	return s.system.Cartridge().LoadRAM(s.saveFile)
+}
+
+// connect the serial capture, the printer or the link cable
+func (s *session) connectSerialPeer(stdout io.Writer) error {
+	var err error
+	var serial = s.system.Serial()
+
+	switch {
+	case s.options.serialOut != "" || s.options.untilText != "":
+		var writer io.Writer
+
+		switch s.options.serialOut {
+		case "":
+		case "-":
+			writer = stdout
+		default:
+			file, err := os.Create(s.options.serialOut)
+			if err != nil {
+				return err
+			}
+			s.closers = append(s.closers, file)
+			writer = file
+		}
+
+		s.capture = system.NewSerialCapture(writer)
+		serial.ConnectPeer(s.capture)
+
+	case s.options.printerDir != "":
+		err = os.MkdirAll(s.options.printerDir, 0755)
+		if err != nil {
+			return err
+		}
+
+		s.printer = system.NewGameBoyPrinter(s.options.printerDir)
+		serial.ConnectPeer(s.printer)
+
+	case s.options.linkListen != "":
+		s.link, err = system.ListenLink(s.options.linkListen, serial)
+
+	case s.options.linkConnect != "":
+		s.link, err = system.DialLink(s.options.linkConnect, serial)
+	}
+
+	if s.link != nil {
+		s.closers = append(s.closers, s.link)
+	}
+
+	return err
+}
+
+// connect the audio recorder to the APU
+func (s *session) connectAudioRecorder() error {
+	var err error
+
+	if s.options.audioRecord == "" {
+		return nil
+	}
+
+	s.recorder, err = apu.NewAudioRecorder(s.options.audioRecord, s.options.audioRate, s.options.audioChannels,
+		s.options.audioStart, s.options.audioStop, s.system.CGBMode())
+	if err != nil {
+		return err
+	}
+
+	s.system.APU().ConnectSink(s.recorder)
+	if s.options.audioChannels {
+		s.system.APU().ConnectChannelSink(s.recorder)
+	}
+
+	return nil
+}
+
+// load the cheats file
+func (s *session) loadCheats() error {
+
+	cheats, err := system.LoadCheats(s.options.cheats)
+	if err != nil {
+		return err
+	}
+
+	for _, cheat := range cheats {
+		s.system.AddCheat(cheat)
+	}
+
+	return nil
+}
+
+// open the state hashes output
+func (s *session) openStateHashes(stdout io.Writer) error {
+
+	switch s.options.stateHashes {
+	case "":
+		return nil
+
+	case "-":
+		s.hashes = bufio.NewWriter(stdout)
+
+	default:
+		file, err := os.Create(s.options.stateHashes)
+		if err != nil {
+			return err
+		}
+		s.closers = append(s.closers, file)
+		s.hashes = bufio.NewWriter(file)
+	}
+
+	return nil
+}
+
+// return true when the stop condition was reached
+func (s *session) conditionReached() bool {
+
+	if s.options.untilPC != "" && s.system.CPU().PC() == s.untilPC {
+		return true
+	}
+
+	return s.options.untilText != "" && strings.Contains(s.capture.String(), s.options.untilText)
+}
+
+// run the frames until the stop condition
+func (s *session) Run() error {
+
+	for range s.options.frames {
+		err := s.runFrame()
+		if err != nil {
+			return err
+		}
+
+		if s.conditionReached() {
+			return nil
+		}
+		if s.movie != nil && s.movie.Finished() {
+			return nil
+		}
+	}
+
+	if s.options.untilPC != "" || s.options.untilText != "" {
+		return fmt.Errorf("stop condition not reached after %d frames", s.options.frames)
+	}
+
+	return nil
+}
+
+// run one frame and everything synchronized with it
+func (s *session) runFrame() error {
+
+	if s.options.untilPC == "" {
+		err := s.system.RunFrame()
+		if err != nil {
+			return err
+		}
+	} else {
+		err := s.system.RunFrameUntilPC(s.untilPC)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.recorder != nil {
+		err := s.recorder.EndFrame()
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.movie != nil {
+		err := s.movie.EndFrame(s.system.PPU().Framebuffer())
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.hashes != nil {
+		_, err := fmt.Fprintf(s.hashes, "%d %016x\n", s.system.Frame(), s.system.StateHash())
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.link != nil {
+		return s.link.Sync()
+	}
+
+	return nil
+}
+
+// write the outputs requested in the command line
+func (s *session) writeOutputs() error {
+	var result error
+
+	if s.saveFile != "" {
+		result = errors.Join(result, s.system.Cartridge().SaveRAM(s.saveFile))
+	}
+
+	if s.hashes != nil {
+		result = errors.Join(result, s.hashes.Flush())
+	}
+
+	if s.options.stateSave != NO_STATE_SLOT {
+		result = errors.Join(result, s.system.SaveStateFile(s.options.stateFile(s.options.stateSave)))
+	}
+
+	if s.printer != nil {
+		result = errors.Join(result, s.printer.Flush())
+	}
+
+	if s.options.screenshot != "" || s.options.vramDebug != "" {
+		postProcessor, err := s.postProcessor()
+		if err != nil {
+			return errors.Join(result, err)
+		}
+
+		if s.options.screenshot != "" {
+			result = errors.Join(result, ppu.SaveScreenshot(s.options.screenshot, s.system.PPU().Framebuffer(), postProcessor, s.options.scale))
+		}
+		if s.options.vramDebug != "" {
+			result = errors.Join(result, s.system.PPU().ExportVRAMDebug(s.options.vramDebug, postProcessor))
+		}
+	}
+
+	return result
+}
+
+// create the post processor from the command line options
+func (s *session) postProcessor() (*ppu.PostProcessor, error) {
+
+	colorCorrection, err := ppu.ParseColorCorrection(s.options.colorCorrection)
+	if err != nil {
+		return nil, err
+	}
+
+	palette, err := ppu.ParseDMG_palette(s.options.palette)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.system.NewPostProcessor(colorCorrection, palette)
+}
+
+// close the files and connections
+func (s *session) Close() error {
+	var result error
+
+	if s.recorder != nil {
+		result = errors.Join(result, s.recorder.Close())
+	}
+
+	for _, closer := range s.closers {
+		result = errors.Join(result, closer.Close())
+	}
+
+	return result
+}
+
+// run the command line
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+
+	options, err := parseCommandLine(args, stderr)
+	if err != nil {
+		return err
+	}
+
+	if options.httpAddress != "" {
+		return serveHTTP(options, stdout)
+	}
+
+	s, err := newSession(options, stdout)
+	if err != nil {
+		return err
+	}
+
+	//	the outputs are written even when the emulation stops with an error
+	err = s.Run()
+	err = errors.Join(err, s.writeOutputs())
+
+	return errors.Join(err, s.Close())
+}
+
+func main() {
+
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[error] %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,260 @@
+////////////////////////////////////////////////////////////////////////////////
+//	main_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the gbc command line
+////////////////////////////////////////////////////////////////////////////////
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cartridge"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// record a movie pressing buttons on a few frames of a ROM
+func recordTestMovie(t *testing.T, romFile string, movieFile string, model string, cheats []*system.Cheat) {
+
+	rom, _ := os.ReadFile(romFile)
+	modelCode, _ := system.ParseModel(model)
+	gbc, err := system.NewSystem(rom, modelCode, nil, false)
+	if err != nil {
+		t.Fatalf("failed creating the system: %v", err)
+	}
+	for _, cheat := range cheats {
+		gbc.AddCheat(cheat)
+	}
+
+	recorder := system.NewMovieRecorder(rom, nil, gbc.Cheats(), map[string]string{"model": model}, system.InputFunc(func(frame uint64) uint8 {
+		if frame >= 2 {
+			return system.BUTTON_START
+		}
+		return 0
+	}))
+	gbc.Joypad().ConnectInput(recorder)
+
+	for range 5 {
+		err = gbc.RunFrame()
+		if err == nil {
+			err = recorder.EndFrame(gbc.PPU().Framebuffer())
+		}
+		if err != nil {
+			t.Fatalf("failed recording the movie: %v", err)
+		}
+	}
+
+	err = recorder.Movie().Save(movieFile)
+	if err != nil {
+		t.Fatalf("failed saving the movie: %v", err)
+	}
+}
+
+// write a 32KB ROM into a directory: it sends "OK" through the serial port, counts in the work RAM and loops
+func writeTestROM(t *testing.T, directory string) string {
+	var rom = make([]uint8, 2*cartridge.ROM_BANK_SIZE)
+
+	copy(rom[cartridge.CARTRIDGE_TITLE_ADDRESS:], "CMDTEST")
+	copy(rom[system.CARTRIDGE_ENTRY:], []uint8{
+		cpu.LD_A_n, 'O', cpu.LDH_ADDR_n_A, 0x01,
+		cpu.LD_A_n, 0x81, cpu.LDH_ADDR_n_A, 0x02,
+		cpu.LDH_A_ADDR_n, 0x02, cpu.AND_n, 0x80, cpu.JR_NZ_e, 0xfa,
+		cpu.LD_A_n, 'K', cpu.LDH_ADDR_n_A, 0x01,
+		cpu.LD_A_n, 0x81, cpu.LDH_ADDR_n_A, 0x02,
+		cpu.LD_A_ADDR_nn, 0x00, 0xc0,
+		cpu.INC_A,
+		cpu.LD_ADDR_nn_A, 0x00, 0xc0,
+		cpu.JR_e, 0xf7,
+	})
+
+	fileName := filepath.Join(directory, "test.gb")
+
+	err := os.WriteFile(fileName, rom, 0644)
+	if err != nil {
+		t.Fatalf("failed writing the ROM: %v", err)
+	}
+
+	return fileName
+}
+
+// command line parsing unit tests
+func Test_ParseCommandLine(t *testing.T) {
+
+	t.Run(">>> command line: scenario 1 - defaults", func(t *testing.T) {
+
+		options, err := parseCommandLine([]string{"game.gb"}, io.Discard)
+		if err != nil {
+			t.Fatalf("failed parsing the command line: %v", err)
+		}
+
+		if options.romFile != "game.gb" || options.model != "auto" || options.frames != DEFAULT_FRAMES ||
+			options.stateLoad != NO_STATE_SLOT || options.stateSave != NO_STATE_SLOT || options.audioRate != DEFAULT_AUDIO_RATE {
+			t.Errorf("failed parsing the defaults: result: %+v", options)
+		}
+
+		if options.stateFile(3) != filepath.Join(".", "game.ss3") {
+			t.Errorf("failed naming the state file: expected: game.ss3\n\tresult: %s", options.stateFile(3))
+		}
+
+		options, _ = parseCommandLine([]string{"-save-dir", "saves", "roms/game.gbc"}, io.Discard)
+		if options.saveDirFile(SAVE_FILE_EXTENSION) != filepath.Join("saves", "game.sav") {
+			t.Errorf("failed naming the save file: expected: saves/game.sav\n\tresult: %s", options.saveDirFile(SAVE_FILE_EXTENSION))
+		}
+	})
+
+	t.Run(">>> command line: scenario 2 - invalid combinations", func(t *testing.T) {
+
+		for _, test := range []struct {
+			args     []string
+			expected string
+		}{
+			{args: []string{}, expected: "missing ROM file"},
+			{args: []string{"a.gb", "b.gb"}, expected: "missing ROM file"},
+			{args: []string{"-serial-out", "-", "-printer", "out", "game.gb"}, expected: "only one of serial capture"},
+			{args: []string{"-until-serial", "ok", "-link-listen", ":8765", "game.gb"}, expected: "only one of serial capture"},
+			{args: []string{"-movie-record", "a.mov", "-movie-play", "b.mov", "game.gb"}, expected: "only one of movie"},
+			{args: []string{"-state-load", "10", "game.gb"}, expected: "invalid save state slot: 10"},
+			{args: []string{"-state-save", "-2", "game.gb"}, expected: "invalid save state slot: -2"},
+			{args: []string{"-state-load", "1", "-movie-verify", "a.mov", "game.gb"}, expected: "can't be loaded when recording or replaying"},
+			{args: []string{"-state-load", "1", "-movie-record", "a.mov", "game.gb"}, expected: "can't be loaded when recording or replaying"},
+			{args: []string{"-movie-record", "a.mov", "game.gb"}, expected: "only be recorded from the joypad events of the HTTP server"},
+			{args: []string{"-http", ":8080", "-movie-play", "a.mov", "game.gb"}, expected: "HTTP server can't be combined"},
+			{args: []string{"-http", ":8080", "-state-save", "1", "game.gb"}, expected: "HTTP server can't be combined"},
+			{args: []string{"-http", ":8080", "-until-pc", "0150", "game.gb"}, expected: "HTTP server can't be combined"},
+			{args: []string{"-frames", "many", "game.gb"}, expected: "invalid value"},
+		} {
+			_, err := parseCommandLine(test.args, io.Discard)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("failed rejecting %v: expected: %s\n\tresult: %v", test.args, test.expected, err)
+			}
+		}
+
+		_, err := parseCommandLine([]string{"-h"}, io.Discard)
+		if !errors.Is(err, flag.ErrHelp) {
+			t.Errorf("failed asking for help: expected: %v\n\tresult: %v", flag.ErrHelp, err)
+		}
+	})
+}
+
+// command line run unit tests
+func Test_Run(t *testing.T) {
+
+	t.Run(">>> run: scenario 1 - serial output and stop condition", func(t *testing.T) {
+
+		var stdout bytes.Buffer
+
+		romFile := writeTestROM(t, t.TempDir())
+
+		err := run([]string{"-serial-out", "-", "-until-serial", "OK", "-frames", "10", romFile}, &stdout, io.Discard)
+		if err != nil || stdout.String() != "OK" {
+			t.Errorf("failed running until the serial output: expected: OK\n\tresult: %q (%v)", stdout.String(), err)
+		}
+
+		err = run([]string{"-until-serial", "KO", "-frames", "3", romFile}, io.Discard, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), "stop condition not reached after 3 frames") {
+			t.Errorf("failed reporting the stop condition: result: %v", err)
+		}
+
+		err = run([]string{"-until-pc", "0x0111", "-frames", "1", romFile}, io.Discard, io.Discard)
+		if err != nil {
+			t.Errorf("failed running until the program counter: %v", err)
+		}
+	})
+
+	t.Run(">>> run: scenario 2 - save states and state hashes", func(t *testing.T) {
+
+		var hashes bytes.Buffer
+
+		directory := t.TempDir()
+		romFile := writeTestROM(t, directory)
+
+		err := run([]string{"-frames", "4", "-state-save", "2", romFile}, io.Discard, io.Discard)
+		if err != nil {
+			t.Fatalf("failed saving the state: %v", err)
+		}
+		_, err = os.Stat(filepath.Join(directory, "test.ss2"))
+		if err != nil {
+			t.Fatalf("failed writing the state file: %v", err)
+		}
+
+		err = run([]string{"-frames", "2", "-state-load", "2", "-state-hashes", "-", romFile}, &hashes, io.Discard)
+		lines := strings.Split(strings.TrimSpace(hashes.String()), "\n")
+		if err != nil || len(lines) != 2 || !strings.HasPrefix(lines[0], "5 ") || !strings.HasPrefix(lines[1], "6 ") {
+			t.Errorf("failed running from the state: expected: hashes of frames 5 and 6\n\tresult: %q (%v)", hashes.String(), err)
+		}
+
+		err = run([]string{"-state-load", "7", romFile}, io.Discard, io.Discard)
+		if err == nil {
+			t.Errorf("failed reporting a missing state slot")
+		}
+	})
+
+	t.Run(">>> run: scenario 3 - verify a movie", func(t *testing.T) {
+
+		directory := t.TempDir()
+		romFile := writeTestROM(t, directory)
+		movieFile := filepath.Join(directory, "test.gbm")
+		screenshot := filepath.Join(directory, "test.png")
+
+		recordTestMovie(t, romFile, movieFile, "cgb", nil)
+
+		err := run([]string{"-movie-verify", movieFile, "-screenshot", screenshot, romFile}, io.Discard, io.Discard)
+		if err != nil {
+			t.Errorf("failed verifying the movie: %v", err)
+		}
+		_, err = os.Stat(screenshot)
+		if err != nil {
+			t.Errorf("failed saving the screenshot: %v", err)
+		}
+
+		//	the cheats are part of the movie setup
+		cheatsFile := filepath.Join(directory, "test.cht")
+		os.WriteFile(cheatsFile, []byte("01FFC0C0 counter\n"), 0644)
+
+		cheat, _ := system.ParseCheat("01FFC0C0", "counter")
+		recordTestMovie(t, romFile, movieFile, "dmg", []*system.Cheat{cheat})
+
+		err = run([]string{"-movie-verify", movieFile, romFile}, io.Discard, io.Discard)
+		if err == nil || !strings.Contains(err.Error(), "movie recorded with other cheats") {
+			t.Errorf("failed checking the movie cheats: result: %v", err)
+		}
+
+		err = run([]string{"-cheats", cheatsFile, "-movie-verify", movieFile, romFile}, io.Discard, io.Discard)
+		if err != nil {
+			t.Errorf("failed verifying the movie with cheats: %v", err)
+		}
+	})
+
+	t.Run(">>> run: scenario 4 - setup errors", func(t *testing.T) {
+
+		directory := t.TempDir()
+		romFile := writeTestROM(t, directory)
+		cheatsFile := filepath.Join(directory, "test.cht")
+		os.WriteFile(cheatsFile, []byte("ZZZ-ZZZ bad\n"), 0644)
+
+		for _, test := range []struct {
+			args     []string
+			expected string
+		}{
+			{args: []string{filepath.Join(directory, "missing.gb")}, expected: "missing.gb"},
+			{args: []string{"-model", "gba", romFile}, expected: "gba"},
+			{args: []string{"-boot-rom", filepath.Join(directory, "missing.bin"), romFile}, expected: "missing.bin"},
+			{args: []string{"-cheats", cheatsFile, romFile}, expected: "test.cht:1"},
+			{args: []string{"-until-pc", "xyz", romFile}, expected: "invalid address: xyz"},
+			{args: []string{"-movie-play", filepath.Join(directory, "missing.gbm"), romFile}, expected: "missing.gbm"},
+		} {
+			err := run(test.args, io.Discard, io.Discard)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("failed rejecting %v: expected: %s\n\tresult: %v", test.args, test.expected, err)
+			}
+		}
+	})
+}
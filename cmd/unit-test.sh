@@ -29,11 +29,15 @@ function unitTestTarget {
 }
 
 TARGET=unit-test
-PACKAGE_TARGET=github.com/aldebap/go_gbc
 
-unitTestTarget
+for PACKAGE_TARGET in memory cpu cartridge ppu apu system savestate terminal stream cmd/gbc
+do
+    PACKAGE_TARGET=github.com/aldebap/go_gbc/${PACKAGE_TARGET}
+
+    unitTestTarget
+done
 
 TARGET=unit-test
 PACKAGE_TARGET=github.com/aldebap/go_gbc/test
 
-#unitTestTarget
+unitTestTarget
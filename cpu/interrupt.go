@@ -0,0 +1,32 @@
+////////////////////////////////////////////////////////////////////////////////
+//	interrupt.go - Oct-19-2026 by aldebap
+//
+//	SM83 interrupt sources shared by the CPU and the peripherals
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+// interrupt flags (IF and IE bits)
+const (
+	INTERRUPT_VBLANK   = uint8(0x01)
+	INTERRUPT_LCD_STAT = uint8(0x02)
+	INTERRUPT_TIMER    = uint8(0x04)
+	INTERRUPT_SERIAL   = uint8(0x08)
+	INTERRUPT_JOYPAD   = uint8(0x10)
+	INTERRUPT_MASK     = uint8(0x1f)
+)
+
+// interrupt vectors: the handler of interrupt bit n is at INTERRUPT_VECTOR + n * INTERRUPT_VECTOR_SIZE
+const (
+	INTERRUPT_VECTOR      = uint16(0x0040)
+	INTERRUPT_VECTOR_SIZE = uint16(0x0008)
+)
+
+// request an interrupt setting its bit in IF
+type InterruptRequester func(interrupt uint8)
+
+// return the interrupts requested and enabled (IF and IE)
+type InterruptSource func() uint8
+
+// clear an interrupt bit in IF once the CPU services it
+type InterruptAcknowledger func(interrupt uint8)
@@ -4,10 +4,18 @@
 //	Emulator for Sharp SM83 CPU
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
+
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// SM83 CPU clock (T-cycles per second)
+const (
+	CPU_CLOCK_RATE = 4194304
 )
 
 // SM83 CPU states
@@ -18,6 +26,7 @@ const (
 	EXECUTION_CYCLE_3    = 4
 	EXECUTION_CYCLE_4    = 5
 	EXECUTION_CYCLE_5    = 6
+	EXECUTION_CYCLE_6    = 7
 )
 
 // SM83 CPU flags
@@ -96,6 +105,7 @@ const (
 	INC_A         = uint8(0x3c)
 	DEC_A         = uint8(0x3d)
 	LD_A_n        = uint8(0x3e)
+	CCF           = uint8(0x3f)
 
 	LD_B_B       = uint8(0x40)
 	LD_B_C       = uint8(0x41)
@@ -182,25 +192,120 @@ const (
 	ADC_ADDR_HL = uint8(0x8e)
 	ADC_A       = uint8(0x8f)
 
-	ADD_n = uint8(0xc6)
-	ADC_n = uint8(0xce)
+	SUB_B       = uint8(0x90)
+	SUB_C       = uint8(0x91)
+	SUB_D       = uint8(0x92)
+	SUB_E       = uint8(0x93)
+	SUB_H       = uint8(0x94)
+	SUB_L       = uint8(0x95)
+	SUB_ADDR_HL = uint8(0x96)
+	SUB_A       = uint8(0x97)
+	SBC_B       = uint8(0x98)
+	SBC_C       = uint8(0x99)
+	SBC_D       = uint8(0x9a)
+	SBC_E       = uint8(0x9b)
+	SBC_H       = uint8(0x9c)
+	SBC_L       = uint8(0x9d)
+	SBC_ADDR_HL = uint8(0x9e)
+	SBC_A       = uint8(0x9f)
+
+	AND_B       = uint8(0xa0)
+	AND_C       = uint8(0xa1)
+	AND_D       = uint8(0xa2)
+	AND_E       = uint8(0xa3)
+	AND_H       = uint8(0xa4)
+	AND_L       = uint8(0xa5)
+	AND_ADDR_HL = uint8(0xa6)
+	AND_A       = uint8(0xa7)
+	XOR_B       = uint8(0xa8)
+	XOR_C       = uint8(0xa9)
+	XOR_D       = uint8(0xaa)
+	XOR_E       = uint8(0xab)
+	XOR_H       = uint8(0xac)
+	XOR_L       = uint8(0xad)
+	XOR_ADDR_HL = uint8(0xae)
+	XOR_A       = uint8(0xaf)
+
+	OR_B       = uint8(0xb0)
+	OR_C       = uint8(0xb1)
+	OR_D       = uint8(0xb2)
+	OR_E       = uint8(0xb3)
+	OR_H       = uint8(0xb4)
+	OR_L       = uint8(0xb5)
+	OR_ADDR_HL = uint8(0xb6)
+	OR_A       = uint8(0xb7)
+	CP_B       = uint8(0xb8)
+	CP_C       = uint8(0xb9)
+	CP_D       = uint8(0xba)
+	CP_E       = uint8(0xbb)
+	CP_H       = uint8(0xbc)
+	CP_L       = uint8(0xbd)
+	CP_ADDR_HL = uint8(0xbe)
+	CP_A       = uint8(0xbf)
+
+	RET_NZ     = uint8(0xc0)
+	POP_BC     = uint8(0xc1)
+	JP_NZ_nn   = uint8(0xc2)
+	JP_nn      = uint8(0xc3)
+	CALL_NZ_nn = uint8(0xc4)
+	PUSH_BC    = uint8(0xc5)
+	ADD_n      = uint8(0xc6)
+	RST_00     = uint8(0xc7)
+	RET_Z      = uint8(0xc8)
+	RET        = uint8(0xc9)
+	JP_Z_nn    = uint8(0xca)
+	PREFIX_CB  = uint8(0xcb)
+	CALL_Z_nn  = uint8(0xcc)
+	CALL_nn    = uint8(0xcd)
+	ADC_n      = uint8(0xce)
+	RST_08     = uint8(0xcf)
+
+	RET_NC     = uint8(0xd0)
+	POP_DE     = uint8(0xd1)
+	JP_NC_nn   = uint8(0xd2)
+	CALL_NC_nn = uint8(0xd4)
+	PUSH_DE    = uint8(0xd5)
+	SUB_n      = uint8(0xd6)
+	RST_10     = uint8(0xd7)
+	RET_C      = uint8(0xd8)
+	RETI       = uint8(0xd9)
+	JP_C_nn    = uint8(0xda)
+	CALL_C_nn  = uint8(0xdc)
+	SBC_n      = uint8(0xde)
+	RST_18     = uint8(0xdf)
 
 	LDH_ADDR_n_A = uint8(0xe0)
 	POP_HL       = uint8(0xe1)
 	LDH_ADDR_C_A = uint8(0xe2)
+	PUSH_HL      = uint8(0xe5)
+	AND_n        = uint8(0xe6)
+	RST_20       = uint8(0xe7)
+	ADD_SP_e     = uint8(0xe8)
+	JP_HL        = uint8(0xe9)
 	LD_ADDR_nn_A = uint8(0xea)
+	XOR_n        = uint8(0xee)
+	RST_28       = uint8(0xef)
 
 	LDH_A_ADDR_n = uint8(0xf0)
 	POP_AF       = uint8(0xf1)
 	LDH_A_ADDR_C = uint8(0xf2)
+	DI           = uint8(0xf3)
+	PUSH_AF      = uint8(0xf5)
+	OR_n         = uint8(0xf6)
+	RST_30       = uint8(0xf7)
+	LD_HL_SP_e   = uint8(0xf8)
+	LD_SP_HL     = uint8(0xf9)
 	LD_A_ADDR_nn = uint8(0xfa)
+	EI           = uint8(0xfb)
+	CP_n         = uint8(0xfe)
+	RST_38       = uint8(0xff)
 )
 
 // SM83 CPU internal registers and connections
 type SM83_CPU struct {
 	pc    uint16
 	ir    uint8
-	ie    uint8
+	ime   uint8
 	a     uint8
 	b     uint8
 	c     uint8
@@ -212,13 +317,18 @@ type SM83_CPU struct {
 	p     uint8
 	flags uint8
 
-	trace     bool
-	cpu_state uint8
-	n_lsb     uint8
-	n_msb     uint8
+	trace       bool
+	cpu_state   uint8
+	n_lsb       uint8
+	n_msb       uint8
+	halted      bool
+	dispatching bool
 
-	memoryBank        []memory
+	memoryBank        []memory.Memory
 	memoryBankAddress []uint16
+
+	pendingInterrupts    InterruptSource
+	acknowledgeInterrupt InterruptAcknowledger
 }
 
 // create a new SM83 CPU
@@ -227,7 +337,7 @@ func NewSM83_CPU(trace bool) *SM83_CPU {
 	return &SM83_CPU{
 		pc:    0,
 		ir:    0,
-		ie:    0,
+		ime:   0,
 		a:     0,
 		b:     0,
 		c:     0,
@@ -248,9 +358,9 @@ func NewSM83_CPU(trace bool) *SM83_CPU {
 }
 
 // connect a new memory bank to the CPU
-func (c *SM83_CPU) ConnectMemory(memoryBank memory, intialAddress uint16) error {
+func (c *SM83_CPU) ConnectMemory(memoryBank memory.Memory, intialAddress uint16) error {
 	if c.memoryBank == nil {
-		c.memoryBank = make([]memory, 0)
+		c.memoryBank = make([]memory.Memory, 0)
 		c.memoryBankAddress = make([]uint16, 0)
 	}
 
@@ -265,12 +375,37 @@ func (c *SM83_CPU) ConnectMemory(memoryBank memory, intialAddress uint16) error
 	return nil
 }
 
+// connect the interrupt controller: the interrupts requested and enabled (IF and IE) are serviced between
+// instructions while IME is set, and wake the CPU from HALT
+func (c *SM83_CPU) ConnectInterrupts(pendingInterrupts InterruptSource, acknowledgeInterrupt InterruptAcknowledger) {
+	c.pendingInterrupts = pendingInterrupts
+	c.acknowledgeInterrupt = acknowledgeInterrupt
+}
+
+// return the interrupts requested and enabled
+func (c *SM83_CPU) pending() uint8 {
+
+	if c.pendingInterrupts == nil {
+		return 0
+	}
+
+	return c.pendingInterrupts() & INTERRUPT_MASK
+}
+
 // run one machine cycle
 func (c *SM83_CPU) MachineCycle() error {
 	var err error
 
 	switch c.cpu_state {
 	case FETCHING_INSTRUCTION:
+		//	a halted CPU idles until an interrupt is pending
+		if c.halted {
+			if c.pending() == 0 {
+				return nil
+			}
+			c.halted = false
+		}
+
 		err = c.fetchInstruction()
 		if err != nil {
 			if c.trace {
@@ -279,7 +414,7 @@ func (c *SM83_CPU) MachineCycle() error {
 			return err
 		}
 
-	case EXECUTION_CYCLE_1, EXECUTION_CYCLE_2, EXECUTION_CYCLE_3, EXECUTION_CYCLE_4, EXECUTION_CYCLE_5:
+	case EXECUTION_CYCLE_1, EXECUTION_CYCLE_2, EXECUTION_CYCLE_3, EXECUTION_CYCLE_4, EXECUTION_CYCLE_5, EXECUTION_CYCLE_6:
 		err = c.executeInstruction()
 		if err != nil {
 			if c.trace {
@@ -292,13 +427,21 @@ func (c *SM83_CPU) MachineCycle() error {
 	return nil
 }
 
-// fetch one instruction from memory
+// fetch one instruction from memory (or start servicing an interrupt instead)
 func (c *SM83_CPU) fetchInstruction() error {
 	var err error
 
+	if c.ime != 0 && c.pending() != 0 {
+		c.ime = 0
+		c.dispatching = true
+		c.cpu_state = EXECUTION_CYCLE_1
+
+		return nil
+	}
+
 	for i := 0; i < len(c.memoryBankAddress); i++ {
-		if c.pc >= c.memoryBankAddress[i] && c.pc < c.memoryBankAddress[i]+c.memoryBank[i].Len() {
-			c.ir, err = c.memoryBank[i].ReadByte(c.pc)
+		if c.pc >= c.memoryBankAddress[i] && uint32(c.pc) < uint32(c.memoryBankAddress[i])+uint32(c.memoryBank[i].Len()) {
+			c.ir, err = c.memoryBank[i].ReadByte(c.pc - c.memoryBankAddress[i])
 			if err != nil {
 				return err
 			}
@@ -318,8 +461,8 @@ func (c *SM83_CPU) fetchInstructionArgument() (uint8, error) {
 	var aux uint8
 
 	for i := 0; i < len(c.memoryBankAddress); i++ {
-		if c.pc >= c.memoryBankAddress[i] && c.pc < c.memoryBankAddress[i]+c.memoryBank[i].Len() {
-			aux, err = c.memoryBank[i].ReadByte(c.pc)
+		if c.pc >= c.memoryBankAddress[i] && uint32(c.pc) < uint32(c.memoryBankAddress[i])+uint32(c.memoryBank[i].Len()) {
+			aux, err = c.memoryBank[i].ReadByte(c.pc - c.memoryBankAddress[i])
 			if err != nil {
 				return 0, err
 			}
@@ -336,7 +479,7 @@ func (c *SM83_CPU) fetchInstructionArgument() (uint8, error) {
 func (c *SM83_CPU) writeByteIntoMemory(address uint16, value uint8) error {
 
 	for i := 0; i < len(c.memoryBankAddress); i++ {
-		if address >= c.memoryBankAddress[i] && address < c.memoryBankAddress[i]+c.memoryBank[i].Len() {
+		if address >= c.memoryBankAddress[i] && uint32(address) < uint32(c.memoryBankAddress[i])+uint32(c.memoryBank[i].Len()) {
 			return c.memoryBank[i].WriteByte(address-c.memoryBankAddress[i], value)
 		}
 	}
@@ -348,7 +491,7 @@ func (c *SM83_CPU) writeByteIntoMemory(address uint16, value uint8) error {
 func (c *SM83_CPU) readByteFromMemory(address uint16) (uint8, error) {
 
 	for i := 0; i < len(c.memoryBankAddress); i++ {
-		if address >= c.memoryBankAddress[i] && address < c.memoryBankAddress[i]+c.memoryBank[i].Len() {
+		if address >= c.memoryBankAddress[i] && uint32(address) < uint32(c.memoryBankAddress[i])+uint32(c.memoryBank[i].Len()) {
 			return c.memoryBank[i].ReadByte(address - c.memoryBankAddress[i])
 		}
 	}
@@ -373,6 +516,11 @@ func (c *SM83_CPU) executeInstruction() error {
 		REG_SP = "SP"
 	)
 
+	//	an interrupt being serviced runs in place of an instruction
+	if c.dispatching {
+		return c.executeInterruptDispatch()
+	}
+
 	switch c.ir {
 	//	instructions 0x00 - 0x0f
 	case NOP:
@@ -535,19 +683,19 @@ func (c *SM83_CPU) executeInstruction() error {
 		return c.executeInstruction_INC_XX(&c.s, &c.p, REG_SP)
 
 	case INC_ADDR_HL:
-		return nil // TODO: implement INC_ADDR_HL
+		return c.executeInstruction_INC_ADDR_HL()
 
 	case DEC_ADDR_HL:
-		return nil // TODO: implement DEC_ADDR_HL
+		return c.executeInstruction_DEC_ADDR_HL()
 
 	case LD_ADDR_HL_n:
 		return c.executeInstruction_LD_ADDR_HL_n()
 
 	case SCF:
-		return nil // TODO: implement SCF
+		return c.executeInstruction_SCF()
 
 	case JR_C_e:
-		return nil // TODO: implement JR_C_e
+		return c.executeInstruction_JR_C_e()
 
 	case ADD_HL_SP:
 		return c.executeInstruction_ADD_HL_XX(c.s, c.p, REG_SP)
@@ -567,6 +715,9 @@ func (c *SM83_CPU) executeInstruction() error {
 	case LD_A_n:
 		return c.executeInstruction_LD_X_n(&c.a, REG_A)
 
+	case CCF:
+		return c.executeInstruction_CCF()
+
 		//	instructions 0x40 - 0x4f
 	case LD_B_B:
 		return c.executeInstruction_LD_X_Y(&c.b, REG_B, c.b, REG_B)
@@ -709,7 +860,7 @@ func (c *SM83_CPU) executeInstruction() error {
 		return c.executeInstruction_LD_X_Y(&c.l, REG_L, c.l, REG_L)
 
 	case LD_L_ADDR_HL:
-		return c.executeInstruction_LD_X_ADDR_HL(&c.e, REG_L)
+		return c.executeInstruction_LD_X_ADDR_HL(&c.l, REG_L)
 
 	case LD_L_A:
 		return c.executeInstruction_LD_X_Y(&c.l, REG_L, c.a, REG_A)
@@ -734,7 +885,7 @@ func (c *SM83_CPU) executeInstruction() error {
 		return c.executeInstruction_LD_ADDR_HL_X(c.l, REG_L)
 
 	case HALT:
-		return nil // TODO: implement HALT
+		return c.executeInstruction_HALT()
 
 	case LD_ADDR_HL_A:
 		return c.executeInstruction_LD_ADDR_HL_X(c.a, REG_A)
@@ -813,44 +964,378 @@ func (c *SM83_CPU) executeInstruction() error {
 		return c.executeInstruction_ADC_X(c.a, REG_A)
 
 		//	instructions 0x90 - 0x9f
+	case SUB_B:
+		return c.executeInstruction_SUB_X(c.b, REG_B)
+
+	case SUB_C:
+		return c.executeInstruction_SUB_X(c.c, REG_C)
+
+	case SUB_D:
+		return c.executeInstruction_SUB_X(c.d, REG_D)
+
+	case SUB_E:
+		return c.executeInstruction_SUB_X(c.e, REG_E)
+
+	case SUB_H:
+		return c.executeInstruction_SUB_X(c.h, REG_H)
+
+	case SUB_L:
+		return c.executeInstruction_SUB_X(c.l, REG_L)
+
+	case SUB_ADDR_HL:
+		return c.executeInstruction_SUB_ADDR_HL()
+
+	case SUB_A:
+		return c.executeInstruction_SUB_X(c.a, REG_A)
+
+	case SBC_B:
+		return c.executeInstruction_SBC_X(c.b, REG_B)
+
+	case SBC_C:
+		return c.executeInstruction_SBC_X(c.c, REG_C)
+
+	case SBC_D:
+		return c.executeInstruction_SBC_X(c.d, REG_D)
+
+	case SBC_E:
+		return c.executeInstruction_SBC_X(c.e, REG_E)
+
+	case SBC_H:
+		return c.executeInstruction_SBC_X(c.h, REG_H)
+
+	case SBC_L:
+		return c.executeInstruction_SBC_X(c.l, REG_L)
+
+	case SBC_ADDR_HL:
+		return c.executeInstruction_SBC_ADDR_HL()
+
+	case SBC_A:
+		return c.executeInstruction_SBC_X(c.a, REG_A)
+
+		//	instructions 0xa0 - 0xaf
+	case AND_B:
+		return c.executeInstruction_AND_X(c.b, REG_B)
+
+	case AND_C:
+		return c.executeInstruction_AND_X(c.c, REG_C)
+
+	case AND_D:
+		return c.executeInstruction_AND_X(c.d, REG_D)
+
+	case AND_E:
+		return c.executeInstruction_AND_X(c.e, REG_E)
+
+	case AND_H:
+		return c.executeInstruction_AND_X(c.h, REG_H)
+
+	case AND_L:
+		return c.executeInstruction_AND_X(c.l, REG_L)
+
+	case AND_ADDR_HL:
+		return c.executeInstruction_AND_ADDR_HL()
+
+	case AND_A:
+		return c.executeInstruction_AND_X(c.a, REG_A)
+
+	case XOR_B:
+		return c.executeInstruction_XOR_X(c.b, REG_B)
+
+	case XOR_C:
+		return c.executeInstruction_XOR_X(c.c, REG_C)
+
+	case XOR_D:
+		return c.executeInstruction_XOR_X(c.d, REG_D)
+
+	case XOR_E:
+		return c.executeInstruction_XOR_X(c.e, REG_E)
+
+	case XOR_H:
+		return c.executeInstruction_XOR_X(c.h, REG_H)
+
+	case XOR_L:
+		return c.executeInstruction_XOR_X(c.l, REG_L)
+
+	case XOR_ADDR_HL:
+		return c.executeInstruction_XOR_ADDR_HL()
+
+	case XOR_A:
+		return c.executeInstruction_XOR_X(c.a, REG_A)
+
+		//	instructions 0xb0 - 0xbf
+	case OR_B:
+		return c.executeInstruction_OR_X(c.b, REG_B)
+
+	case OR_C:
+		return c.executeInstruction_OR_X(c.c, REG_C)
+
+	case OR_D:
+		return c.executeInstruction_OR_X(c.d, REG_D)
+
+	case OR_E:
+		return c.executeInstruction_OR_X(c.e, REG_E)
+
+	case OR_H:
+		return c.executeInstruction_OR_X(c.h, REG_H)
+
+	case OR_L:
+		return c.executeInstruction_OR_X(c.l, REG_L)
+
+	case OR_ADDR_HL:
+		return c.executeInstruction_OR_ADDR_HL()
+
+	case OR_A:
+		return c.executeInstruction_OR_X(c.a, REG_A)
+
+	case CP_B:
+		return c.executeInstruction_CP_X(c.b, REG_B)
+
+	case CP_C:
+		return c.executeInstruction_CP_X(c.c, REG_C)
+
+	case CP_D:
+		return c.executeInstruction_CP_X(c.d, REG_D)
+
+	case CP_E:
+		return c.executeInstruction_CP_X(c.e, REG_E)
+
+	case CP_H:
+		return c.executeInstruction_CP_X(c.h, REG_H)
+
+	case CP_L:
+		return c.executeInstruction_CP_X(c.l, REG_L)
+
+	case CP_ADDR_HL:
+		return c.executeInstruction_CP_ADDR_HL()
+
+	case CP_A:
+		return c.executeInstruction_CP_X(c.a, REG_A)
 
 		//	instructions 0xc0 - 0xcf
+	case RET_NZ:
+		return c.executeInstruction_RET_cc(c.flags&FLAG_Z == 0, "NZ")
+
+	case POP_BC:
+		return c.executeInstruction_POP_XX(&c.b, &c.c, REG_BC)
+
+	case JP_NZ_nn:
+		return c.executeInstruction_JP_cc_nn(c.flags&FLAG_Z == 0, "NZ")
+
+	case JP_nn:
+		return c.executeInstruction_JP_nn()
+
+	case CALL_NZ_nn:
+		return c.executeInstruction_CALL_cc_nn(c.flags&FLAG_Z == 0, "NZ")
+
+	case PUSH_BC:
+		return c.executeInstruction_PUSH_XX(c.b, c.c, REG_BC)
+
 	case ADD_n:
 		return c.executeInstruction_ADD_n()
 
+	case RST_00:
+		return c.executeInstruction_RST(0x00)
+
+	case RET_Z:
+		return c.executeInstruction_RET_cc(c.flags&FLAG_Z != 0, "Z")
+
+	case RET:
+		return c.executeInstruction_RET()
+
+	case JP_Z_nn:
+		return c.executeInstruction_JP_cc_nn(c.flags&FLAG_Z != 0, "Z")
+
+	case PREFIX_CB:
+		return c.executeInstruction_PREFIX_CB()
+
+	case CALL_Z_nn:
+		return c.executeInstruction_CALL_cc_nn(c.flags&FLAG_Z != 0, "Z")
+
+	case CALL_nn:
+		return c.executeInstruction_CALL_nn()
+
 	case ADC_n:
 		return c.executeInstruction_ADC_n()
 
+	case RST_08:
+		return c.executeInstruction_RST(0x08)
+
 		//	instructions 0xd0 - 0xdf
+	case RET_NC:
+		return c.executeInstruction_RET_cc(c.flags&FLAG_C == 0, "NC")
+
+	case POP_DE:
+		return c.executeInstruction_POP_XX(&c.d, &c.e, REG_DE)
+
+	case JP_NC_nn:
+		return c.executeInstruction_JP_cc_nn(c.flags&FLAG_C == 0, "NC")
+
+	case CALL_NC_nn:
+		return c.executeInstruction_CALL_cc_nn(c.flags&FLAG_C == 0, "NC")
+
+	case PUSH_DE:
+		return c.executeInstruction_PUSH_XX(c.d, c.e, REG_DE)
+
+	case SUB_n:
+		return c.executeInstruction_SUB_n()
+
+	case RST_10:
+		return c.executeInstruction_RST(0x10)
+
+	case RET_C:
+		return c.executeInstruction_RET_cc(c.flags&FLAG_C != 0, "C")
+
+	case RETI:
+		return c.executeInstruction_RETI()
+
+	case JP_C_nn:
+		return c.executeInstruction_JP_cc_nn(c.flags&FLAG_C != 0, "C")
+
+	case CALL_C_nn:
+		return c.executeInstruction_CALL_cc_nn(c.flags&FLAG_C != 0, "C")
+
+	case SBC_n:
+		return c.executeInstruction_SBC_n()
+
+	case RST_18:
+		return c.executeInstruction_RST(0x18)
 
 		//	instructions 0xe0 - 0xef
 	case LDH_ADDR_n_A:
 		return c.executeInstruction_LDH_ADDR_n_A()
 
 	case POP_HL:
-		return nil // TODO: implement POP_HL
+		return c.executeInstruction_POP_XX(&c.h, &c.l, REG_HL)
 
 	case LDH_ADDR_C_A:
 		return c.executeInstruction_LDH_ADDR_C_A()
 
+	case PUSH_HL:
+		return c.executeInstruction_PUSH_XX(c.h, c.l, REG_HL)
+
+	case AND_n:
+		return c.executeInstruction_AND_n()
+
+	case RST_20:
+		return c.executeInstruction_RST(0x20)
+
+	case ADD_SP_e:
+		return c.executeInstruction_ADD_SP_e()
+
+	case JP_HL:
+		return c.executeInstruction_JP_HL()
+
 	case LD_ADDR_nn_A:
 		return c.executeInstruction_LD_ADDR_nn_A()
 
+	case XOR_n:
+		return c.executeInstruction_XOR_n()
+
+	case RST_28:
+		return c.executeInstruction_RST(0x28)
+
 		//	instructions 0xf0 - 0xff
 	case LDH_A_ADDR_n:
 		return c.executeInstruction_LDH_A_ADDR_n()
 
 	case POP_AF:
-		return nil // TODO: implement POP_AF
+		return c.executeInstruction_POP_AF()
 
 	case LDH_A_ADDR_C:
 		return c.executeInstruction_LDH_A_ADDR_C()
 
+	case DI:
+		return c.executeInstruction_DI()
+
+	case PUSH_AF:
+		return c.executeInstruction_PUSH_XX(c.a, c.flags, "AF")
+
+	case OR_n:
+		return c.executeInstruction_OR_n()
+
+	case RST_30:
+		return c.executeInstruction_RST(0x30)
+
+	case LD_HL_SP_e:
+		return c.executeInstruction_LD_HL_SP_e()
+
+	case LD_SP_HL:
+		return c.executeInstruction_LD_SP_HL()
+
 	case LD_A_ADDR_nn:
 		return c.executeInstruction_LD_A_ADDR_nn()
+
+	case EI:
+		return c.executeInstruction_EI()
+
+	case CP_n:
+		return c.executeInstruction_CP_n()
+
+	case RST_38:
+		return c.executeInstruction_RST(0x38)
+
+	default:
+		//	the unused opcodes lock up the SM83
+		return fmt.Errorf("illegal instruction: 0x%02x at 0x%04x", c.ir, c.pc-1)
 	}
+}
 
-	return nil
+// return the program counter
+func (c *SM83_CPU) PC() uint16 {
+	return c.pc
+}
+
+// return the opcode of the instruction about to be executed, and whether the CPU is at the start of one
+// (not halted, not in the middle of an instruction or of an interrupt dispatch)
+func (c *SM83_CPU) Instruction() (uint8, bool) {
+	return c.ir, c.cpu_state == EXECUTION_CYCLE_1 && !c.dispatching && !c.halted
+}
+
+// set the registers (e.g. the values left by the boot ROM)
+func (c *SM83_CPU) SetRegisters(af uint16, bc uint16, de uint16, hl uint16, sp uint16, pc uint16) {
+	c.a, c.flags = uint8(af>>8), uint8(af&0x00f0)
+	c.b, c.c = uint8(bc>>8), uint8(bc)
+	c.d, c.e = uint8(de>>8), uint8(de)
+	c.h, c.l = uint8(hl>>8), uint8(hl)
+	c.s, c.p = uint8(sp>>8), uint8(sp)
+	c.pc = pc
+}
+
+// write the registers and the state of the instruction being executed
+func (c *SM83_CPU) SaveState(w *savestate.Writer) {
+	w.Section("CPU")
+
+	for _, register := range []uint8{c.ir, c.ime, c.a, c.b, c.c, c.d, c.e, c.h, c.l, c.s, c.p, c.flags} {
+		w.Uint8(register)
+	}
+	w.Uint16(c.pc)
+
+	w.Uint8(c.cpu_state)
+	w.Uint8(c.n_lsb)
+	w.Uint8(c.n_msb)
+	w.Bool(c.halted)
+	w.Bool(c.dispatching)
+}
+
+// read the registers and the state of the instruction being executed
+func (c *SM83_CPU) LoadState(r *savestate.Reader) error {
+	r.Section("CPU")
+
+	for _, register := range []*uint8{&c.ir, &c.ime, &c.a, &c.b, &c.c, &c.d, &c.e, &c.h, &c.l, &c.s, &c.p, &c.flags} {
+		*register = r.Uint8()
+	}
+	c.pc = r.Uint16()
+
+	c.cpu_state = r.Uint8()
+	c.n_lsb = r.Uint8()
+	c.n_msb = r.Uint8()
+	c.halted = r.Bool()
+	c.dispatching = r.Bool()
+
+	if r.Err() == nil && (c.cpu_state < FETCHING_INSTRUCTION || c.cpu_state > EXECUTION_CYCLE_6) {
+		r.Fail(fmt.Errorf("invalid save state: CPU state %d", c.cpu_state))
+	}
+
+	return r.Err()
 }
 
 // dump CPU registers
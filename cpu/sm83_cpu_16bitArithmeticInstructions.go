@@ -4,7 +4,7 @@
 //	Emulator for Sharp SM83 CPU - 16 bit arithmetic instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
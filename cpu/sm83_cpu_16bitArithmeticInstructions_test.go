@@ -4,6 +4,6 @@
 //	Test cases for Sharp SM83 CPU - 83 CPU - 16 bit arithmetic instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 // TODO: write test cases for 8 bit arithmetic instructions
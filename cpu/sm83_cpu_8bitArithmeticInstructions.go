@@ -0,0 +1,579 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_8bitArithmeticInstructions.go - Aug-3-2025 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - 8 bit arithmetic instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+)
+
+/*
+ADC A,r8  	--> ADC_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADC_A,r8)
+ADC A,[HL]  --> ADC_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADC_A,_HL_)
+ADC A,n8    --> ADC_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADC_A,n8)
+ADD A,r8    --> ADD_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADD_A,r8)
+ADD A,[HL]  --> ADD_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADD_A,_HL_)
+ADD A,n8    --> ADD_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADD_A,n8)
+CP A,r8     --> CP_X        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CP_A,r8)
+CP A,[HL]   --> CP_ADDR_HL  (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CP_A,_HL_)
+CP A,n8     --> CP_n        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CP_A,n8)
+DEC r8      --> DEC_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#DEC_r8)
+DEC [HL]    --> DEC_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#DEC__HL_)
+INC r8      --> INC_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#INC_r8)
+INC [HL]    --> INC_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#INC__HL_)
+SBC A,r8    --> SBC_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SBC_A,r8)
+SBC A,[HL]  --> SBC_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SBC_A,_HL_)
+SBC A,n8    --> SBC_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SBC_A,n8)
+SUB A,r8    --> SUB_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SUB_A,r8)
+SUB A,[HL]  --> SUB_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SUB_A,_HL_)
+SUB A,n8    --> SUB_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SUB_A,n8)
+*/
+
+// execute instruction ADC_X
+func (c *SM83_CPU) executeInstruction_ADC_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		var aux16 = uint16(c.a) + uint16(r)
+
+		if (c.flags & FLAG_C) != 0 {
+			aux16++
+		}
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(r&0x0f)+(c.flags&FLAG_C) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADC %s: 0x%02x\n", reg, r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction ADC_ADDR_HL
+func (c *SM83_CPU) executeInstruction_ADC_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		var aux16 = uint16(c.a) + uint16(c.n_lsb)
+
+		if (c.flags & FLAG_C) != 0 {
+			aux16++
+		}
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(c.n_lsb&0x0f)+(c.flags&FLAG_C) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADC (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction ADC_n
+func (c *SM83_CPU) executeInstruction_ADC_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		var aux16 = uint16(c.a) + uint16(c.n_lsb)
+
+		if (c.flags & FLAG_C) != 0 {
+			aux16++
+		}
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(c.n_lsb&0x0f)+(c.flags&FLAG_C) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADC n: 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction ADD_X
+func (c *SM83_CPU) executeInstruction_ADD_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		var aux16 = uint16(c.a) + uint16(r)
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(r&0x0f) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADD %s: 0x%02x\n", reg, r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction ADD_ADDR_HL
+func (c *SM83_CPU) executeInstruction_ADD_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		var aux16 = uint16(c.a) + uint16(c.n_lsb)
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(c.n_lsb&0x0f) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADD (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction ADD_n
+func (c *SM83_CPU) executeInstruction_ADD_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		var aux16 = uint16(c.a) + uint16(c.n_lsb)
+
+		c.flags = 0x00
+
+		if aux16&0x00ff == 0 {
+			c.flags |= FLAG_Z
+		}
+		if (c.a&0x0f)+(c.n_lsb&0x0f) > 0x0f {
+			c.flags |= FLAG_H
+		}
+		if aux16&0xff00 != 0 {
+			c.flags |= FLAG_C
+		}
+
+		c.a = uint8(aux16 & 0x00ff)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADD n: 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction DEC_X
+func (c *SM83_CPU) executeInstruction_DEC_X(r *uint8, reg string) error {
+
+	*r--
+
+	if *r == 0x00 {
+		c.flags |= FLAG_Z
+	} else {
+		c.flags &= ^FLAG_Z
+	}
+	c.flags &= ^FLAG_N
+
+	if c.trace {
+		fmt.Printf("[trace] DEC %s: 0x%02x\n", reg, *r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction INC_X
+func (c *SM83_CPU) executeInstruction_INC_X(r *uint8, reg string) error {
+
+	*r++
+
+	if *r == 0x00 {
+		c.flags |= FLAG_Z
+	} else {
+		c.flags &= ^FLAG_Z
+	}
+	c.flags &= ^FLAG_N
+
+	if c.trace {
+		fmt.Printf("[trace] INC %s: 0x%02x\n", reg, *r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction INC_ADDR_HL
+func (c *SM83_CPU) executeInstruction_INC_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_lsb++
+
+		c.flags &= FLAG_C
+		if c.n_lsb == 0x00 {
+			c.flags |= FLAG_Z
+		}
+		if c.n_lsb&0x0f == 0x00 {
+			c.flags |= FLAG_H
+		}
+
+		err = c.writeByteIntoMemory(uint16(c.h)<<8|uint16(c.l), c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] INC (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction DEC_ADDR_HL
+func (c *SM83_CPU) executeInstruction_DEC_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_lsb--
+
+		c.flags = c.flags&FLAG_C | FLAG_N
+		if c.n_lsb == 0x00 {
+			c.flags |= FLAG_Z
+		}
+		if c.n_lsb&0x0f == 0x0f {
+			c.flags |= FLAG_H
+		}
+
+		err = c.writeByteIntoMemory(uint16(c.h)<<8|uint16(c.l), c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] DEC (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// subtract a value and a borrow from A setting the flags (shared by SUB, SBC and CP)
+func (c *SM83_CPU) subtract(value uint8, borrow uint8) uint8 {
+	var aux16 = uint16(c.a) - uint16(value) - uint16(borrow)
+
+	c.flags = FLAG_N
+
+	if aux16&0x00ff == 0 {
+		c.flags |= FLAG_Z
+	}
+	if c.a&0x0f < value&0x0f+borrow {
+		c.flags |= FLAG_H
+	}
+	if aux16&0xff00 != 0 {
+		c.flags |= FLAG_C
+	}
+
+	return uint8(aux16 & 0x00ff)
+}
+
+// return the carry flag as 0 or 1 (the carry in of ADC, SBC, RL and RR)
+func (c *SM83_CPU) carry() uint8 {
+	return (c.flags & FLAG_C) >> 4
+}
+
+// execute instruction SUB_X
+func (c *SM83_CPU) executeInstruction_SUB_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.a = c.subtract(r, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SUB %s: 0x%02x\n", reg, r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction SUB_ADDR_HL
+func (c *SM83_CPU) executeInstruction_SUB_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a = c.subtract(c.n_lsb, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SUB (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction SUB_n
+func (c *SM83_CPU) executeInstruction_SUB_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a = c.subtract(c.n_lsb, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SUB n: 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction SBC_X
+func (c *SM83_CPU) executeInstruction_SBC_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.a = c.subtract(r, c.carry())
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SBC %s: 0x%02x\n", reg, r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction SBC_ADDR_HL
+func (c *SM83_CPU) executeInstruction_SBC_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a = c.subtract(c.n_lsb, c.carry())
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SBC (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction SBC_n
+func (c *SM83_CPU) executeInstruction_SBC_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a = c.subtract(c.n_lsb, c.carry())
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] SBC n: 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction CP_X
+func (c *SM83_CPU) executeInstruction_CP_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.subtract(r, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] CP %s: 0x%02x\n", reg, r)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction CP_ADDR_HL
+func (c *SM83_CPU) executeInstruction_CP_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.subtract(c.n_lsb, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] CP (HL): 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction CP_n
+func (c *SM83_CPU) executeInstruction_CP_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.subtract(c.n_lsb, 0)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] CP n: 0x%02x\n", c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
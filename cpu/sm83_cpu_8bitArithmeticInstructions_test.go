@@ -4,11 +4,13 @@
 //	Test cases for Sharp SM83 CPU - 83 CPU - 8 bit arithmetic instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // ADC_X instruction unit tests
@@ -25,7 +27,7 @@ func Test_ADC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -76,7 +78,7 @@ func Test_ADC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -128,7 +130,7 @@ func Test_ADC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -179,7 +181,7 @@ func Test_ADC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -236,7 +238,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -255,7 +257,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -313,7 +315,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -332,7 +334,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -391,7 +393,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -410,7 +412,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -468,7 +470,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -487,7 +489,7 @@ func Test_ADC_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -551,7 +553,7 @@ func Test_ADC_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -602,7 +604,7 @@ func Test_ADC_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -654,7 +656,7 @@ func Test_ADC_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -705,7 +707,7 @@ func Test_ADC_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -762,7 +764,7 @@ func Test_ADD_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -813,7 +815,7 @@ func Test_ADD_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -865,7 +867,7 @@ func Test_ADD_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -916,7 +918,7 @@ func Test_ADD_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -973,7 +975,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -992,7 +994,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1050,7 +1052,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1069,7 +1071,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1128,7 +1130,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1147,7 +1149,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1205,7 +1207,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1224,7 +1226,7 @@ func Test_ADD_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1288,7 +1290,7 @@ func Test_ADD_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1339,7 +1341,7 @@ func Test_ADD_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1391,7 +1393,7 @@ func Test_ADD_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1442,7 +1444,7 @@ func Test_ADD_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1499,7 +1501,7 @@ func Test_DEC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1549,7 +1551,7 @@ func Test_DEC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1605,7 +1607,7 @@ func Test_INC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1655,7 +1657,7 @@ func Test_INC_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1696,3 +1698,133 @@ func Test_INC_X(t *testing.T) {
 		}
 	})
 }
+
+// INC (HL) and DEC (HL) instructions unit tests
+func Test_INC_DEC_ADDR_HL(t *testing.T) {
+
+	runProgramTests(t, "INC/DEC (HL)", []programTest{
+		{
+			name:    "INC (HL) with half carry keeps the carry",
+			program: []uint8{INC_ADDR_HL, LD_A_ADDR_HL, NOP},
+			cycles:  6,
+			setup: func(cpu *SM83_CPU) {
+				cpu.h, cpu.l, cpu.flags = 0x80, 0x00, FLAG_C|FLAG_N
+				cpu.writeByteIntoMemory(0x8000, 0x0f)
+			},
+			want: registers(0x0003, TEST_STACK, FLAG_H|FLAG_C, 0x10, 0x0000, 0x0000, 0x8000),
+		},
+		{
+			name:    "DEC (HL) to zero",
+			program: []uint8{DEC_ADDR_HL, LD_A_ADDR_HL, NOP},
+			cycles:  6,
+			setup: func(cpu *SM83_CPU) {
+				cpu.h, cpu.l = 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x01)
+			},
+			want: registers(0x0003, TEST_STACK, FLAG_Z|FLAG_N, 0x00, 0x0000, 0x0000, 0x8000),
+		},
+		{
+			name:    "DEC (HL) with half borrow",
+			program: []uint8{DEC_ADDR_HL, LD_A_ADDR_HL, NOP},
+			cycles:  6,
+			setup: func(cpu *SM83_CPU) {
+				cpu.h, cpu.l = 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x00)
+			},
+			want: registers(0x0003, TEST_STACK, FLAG_N|FLAG_H, 0xff, 0x0000, 0x0000, 0x8000),
+		},
+	})
+}
+
+// SUB instructions unit tests
+func Test_SUB(t *testing.T) {
+
+	runProgramTests(t, "SUB", []programTest{
+		{
+			name:    "SUB B to zero",
+			program: []uint8{SUB_B, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.b = 0x3e, 0x3e },
+			want:    registers(0x0002, TEST_STACK, FLAG_Z|FLAG_N, 0x00, 0x3e00, 0x0000, 0x0000),
+		},
+		{
+			name:    "SUB n with half borrow",
+			program: []uint8{SUB_n, 0x0f, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a = 0x3e },
+			want:    registers(0x0003, TEST_STACK, FLAG_N|FLAG_H, 0x2f, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "SUB (HL) with borrow",
+			program: []uint8{SUB_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0x3e, 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x40)
+			},
+			want: registers(0x0002, TEST_STACK, FLAG_N|FLAG_C, 0xfe, 0x0000, 0x0000, 0x8000),
+		},
+	})
+}
+
+// SBC instructions unit tests
+func Test_SBC(t *testing.T) {
+
+	runProgramTests(t, "SBC", []programTest{
+		{
+			name:    "SBC n with carry in",
+			program: []uint8{SBC_n, 0x2a, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x3b, FLAG_C },
+			want:    registers(0x0003, TEST_STACK, FLAG_N, 0x10, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "SBC A with carry in",
+			program: []uint8{SBC_A, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x10, FLAG_C },
+			want:    registers(0x0002, TEST_STACK, FLAG_N|FLAG_H|FLAG_C, 0xff, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "SBC (HL) without carry in",
+			program: []uint8{SBC_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0x3b, 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x3b)
+			},
+			want: registers(0x0002, TEST_STACK, FLAG_Z|FLAG_N, 0x00, 0x0000, 0x0000, 0x8000),
+		},
+	})
+}
+
+// CP instructions unit tests
+func Test_CP(t *testing.T) {
+
+	runProgramTests(t, "CP", []programTest{
+		{
+			name:    "CP n lower than A",
+			program: []uint8{CP_n, 0x40, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a = 0x3c },
+			want:    registers(0x0003, TEST_STACK, FLAG_N|FLAG_C, 0x3c, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "CP (HL) equal to A",
+			program: []uint8{CP_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0x3c, 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x3c)
+			},
+			want: registers(0x0002, TEST_STACK, FLAG_Z|FLAG_N, 0x3c, 0x0000, 0x0000, 0x8000),
+		},
+		{
+			name:    "CP E with half borrow",
+			program: []uint8{CP_E, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.e = 0x3c, 0x2f },
+			want:    registers(0x0002, TEST_STACK, FLAG_N|FLAG_H, 0x3c, 0x0000, 0x002f, 0x0000),
+		},
+	})
+}
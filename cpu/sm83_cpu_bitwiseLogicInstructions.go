@@ -0,0 +1,228 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_bitwiseLogicInstructions.go - Oct-19-2026 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - bitwise logic instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+)
+
+/*
+AND A,r8   --> AND_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#AND_A,r8)
+AND A,[HL] --> AND_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#AND_A,_HL_)
+AND A,n8   --> AND_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#AND_A,n8)
+CPL        --> CPL         (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CPL)
+OR A,r8    --> OR_X        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#OR_A,r8)
+OR A,[HL]  --> OR_ADDR_HL  (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#OR_A,_HL_)
+OR A,n8    --> OR_n        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#OR_A,n8)
+XOR A,r8   --> XOR_X       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#XOR_A,r8)
+XOR A,[HL] --> XOR_ADDR_HL (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#XOR_A,_HL_)
+XOR A,n8   --> XOR_n       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#XOR_A,n8)
+*/
+
+// set the flags of a logic operation: Z from the result, H set by AND only
+func (c *SM83_CPU) logicFlags(result uint8, halfCarry uint8) {
+
+	c.flags = halfCarry
+	if result == 0x00 {
+		c.flags |= FLAG_Z
+	}
+}
+
+// execute instruction AND_X
+func (c *SM83_CPU) executeInstruction_AND_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.a &= r
+		c.logicFlags(c.a, FLAG_H)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] AND %s: 0x%02x\n", reg, c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction AND_ADDR_HL
+func (c *SM83_CPU) executeInstruction_AND_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a &= c.n_lsb
+		c.logicFlags(c.a, FLAG_H)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] AND (HL): 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction AND_n
+func (c *SM83_CPU) executeInstruction_AND_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a &= c.n_lsb
+		c.logicFlags(c.a, FLAG_H)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] AND n: 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction OR_X
+func (c *SM83_CPU) executeInstruction_OR_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.a |= r
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] OR %s: 0x%02x\n", reg, c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction OR_ADDR_HL
+func (c *SM83_CPU) executeInstruction_OR_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a |= c.n_lsb
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] OR (HL): 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction OR_n
+func (c *SM83_CPU) executeInstruction_OR_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a |= c.n_lsb
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] OR n: 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction XOR_X
+func (c *SM83_CPU) executeInstruction_XOR_X(r uint8, reg string) error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.a ^= r
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] XOR %s: 0x%02x\n", reg, c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction XOR_ADDR_HL
+func (c *SM83_CPU) executeInstruction_XOR_ADDR_HL() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a ^= c.n_lsb
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] XOR (HL): 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction XOR_n
+func (c *SM83_CPU) executeInstruction_XOR_n() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.a ^= c.n_lsb
+		c.logicFlags(c.a, 0x00)
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] XOR n: 0x%02x\n", c.a)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
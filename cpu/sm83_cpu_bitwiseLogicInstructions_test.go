@@ -0,0 +1,104 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_bitwiseLogicInstructions_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - bitwise logic instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"testing"
+)
+
+// AND instructions unit tests
+func Test_AND(t *testing.T) {
+
+	runProgramTests(t, "AND", []programTest{
+		{
+			name:    "AND B sets the half carry",
+			program: []uint8{AND_B, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.b, cpu.flags = 0xf0, 0x3c, FLAG_C },
+			want:    registers(0x0002, TEST_STACK, FLAG_H, 0x30, 0x3c00, 0x0000, 0x0000),
+		},
+		{
+			name:    "AND n with a zero result",
+			program: []uint8{AND_n, 0x0f, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a = 0xf0 },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_H, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "AND (HL)",
+			program: []uint8{AND_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0x5a, 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x0f)
+			},
+			want: registers(0x0002, TEST_STACK, FLAG_H, 0x0a, 0x0000, 0x0000, 0x8000),
+		},
+	})
+}
+
+// OR instructions unit tests
+func Test_OR(t *testing.T) {
+
+	runProgramTests(t, "OR", []programTest{
+		{
+			name:    "OR C clears the carry",
+			program: []uint8{OR_C, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.c, cpu.flags = 0x50, 0x05, FLAG_C|FLAG_N },
+			want:    registers(0x0002, TEST_STACK, 0x00, 0x55, 0x0005, 0x0000, 0x0000),
+		},
+		{
+			name:    "OR n with a zero result",
+			program: []uint8{OR_n, 0x00, NOP},
+			cycles:  3,
+			setup:   nil,
+			want:    registers(0x0003, TEST_STACK, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "OR (HL)",
+			program: []uint8{OR_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0xf0, 0x80, 0x01
+				cpu.writeByteIntoMemory(0x8001, 0x0f)
+			},
+			want: registers(0x0002, TEST_STACK, 0x00, 0xff, 0x0000, 0x0000, 0x8001),
+		},
+	})
+}
+
+// XOR instructions unit tests
+func Test_XOR(t *testing.T) {
+
+	runProgramTests(t, "XOR", []programTest{
+		{
+			name:    "XOR A clears A",
+			program: []uint8{XOR_A, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x5a, FLAG_C|FLAG_H },
+			want:    registers(0x0002, TEST_STACK, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "XOR n",
+			program: []uint8{XOR_n, 0xff, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a = 0x5a },
+			want:    registers(0x0003, TEST_STACK, 0x00, 0xa5, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "XOR (HL)",
+			program: []uint8{XOR_ADDR_HL, NOP},
+			cycles:  3,
+			setup: func(cpu *SM83_CPU) {
+				cpu.a, cpu.h, cpu.l = 0x0f, 0x80, 0x02
+				cpu.writeByteIntoMemory(0x8002, 0x0f)
+			},
+			want: registers(0x0002, TEST_STACK, FLAG_Z, 0x00, 0x0000, 0x0000, 0x8002),
+		},
+	})
+}
@@ -4,7 +4,7 @@
 //	Emulator for Sharp SM83 CPU - instructions 0x00 - 0x0f
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
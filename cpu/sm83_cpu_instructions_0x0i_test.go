@@ -4,11 +4,13 @@
 //	Test cases for Sharp SM83 CPU - instructions 0x00 - 0x0f
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // RLCA instruction unit tests
@@ -25,7 +27,7 @@ func Test_RLCA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -75,7 +77,7 @@ func Test_RLCA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -131,7 +133,7 @@ func Test_LD_ADDR_nn_SP(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -152,7 +154,7 @@ func Test_LD_ADDR_nn_SP(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -216,7 +218,7 @@ func Test_RRCA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -266,7 +268,7 @@ func Test_RRCA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
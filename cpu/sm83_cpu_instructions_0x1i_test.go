@@ -4,11 +4,13 @@
 //	Test cases for Sharp SM83 CPU - instructions 0x10 - 0x1f
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // RLA instruction unit tests
@@ -25,7 +27,7 @@ func Test_RLA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -75,7 +77,7 @@ func Test_RLA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -131,7 +133,7 @@ func Test_JR_e(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -191,7 +193,7 @@ func Test_JR_e(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -249,7 +251,7 @@ func Test_RRA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -299,7 +301,7 @@ func Test_RRA(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -4,7 +4,7 @@
 //	Emulator for Sharp SM83 CPU - instructions 0x20 - 0x2f
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
@@ -38,47 +38,26 @@ func (c *SM83_CPU) executeInstruction_JR_NZ_e() error {
 
 // execute instruction DAA
 func (c *SM83_CPU) executeInstruction_DAA() error {
+	var adjust uint8
 
-	// TODO: review implementation of DAA
-	/*
-		DAA: function() {
-			var a=Z80._r.a;
-			if((Z80._r.f&0x20)||((Z80._r.a&15)>9))
-				Z80._r.a+=6;
-			Z80._r.f&=0xEF;
-			if((Z80._r.f&0x20)||(a>0x99)) {
-				Z80._r.a+=0x60;
-				Z80._r.f|=0x10;
-			}
-			Z80._r.m=1;
-		},
-	*/
-
-	switch c.cpu_state {
-	case EXECUTION_CYCLE_1:
-		if c.flags&FLAG_H != 0 || c.a&0x0F > 0x09 {
-			c.a += 0x06
-		}
-		c.cpu_state = EXECUTION_CYCLE_2
-
-		return nil
-
-	case EXECUTION_CYCLE_2:
-		c.flags &= ^FLAG_N
-		c.cpu_state = EXECUTION_CYCLE_3
-
-		return nil
-
-	case EXECUTION_CYCLE_3:
-		if c.flags&FLAG_H != 0 || c.a > 0x99 {
-			c.a += 0x60
-			c.flags |= FLAG_C
-		}
-		c.cpu_state = EXECUTION_CYCLE_4
+	//	adjust A to BCD after an addition or a subtraction (N flag) using the carries it left
+	if c.flags&FLAG_H != 0 || (c.flags&FLAG_N == 0 && c.a&0x0f > 0x09) {
+		adjust |= 0x06
+	}
+	if c.flags&FLAG_C != 0 || (c.flags&FLAG_N == 0 && c.a > 0x99) {
+		adjust |= 0x60
+		c.flags |= FLAG_C
+	}
 
-		return nil
+	if c.flags&FLAG_N == 0 {
+		c.a += adjust
+	} else {
+		c.a -= adjust
+	}
 
-	case EXECUTION_CYCLE_4:
+	c.flags &= FLAG_N | FLAG_C
+	if c.a == 0x00 {
+		c.flags |= FLAG_Z
 	}
 
 	if c.trace {
@@ -118,33 +97,8 @@ func (c *SM83_CPU) executeInstruction_JR_Z_e() error {
 // execute instruction CPL
 func (c *SM83_CPU) executeInstruction_CPL() error {
 
-	// TODO: review implementation of CPL
-	switch c.cpu_state {
-	case EXECUTION_CYCLE_1:
-		if c.flags&FLAG_H != 0 || c.a&0x0F > 0x09 {
-			c.a += 0x06
-		}
-		c.cpu_state = EXECUTION_CYCLE_2
-
-		return nil
-
-	case EXECUTION_CYCLE_2:
-		c.flags &= ^FLAG_N
-		c.cpu_state = EXECUTION_CYCLE_3
-
-		return nil
-
-	case EXECUTION_CYCLE_3:
-		if c.flags&FLAG_H != 0 || c.a > 0x99 {
-			c.a += 0x60
-			c.flags |= FLAG_C
-		}
-		c.cpu_state = EXECUTION_CYCLE_4
-
-		return nil
-
-	case EXECUTION_CYCLE_4:
-	}
+	c.a = ^c.a
+	c.flags |= FLAG_N | FLAG_H
 
 	if c.trace {
 		fmt.Printf("[trace] CPL: 0x%02x\n", c.a)
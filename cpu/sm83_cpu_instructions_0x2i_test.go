@@ -4,11 +4,13 @@
 //	Test cases for Sharp SM83 CPU - instructions 0x20 - 0x2f
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // JRNZ e instruction unit tests
@@ -25,7 +27,7 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -77,7 +79,7 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -129,7 +131,7 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -171,3 +173,45 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 	})
 }
+
+// DAA instruction unit tests
+func Test_DAA(t *testing.T) {
+
+	runProgramTests(t, "DAA", []programTest{
+		{
+			name:    "adjust after an addition",
+			program: []uint8{ADD_B, DAA, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.b = 0x45, 0x38 },
+			want:    registers(0x0003, TEST_STACK, 0x00, 0x83, 0x3800, 0x0000, 0x0000),
+		},
+		{
+			name:    "adjust after an addition with carry",
+			program: []uint8{ADD_B, DAA, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.b = 0x99, 0x01 },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0100, 0x0000, 0x0000),
+		},
+		{
+			name:    "adjust after a subtraction",
+			program: []uint8{SUB_B, DAA, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.b = 0x83, 0x38 },
+			want:    registers(0x0003, TEST_STACK, FLAG_N, 0x45, 0x3800, 0x0000, 0x0000),
+		},
+	})
+}
+
+// CPL instruction unit tests
+func Test_CPL(t *testing.T) {
+
+	runProgramTests(t, "CPL", []programTest{
+		{
+			name:    "complement A",
+			program: []uint8{CPL, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x35, FLAG_Z|FLAG_C },
+			want:    registers(0x0002, TEST_STACK, FLAG_Z|FLAG_N|FLAG_H|FLAG_C, 0xca, 0x0000, 0x0000, 0x0000),
+		},
+	})
+}
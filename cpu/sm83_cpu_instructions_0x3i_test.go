@@ -0,0 +1,60 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_instructions_0x3i_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - instructions 0x30 - 0x3f
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"testing"
+)
+
+// JR C instruction unit tests
+func Test_JR_C_e(t *testing.T) {
+
+	runProgramTests(t, "JR_C_e", []programTest{
+		{
+			name:    "jump with carry",
+			program: []uint8{JR_C_e, 0x02, NOP, NOP, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_C },
+			want:    registers(0x0005, TEST_STACK, FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "no jump without carry",
+			program: []uint8{JR_C_e, 0x02, NOP, NOP, NOP},
+			cycles:  3,
+			setup:   nil,
+			want:    registers(0x0003, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+	})
+}
+
+// SCF and CCF instructions unit tests
+func Test_SCF_CCF(t *testing.T) {
+
+	runProgramTests(t, "SCF/CCF", []programTest{
+		{
+			name:    "SCF keeps Z",
+			program: []uint8{SCF, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_Z | FLAG_N | FLAG_H },
+			want:    registers(0x0002, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "CCF clears the carry",
+			program: []uint8{CCF, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_N | FLAG_H | FLAG_C },
+			want:    registers(0x0002, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "CCF sets the carry",
+			program: []uint8{CCF, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_Z },
+			want:    registers(0x0002, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+	})
+}
@@ -0,0 +1,120 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_interruptInstructions.go - Oct-19-2026 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - interrupt-related instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+/*
+DI   --> DI   (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#DI)
+EI   --> EI   (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#EI)
+HALT --> HALT (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#HALT)
+*/
+
+// execute instruction DI
+func (c *SM83_CPU) executeInstruction_DI() error {
+
+	c.ime = 0
+
+	if c.trace {
+		fmt.Printf("[trace] DI\n")
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction EI
+func (c *SM83_CPU) executeInstruction_EI() error {
+
+	if c.trace {
+		fmt.Printf("[trace] EI\n")
+	}
+
+	//	IME is set after the next instruction: the fetch in this cycle doesn't service interrupts yet
+	err := c.fetchInstruction()
+	c.ime = 1
+
+	return err
+}
+
+// execute instruction HALT
+func (c *SM83_CPU) executeInstruction_HALT() error {
+
+	if c.trace {
+		fmt.Printf("[trace] HALT\n")
+	}
+
+	//	the CPU idles until an interrupt is pending
+	if c.pending() == 0 {
+		c.halted = true
+		c.cpu_state = FETCHING_INSTRUCTION
+
+		return nil
+	}
+
+	//	with an interrupt already pending HALT ends at once, and while IME is clear the byte after
+	//	HALT is read twice (HALT bug)
+	if c.ime == 0 {
+		err := c.fetchInstruction()
+		c.pc--
+
+		return err
+	}
+
+	return c.fetchInstruction()
+}
+
+// service the highest priority pending interrupt: push PC and jump to its vector (five machine cycles,
+// the first one taking the place of the instruction fetch)
+func (c *SM83_CPU) executeInterruptDispatch() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return nil
+
+	case EXECUTION_CYCLE_2:
+		err = c.pushByte(uint8((c.pc & 0xff00) >> 8))
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		err = c.pushByte(uint8(c.pc & 0x00ff))
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return err
+
+	case EXECUTION_CYCLE_4:
+		//	the interrupt is chosen after pushing PC: if it was cleared meanwhile (e.g. the push wrote
+		//	into IE) the CPU jumps to 0x0000
+		pending := c.pending()
+		if pending == 0 {
+			c.pc = 0x0000
+		} else {
+			number := bits.TrailingZeros8(pending)
+
+			if c.acknowledgeInterrupt != nil {
+				c.acknowledgeInterrupt(uint8(1) << number)
+			}
+			c.pc = INTERRUPT_VECTOR + uint16(number)*INTERRUPT_VECTOR_SIZE
+		}
+		c.dispatching = false
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] interrupt: 0x%04x\n", c.pc)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
@@ -0,0 +1,217 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_interruptInstructions_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - interrupt-related instructions and interrupt dispatch
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// IF and IE registers connected to a test CPU
+type testInterrupts struct {
+	requested uint8
+	enabled   uint8
+}
+
+// connect the test interrupt registers to a CPU
+func (i *testInterrupts) connect(cpu *SM83_CPU) {
+	cpu.ConnectInterrupts(
+		func() uint8 { return i.requested & i.enabled },
+		func(interrupt uint8) { i.requested &^= interrupt },
+	)
+}
+
+// a program of NOPs long enough to hold the interrupt vectors, starting with some instructions
+func interruptProgram(instructions ...uint8) []uint8 {
+	var program = make([]uint8, 0x0070)
+
+	copy(program, instructions)
+
+	return program
+}
+
+// read the return address pushed on the stack
+func returnAddress(ram *memory.RAM_memory, sp uint16) uint16 {
+
+	lsb, _ := ram.ReadByte(sp - TEST_RAM_ADDRESS)
+	msb, _ := ram.ReadByte(sp + 1 - TEST_RAM_ADDRESS)
+
+	return uint16(msb)<<8 | uint16(lsb)
+}
+
+// interrupt dispatch unit tests
+func Test_InterruptDispatch(t *testing.T) {
+
+	t.Run(">>> interrupt: scenario 1 - dispatch to the timer vector in five cycles", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: INTERRUPT_TIMER, enabled: INTERRUPT_TIMER | INTERRUPT_VBLANK}
+
+		cpu, ram := runTestProgram(t, interruptProgram(NOP), 5, func(cpu *SM83_CPU) {
+			interrupts.connect(cpu)
+			cpu.ime = 1
+		})
+
+		//	the dispatch took the place of the first fetch, and the vector of the timer interrupt (0x50)
+		//	was fetched in the last cycle
+		if cpu.pc != 0x0051 || cpu.sp() != TEST_STACK-2 || returnAddress(ram, cpu.sp()) != 0x0000 {
+			t.Errorf("failed dispatching interrupt: expected: PC 0x0051, return address 0x0000\n\tresult: %s, return address 0x%04x",
+				cpu.DumpRegisters(), returnAddress(ram, cpu.sp()))
+		}
+		if cpu.ime != 0 || interrupts.requested != 0x00 {
+			t.Errorf("failed acknowledging interrupt: expected: IME 0, IF 0x00\n\tresult: IME %d, IF 0x%02x", cpu.ime, interrupts.requested)
+		}
+	})
+
+	t.Run(">>> interrupt: scenario 2 - priority of the lowest bit", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: 0x1f, enabled: INTERRUPT_LCD_STAT | INTERRUPT_SERIAL}
+
+		cpu, _ := runTestProgram(t, interruptProgram(NOP), 5, func(cpu *SM83_CPU) {
+			interrupts.connect(cpu)
+			cpu.ime = 1
+		})
+
+		if cpu.pc != 0x0049 || interrupts.requested != 0x1d {
+			t.Errorf("failed dispatching interrupt: expected: PC 0x0049, IF 0x1d\n\tresult: PC 0x%04x, IF 0x%02x", cpu.pc, interrupts.requested)
+		}
+	})
+
+	t.Run(">>> interrupt: scenario 3 - no dispatch while IME is clear", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: INTERRUPT_VBLANK, enabled: INTERRUPT_VBLANK}
+
+		cpu, _ := runTestProgram(t, interruptProgram(NOP, NOP, NOP), 4, interrupts.connect)
+
+		if cpu.pc != 0x0004 || interrupts.requested != INTERRUPT_VBLANK {
+			t.Errorf("failed ignoring interrupt: expected: PC 0x0004\n\tresult: PC 0x%04x", cpu.pc)
+		}
+	})
+}
+
+// EI and DI instructions unit tests
+func Test_EI_DI(t *testing.T) {
+
+	t.Run(">>> EI: scenario 1 - interrupts are serviced after the next instruction", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: INTERRUPT_VBLANK, enabled: INTERRUPT_VBLANK}
+
+		cpu, ram := runTestProgram(t, interruptProgram(EI, INC_A, NOP), 7, interrupts.connect)
+
+		if cpu.pc != 0x0041 || cpu.a != 0x01 || returnAddress(ram, cpu.sp()) != 0x0002 {
+			t.Errorf("failed executing instruction EI: expected: PC 0x0041, A 0x01, return address 0x0002\n\tresult: %s, return address 0x%04x",
+				cpu.DumpRegisters(), returnAddress(ram, cpu.sp()))
+		}
+	})
+
+	t.Run(">>> DI: scenario 1 - EI followed by DI services no interrupt", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: INTERRUPT_VBLANK, enabled: INTERRUPT_VBLANK}
+
+		cpu, _ := runTestProgram(t, interruptProgram(EI, DI, NOP, NOP), 4, interrupts.connect)
+
+		if cpu.pc != 0x0004 || cpu.ime != 0 || interrupts.requested != INTERRUPT_VBLANK {
+			t.Errorf("failed executing instruction DI: expected: PC 0x0004, IME 0\n\tresult: PC 0x%04x, IME %d", cpu.pc, cpu.ime)
+		}
+	})
+
+	t.Run(">>> RETI: scenario 1 - return enabling interrupts at once", func(t *testing.T) {
+
+		cpu, _ := runTestProgram(t, interruptProgram(RETI, NOP, NOP, NOP), 5, func(cpu *SM83_CPU) {
+			cpu.setSP(TEST_STACK - 2)
+			cpu.writeByteIntoMemory(TEST_STACK-2, 0x03)
+			cpu.writeByteIntoMemory(TEST_STACK-1, 0x00)
+		})
+
+		if cpu.pc != 0x0004 || cpu.sp() != TEST_STACK || cpu.ime != 1 {
+			t.Errorf("failed executing instruction RETI: expected: PC 0x0004, IME 1\n\tresult: %s, IME %d", cpu.DumpRegisters(), cpu.ime)
+		}
+	})
+}
+
+// HALT instruction unit tests
+func Test_HALT(t *testing.T) {
+
+	t.Run(">>> HALT (0x76): scenario 1 - wake up without IME", func(t *testing.T) {
+
+		var interrupts testInterrupts
+
+		cpu, _ := runTestProgram(t, interruptProgram(HALT, INC_A, NOP), 10, interrupts.connect)
+		if !cpu.halted || cpu.pc != 0x0001 {
+			t.Fatalf("failed executing instruction HALT: expected: halted at PC 0x0001\n\tresult: PC 0x%04x", cpu.pc)
+		}
+
+		//	an interrupt requested but not enabled doesn't wake the CPU
+		interrupts.requested = INTERRUPT_TIMER
+		cpu.MachineCycle()
+		if !cpu.halted {
+			t.Errorf("failed executing instruction HALT: expected: halted with IE clear")
+		}
+
+		interrupts.enabled = INTERRUPT_TIMER
+		cpu.MachineCycle()
+		cpu.MachineCycle()
+		if cpu.halted || cpu.a != 0x01 || cpu.pc != 0x0003 || interrupts.requested != INTERRUPT_TIMER {
+			t.Errorf("failed waking up: expected: A 0x01, PC 0x0003, IF 0x04\n\tresult: %s, IF 0x%02x", cpu.DumpRegisters(), interrupts.requested)
+		}
+	})
+
+	t.Run(">>> HALT (0x76): scenario 2 - wake up servicing the interrupt", func(t *testing.T) {
+
+		var interrupts = testInterrupts{enabled: INTERRUPT_VBLANK}
+
+		cpu, ram := runTestProgram(t, interruptProgram(HALT, INC_A, NOP), 10, func(cpu *SM83_CPU) {
+			interrupts.connect(cpu)
+			cpu.ime = 1
+		})
+
+		interrupts.requested = INTERRUPT_VBLANK
+		for range 5 {
+			cpu.MachineCycle()
+		}
+		if cpu.pc != 0x0041 || returnAddress(ram, cpu.sp()) != 0x0001 || interrupts.requested != 0x00 {
+			t.Errorf("failed waking up: expected: PC 0x0041, return address 0x0001\n\tresult: %s, return address 0x%04x",
+				cpu.DumpRegisters(), returnAddress(ram, cpu.sp()))
+		}
+	})
+
+	t.Run(">>> HALT (0x76): scenario 3 - HALT bug with an interrupt pending and IME clear", func(t *testing.T) {
+
+		var interrupts = testInterrupts{requested: INTERRUPT_SERIAL, enabled: INTERRUPT_SERIAL}
+
+		cpu, _ := runTestProgram(t, interruptProgram(HALT, INC_A, NOP), 4, interrupts.connect)
+
+		//	the byte after HALT is read twice, so INC A runs twice
+		if cpu.halted || cpu.a != 0x02 || cpu.pc != 0x0003 {
+			t.Errorf("failed executing instruction HALT: expected: A 0x02, PC 0x0003\n\tresult: %s", cpu.DumpRegisters())
+		}
+	})
+
+	t.Run(">>> HALT (0x76): scenario 4 - save state while halted", func(t *testing.T) {
+
+		var interrupts testInterrupts
+
+		cpu, _ := runTestProgram(t, interruptProgram(HALT, INC_A, NOP), 3, interrupts.connect)
+
+		w := savestate.NewWriter()
+		cpu.SaveState(w)
+
+		restored := NewSM83_CPU(trace)
+		err := restored.LoadState(savestate.NewReader(w.Data(), 1))
+		if err != nil || !restored.halted || restored.pc != cpu.pc {
+			t.Errorf("failed restoring state: expected: halted at PC 0x%04x\n\tresult: halted %v at PC 0x%04x (%v)", cpu.pc, restored.halted, restored.pc, err)
+		}
+
+		other := savestate.NewWriter()
+		restored.SaveState(other)
+		if !bytes.Equal(other.Data(), w.Data()) {
+			t.Errorf("failed restoring state: expected the saved state back")
+		}
+	})
+}
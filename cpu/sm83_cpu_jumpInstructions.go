@@ -0,0 +1,254 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_jumpInstructions.go - Oct-19-2026 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - jumps and subroutine instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+)
+
+/*
+CALL n16    --> CALL_nn    (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CALL_n16)
+CALL cc,n16 --> CALL_cc_nn (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#CALL_cc,n16)
+JP HL       --> JP_HL      (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#JP_HL)
+JP n16      --> JP_nn      (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#JP_n16)
+JP cc,n16   --> JP_cc_nn   (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#JP_cc,n16)
+RET         --> RET        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RET)
+RET cc      --> RET_cc     (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RET_cc)
+RETI        --> RETI       (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RETI)
+RST vec     --> RST        (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RST_vec)
+*/
+
+// execute instruction JP_nn
+func (c *SM83_CPU) executeInstruction_JP_nn() error {
+	return c.executeInstruction_JP_cc_nn(true, "")
+}
+
+// execute instruction JP_cc_nn (JP_nn when the condition is always true)
+func (c *SM83_CPU) executeInstruction_JP_cc_nn(condition bool, cc string) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_msb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		if condition {
+			c.pc = uint16(c.n_msb)<<8 | uint16(c.n_lsb)
+			c.cpu_state = EXECUTION_CYCLE_4
+
+			return nil
+		}
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] JP %s nn: 0x%02x%02x\n", cc, c.n_msb, c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction JP_HL
+func (c *SM83_CPU) executeInstruction_JP_HL() error {
+
+	c.pc = uint16(c.h)<<8 | uint16(c.l)
+
+	if c.trace {
+		fmt.Printf("[trace] JP HL: 0x%04x\n", c.pc)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction CALL_nn
+func (c *SM83_CPU) executeInstruction_CALL_nn() error {
+	return c.executeInstruction_CALL_cc_nn(true, "")
+}
+
+// execute instruction CALL_cc_nn (CALL_nn when the condition is always true)
+func (c *SM83_CPU) executeInstruction_CALL_cc_nn(condition bool, cc string) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_msb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		if condition {
+			c.cpu_state = EXECUTION_CYCLE_4
+
+			return nil
+		}
+
+	case EXECUTION_CYCLE_4:
+		err = c.pushByte(uint8((c.pc & 0xff00) >> 8))
+		c.cpu_state = EXECUTION_CYCLE_5
+
+		return err
+
+	case EXECUTION_CYCLE_5:
+		err = c.pushByte(uint8(c.pc & 0x00ff))
+		c.pc = uint16(c.n_msb)<<8 | uint16(c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_6
+
+		return err
+
+	case EXECUTION_CYCLE_6:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] CALL %s nn: 0x%02x%02x\n", cc, c.n_msb, c.n_lsb)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction RET
+func (c *SM83_CPU) executeInstruction_RET() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_msb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		c.pc = uint16(c.n_msb)<<8 | uint16(c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return nil
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] RET: 0x%04x\n", c.pc)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction RET_cc
+func (c *SM83_CPU) executeInstruction_RET_cc(condition bool, cc string) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		//	when the condition is false the next instruction is fetched in the following cycle
+		if !condition {
+			c.cpu_state = EXECUTION_CYCLE_5
+
+			return nil
+		}
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return nil
+
+	case EXECUTION_CYCLE_2:
+		c.n_lsb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		c.n_msb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return err
+
+	case EXECUTION_CYCLE_4:
+		c.pc = uint16(c.n_msb)<<8 | uint16(c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_5
+
+		return nil
+
+	case EXECUTION_CYCLE_5:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] RET %s: 0x%04x\n", cc, c.pc)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction RETI
+func (c *SM83_CPU) executeInstruction_RETI() error {
+
+	//	IME is set at once, not after the next instruction as with EI
+	if c.cpu_state == EXECUTION_CYCLE_3 {
+		c.ime = 1
+	}
+
+	return c.executeInstruction_RET()
+}
+
+// execute instruction RST
+func (c *SM83_CPU) executeInstruction_RST(vector uint16) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return nil
+
+	case EXECUTION_CYCLE_2:
+		err = c.pushByte(uint8((c.pc & 0xff00) >> 8))
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		err = c.pushByte(uint8(c.pc & 0x00ff))
+		c.pc = vector
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return err
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] RST: 0x%04x\n", vector)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
@@ -0,0 +1,111 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_jumpInstructions_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - jumps and subroutine instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"testing"
+)
+
+// JP instructions unit tests
+func Test_JP(t *testing.T) {
+
+	runProgramTests(t, "JP", []programTest{
+		{
+			name:    "JP nn",
+			program: []uint8{JP_nn, 0x05, 0x00, NOP, NOP, NOP},
+			cycles:  5,
+			setup:   nil,
+			want:    registers(0x0006, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "JP NZ nn not taken in three cycles",
+			program: []uint8{JP_NZ_nn, 0x05, 0x00, NOP, NOP, NOP},
+			cycles:  4,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_Z },
+			want:    registers(0x0004, TEST_STACK, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "JP C nn taken",
+			program: []uint8{JP_C_nn, 0x05, 0x00, NOP, NOP, NOP},
+			cycles:  5,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_C },
+			want:    registers(0x0006, TEST_STACK, FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "JP HL",
+			program: []uint8{JP_HL, NOP, NOP, NOP, NOP},
+			cycles:  2,
+			setup:   func(cpu *SM83_CPU) { cpu.h, cpu.l = 0x00, 0x04 },
+			want:    registers(0x0005, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0004),
+		},
+	})
+}
+
+// CALL, RET and RST instructions unit tests
+func Test_CALL_RET(t *testing.T) {
+
+	runProgramTests(t, "CALL/RET", []programTest{
+		{
+			name:    "CALL nn pushes the return address",
+			program: []uint8{CALL_nn, 0x05, 0x00, NOP, NOP, RET},
+			cycles:  7,
+			setup:   nil,
+			want:    registers(0x0006, TEST_STACK-2, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "CALL nn and RET",
+			program: []uint8{CALL_nn, 0x05, 0x00, NOP, NOP, RET},
+			cycles:  11,
+			setup:   nil,
+			want:    registers(0x0004, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "CALL NZ nn not taken",
+			program: []uint8{CALL_NZ_nn, 0x05, 0x00, NOP, NOP, RET},
+			cycles:  4,
+			setup:   func(cpu *SM83_CPU) { cpu.flags = FLAG_Z },
+			want:    registers(0x0004, TEST_STACK, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "RET Z not taken in two cycles",
+			program: []uint8{RET_Z, NOP},
+			cycles:  3,
+			setup:   nil,
+			want:    registers(0x0002, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "RET C taken in five cycles",
+			program: []uint8{RET_C, NOP, NOP, NOP},
+			cycles:  6,
+			setup: func(cpu *SM83_CPU) {
+				cpu.flags = FLAG_C
+				cpu.setSP(TEST_STACK - 2)
+				cpu.writeByteIntoMemory(TEST_STACK-2, 0x03)
+				cpu.writeByteIntoMemory(TEST_STACK-1, 0x00)
+			},
+			want: registers(0x0004, TEST_STACK, FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "RST 08",
+			program: []uint8{RST_08, NOP, NOP, NOP, NOP, NOP, NOP, NOP, NOP},
+			cycles:  5,
+			setup:   nil,
+			want:    registers(0x0009, TEST_STACK-2, 0x00, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+	})
+
+	t.Run(">>> CALL/RET: scenario 7 - return address on the stack", func(t *testing.T) {
+
+		cpu, ram := runTestProgram(t, []uint8{NOP, CALL_nn, 0x06, 0x00, NOP, NOP, NOP}, 8, nil)
+
+		msb, _ := ram.ReadByte(TEST_STACK - 1 - TEST_RAM_ADDRESS)
+		lsb, _ := ram.ReadByte(TEST_STACK - 2 - TEST_RAM_ADDRESS)
+		if cpu.sp() != TEST_STACK-2 || uint16(msb)<<8|uint16(lsb) != 0x0004 {
+			t.Errorf("failed executing instruction CALL: expected: return address 0x0004\n\tresult: 0x%02x%02x (SP: 0x%04x)", msb, lsb, cpu.sp())
+		}
+	})
+}
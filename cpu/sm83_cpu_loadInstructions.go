@@ -4,7 +4,7 @@
 //	Emulator for Sharp SM83 CPU - load instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
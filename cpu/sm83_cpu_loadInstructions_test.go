@@ -4,11 +4,13 @@
 //	Test cases for Sharp SM83 CPU - load instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // LD X, Y instruction unit tests
@@ -25,7 +27,7 @@ func Test_LD_X_Y(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -81,7 +83,7 @@ func Test_LD_X_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -137,7 +139,7 @@ func Test_LD_XX_nn(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -194,7 +196,7 @@ func Test_LD_ADDR_HL_X(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -213,7 +215,7 @@ func Test_LD_ADDR_HL_X(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -274,7 +276,7 @@ func Test_LD_ADDR_HL_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -294,7 +296,7 @@ func Test_LD_ADDR_HL_n(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -354,7 +356,7 @@ func Test_LD_X_ADDR_HL(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -412,7 +414,7 @@ func Test_LD_ADDR_XX_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -431,7 +433,7 @@ func Test_LD_ADDR_XX_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -492,7 +494,7 @@ func Test_LD_ADDR_nn_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -513,7 +515,7 @@ func Test_LD_ADDR_nn_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -572,7 +574,7 @@ func Test_LDH_ADDR_n_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -592,7 +594,7 @@ func Test_LDH_ADDR_n_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -651,7 +653,7 @@ func Test_LDH_ADDR_C_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -670,7 +672,7 @@ func Test_LDH_ADDR_C_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -730,7 +732,7 @@ func Test_LD_A_ADDR_XX(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -749,7 +751,7 @@ func Test_LD_A_ADDR_XX(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -812,7 +814,7 @@ func Test_LD_A_ADDR_nn(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -833,7 +835,7 @@ func Test_LD_A_ADDR_nn(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -894,7 +896,7 @@ func Test_LDH_A_ADDR_n(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -914,7 +916,7 @@ func Test_LDH_A_ADDR_n(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -975,7 +977,7 @@ func Test_LDH_A_ADDR_C(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -994,7 +996,7 @@ func Test_LDH_A_ADDR_C(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1056,7 +1058,7 @@ func Test_LD_ADDR_HLI_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1075,7 +1077,7 @@ func Test_LD_ADDR_HLI_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -1136,7 +1138,7 @@ func Test_LD_ADDR_HLD_A(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1155,7 +1157,7 @@ func Test_LD_ADDR_HLD_A(t *testing.T) {
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
@@ -1216,7 +1218,7 @@ func Test_LD_A_ADDR_HLI(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1235,7 +1237,7 @@ func Test_LD_A_ADDR_HLI(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -1298,7 +1300,7 @@ func Test_LD_A_ADDR_HLD(t *testing.T) {
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
@@ -1317,7 +1319,7 @@ func Test_LD_A_ADDR_HLD(t *testing.T) {
 		}
 
 		//	create a new cartrige ROM memory bank
-		cartridgeRom := &ROM_memory{}
+		cartridgeRom := &memory.ROM_memory{}
 		if cartridgeRom == nil {
 			t.Errorf("fail creating new cartridge ROM memory")
 		}
@@ -4,7 +4,7 @@
 //	Emulator for Sharp SM83 CPU - miscellaneous instructions
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package cpu
 
 import (
 	"fmt"
@@ -0,0 +1,192 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_prefixedInstructions.go - Oct-19-2026 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - instructions prefixed by 0xcb (bit shift and bit flag instructions)
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+)
+
+/*
+BIT u3,r8   --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#BIT_u3,r8)
+BIT u3,[HL] --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#BIT_u3,_HL_)
+RES u3,r8   --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RES_u3,r8)
+RES u3,[HL] --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RES_u3,_HL_)
+SET u3,r8   --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SET_u3,r8)
+SET u3,[HL] --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SET_u3,_HL_)
+RL r8       --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RL_r8)
+RLC r8      --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RLC_r8)
+RR r8       --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RR_r8)
+RRC r8      --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#RRC_r8)
+SLA r8      --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SLA_r8)
+SRA r8      --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SRA_r8)
+SRL r8      --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SRL_r8)
+SWAP r8     --> PREFIX_CB (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#SWAP_r8)
+(and the same bit shift instructions on [HL])
+*/
+
+// prefixed opcodes: bits 7-6 select the group, bits 5-3 the bit shift operation or the bit number,
+// and bits 2-0 the operand (B, C, D, E, H, L, [HL], A)
+const (
+	CB_GROUP_MASK   = uint8(0xc0)
+	CB_BIT_SHIFT    = uint8(0x00)
+	CB_BIT          = uint8(0x40)
+	CB_RES          = uint8(0x80)
+	CB_SET          = uint8(0xc0)
+	CB_OPERAND_MASK = uint8(0x07)
+	CB_ADDR_HL      = uint8(0x06)
+
+	CB_RLC  = uint8(0)
+	CB_RRC  = uint8(1)
+	CB_RL   = uint8(2)
+	CB_RR   = uint8(3)
+	CB_SLA  = uint8(4)
+	CB_SRA  = uint8(5)
+	CB_SWAP = uint8(6)
+	CB_SRL  = uint8(7)
+)
+
+// mnemonics for the trace
+var (
+	prefixedOperation = [...]string{"RLC", "RRC", "RL", "RR", "SLA", "SRA", "SWAP", "SRL"}
+	prefixedGroup     = [...]string{"", "BIT", "RES", "SET"}
+	prefixedOperand   = [...]string{"B", "C", "D", "E", "H", "L", "(HL)", "A"}
+)
+
+// return the register operand of a prefixed opcode (nil for [HL])
+func (c *SM83_CPU) prefixedRegister(opcode uint8) *uint8 {
+
+	switch opcode & CB_OPERAND_MASK {
+	case 0:
+		return &c.b
+	case 1:
+		return &c.c
+	case 2:
+		return &c.d
+	case 3:
+		return &c.e
+	case 4:
+		return &c.h
+	case 5:
+		return &c.l
+	case 7:
+		return &c.a
+	}
+
+	return nil
+}
+
+// run a prefixed operation on a value setting the flags, returning the result
+func (c *SM83_CPU) prefixedResult(opcode uint8, value uint8) uint8 {
+	var result, out uint8
+	var bit = (opcode >> 3) & 0x07
+
+	switch opcode & CB_GROUP_MASK {
+	case CB_BIT:
+		c.flags = c.flags&FLAG_C | FLAG_H
+		if value&(1<<bit) == 0 {
+			c.flags |= FLAG_Z
+		}
+		return value
+
+	case CB_RES:
+		return value &^ (1 << bit)
+
+	case CB_SET:
+		return value | 1<<bit
+	}
+
+	//	bit shift operations: the bit shifted out goes into the carry flag
+	switch bit {
+	case CB_RLC:
+		out = value >> 7
+		result = value<<1 | out
+
+	case CB_RRC:
+		out = value & 0x01
+		result = value>>1 | out<<7
+
+	case CB_RL:
+		out = value >> 7
+		result = value<<1 | c.carry()
+
+	case CB_RR:
+		out = value & 0x01
+		result = value>>1 | c.carry()<<7
+
+	case CB_SLA:
+		out = value >> 7
+		result = value << 1
+
+	case CB_SRA:
+		out = value & 0x01
+		result = value>>1 | value&0x80
+
+	case CB_SWAP:
+		result = value<<4 | value>>4
+
+	case CB_SRL:
+		out = value & 0x01
+		result = value >> 1
+	}
+
+	c.flags = out << 4
+	if result == 0x00 {
+		c.flags |= FLAG_Z
+	}
+
+	return result
+}
+
+// execute the instructions prefixed by 0xcb
+func (c *SM83_CPU) executeInstruction_PREFIX_CB() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_msb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		register := c.prefixedRegister(c.n_msb)
+		if register != nil {
+			*register = c.prefixedResult(c.n_msb, *register)
+			break
+		}
+
+		c.n_lsb, err = c.readByteFromMemory(uint16(c.h)<<8 | uint16(c.l))
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		c.n_lsb = c.prefixedResult(c.n_msb, c.n_lsb)
+		if c.n_msb&CB_GROUP_MASK == CB_BIT {
+			break
+		}
+
+		err = c.writeByteIntoMemory(uint16(c.h)<<8|uint16(c.l), c.n_lsb)
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return err
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		operand := prefixedOperand[c.n_msb&CB_OPERAND_MASK]
+		if c.n_msb&CB_GROUP_MASK == CB_BIT_SHIFT {
+			fmt.Printf("[trace] %s %s\n", prefixedOperation[(c.n_msb>>3)&0x07], operand)
+		} else {
+			fmt.Printf("[trace] %s %d, %s\n", prefixedGroup[c.n_msb>>6], (c.n_msb>>3)&0x07, operand)
+		}
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
@@ -0,0 +1,118 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_prefixedInstructions_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - instructions prefixed by 0xcb
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"testing"
+)
+
+// bit shift instructions unit tests
+func Test_PREFIX_CB_BitShift(t *testing.T) {
+
+	runProgramTests(t, "CB bit shift", []programTest{
+		{
+			name:    "RLC B",
+			program: []uint8{PREFIX_CB, 0x00, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.b = 0x85 },
+			want:    registers(0x0003, TEST_STACK, FLAG_C, 0x00, 0x0b00, 0x0000, 0x0000),
+		},
+		{
+			name:    "RRC C",
+			program: []uint8{PREFIX_CB, 0x09, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.c = 0x01 },
+			want:    registers(0x0003, TEST_STACK, FLAG_C, 0x00, 0x0080, 0x0000, 0x0000),
+		},
+		{
+			name:    "RR A through the carry",
+			program: []uint8{PREFIX_CB, 0x1f, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x01, FLAG_C },
+			want:    registers(0x0003, TEST_STACK, FLAG_C, 0x80, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "SLA E with a zero result",
+			program: []uint8{PREFIX_CB, 0x23, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.e = 0x80 },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "SRA D keeps the sign",
+			program: []uint8{PREFIX_CB, 0x2a, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.d = 0x81 },
+			want:    registers(0x0003, TEST_STACK, FLAG_C, 0x00, 0x0000, 0xc000, 0x0000),
+		},
+		{
+			name:    "SWAP H",
+			program: []uint8{PREFIX_CB, 0x34, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.h, cpu.flags = 0xf1, FLAG_C },
+			want:    registers(0x0003, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0x1f00),
+		},
+		{
+			name:    "SRL L",
+			program: []uint8{PREFIX_CB, 0x3d, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.l = 0x01 },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "RL (HL) in four cycles",
+			program: []uint8{PREFIX_CB, 0x16, NOP},
+			cycles:  5,
+			setup: func(cpu *SM83_CPU) {
+				cpu.h, cpu.l = 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x80)
+			},
+			want: registers(0x0003, TEST_STACK, FLAG_Z|FLAG_C, 0x00, 0x0000, 0x0000, 0x8000),
+		},
+	})
+}
+
+// bit flag instructions unit tests
+func Test_PREFIX_CB_BitFlag(t *testing.T) {
+
+	runProgramTests(t, "CB bit flag", []programTest{
+		{
+			name:    "BIT 7, H keeps the carry",
+			program: []uint8{PREFIX_CB, 0x7c, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.h, cpu.flags = 0x7f, FLAG_C|FLAG_N },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_H|FLAG_C, 0x00, 0x0000, 0x0000, 0x7f00),
+		},
+		{
+			name:    "BIT 0, (HL) in three cycles",
+			program: []uint8{PREFIX_CB, 0x46, NOP},
+			cycles:  4,
+			setup: func(cpu *SM83_CPU) {
+				cpu.h, cpu.l = 0x80, 0x00
+				cpu.writeByteIntoMemory(0x8000, 0x01)
+			},
+			want: registers(0x0003, TEST_STACK, FLAG_H, 0x00, 0x0000, 0x0000, 0x8000),
+		},
+		{
+			name:    "RES 0, A",
+			program: []uint8{PREFIX_CB, 0x87, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0xff, FLAG_Z },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z, 0xfe, 0x0000, 0x0000, 0x0000),
+		},
+	})
+
+	t.Run(">>> CB bit flag: scenario 4 - SET 3, (HL) writes the memory", func(t *testing.T) {
+
+		cpu, ram := runTestProgram(t, []uint8{PREFIX_CB, 0xde, NOP}, 5, func(cpu *SM83_CPU) { cpu.h, cpu.l = 0x80, 0x10 })
+
+		value, _ := ram.ReadByte(0x0010)
+		if cpu.pc != 0x0003 || value != 0x08 {
+			t.Errorf("failed executing instruction SET 3, (HL): expected: 0x08\n\tresult: 0x%02x (PC: 0x%04x)", value, cpu.pc)
+		}
+	})
+}
@@ -0,0 +1,223 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_stackInstructions.go - Oct-19-2026 by aldebap
+//
+//	Emulator for Sharp SM83 CPU - stack manipulation instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+)
+
+/*
+ADD SP,e8    --> ADD_SP_e   (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#ADD_SP,e8)
+LD HL,SP+e8  --> LD_HL_SP_e (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#LD_HL,SP+e8)
+LD SP,HL     --> LD_SP_HL   (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#LD_SP,HL)
+POP AF       --> POP_AF     (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#POP_AF)
+POP r16      --> POP_XX     (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#POP_r16)
+PUSH AF      --> PUSH_XX    (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#PUSH_AF)
+PUSH r16     --> PUSH_XX    (https://rgbds.gbdev.io/docs/v0.9.4/gbz80.7#PUSH_r16)
+*/
+
+// return the stack pointer
+func (c *SM83_CPU) sp() uint16 {
+	return uint16(c.s)<<8 | uint16(c.p)
+}
+
+// set the stack pointer
+func (c *SM83_CPU) setSP(value uint16) {
+	c.s = uint8((value & 0xff00) >> 8)
+	c.p = uint8(value & 0x00ff)
+}
+
+// push a byte into the stack
+func (c *SM83_CPU) pushByte(value uint8) error {
+
+	c.setSP(c.sp() - 1)
+
+	return c.writeByteIntoMemory(c.sp(), value)
+}
+
+// pop a byte from the stack
+func (c *SM83_CPU) popByte() (uint8, error) {
+
+	value, err := c.readByteFromMemory(c.sp())
+	c.setSP(c.sp() + 1)
+
+	return value, err
+}
+
+// add a signed offset to SP setting the flags from the unsigned addition of the low byte (ADD SP,e8 and LD HL,SP+e8)
+func (c *SM83_CPU) offsetSP(offset uint8) uint16 {
+
+	c.flags = 0x00
+
+	if (c.p&0x0f)+(offset&0x0f) > 0x0f {
+		c.flags |= FLAG_H
+	}
+	if uint16(c.p)+uint16(offset) > 0x00ff {
+		c.flags |= FLAG_C
+	}
+
+	return c.sp() + uint16(int8(offset))
+}
+
+// execute instruction PUSH_XX
+func (c *SM83_CPU) executeInstruction_PUSH_XX(msr uint8, lsr uint8, reg string) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return nil
+
+	case EXECUTION_CYCLE_2:
+		err = c.pushByte(msr)
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		err = c.pushByte(lsr)
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return err
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] PUSH %s: 0x%02x%02x\n", reg, msr, lsr)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction POP_XX
+func (c *SM83_CPU) executeInstruction_POP_XX(msr *uint8, lsr *uint8, reg string) error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.n_msb, err = c.popByte()
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return err
+
+	case EXECUTION_CYCLE_3:
+		*msr = c.n_msb
+		*lsr = c.n_lsb
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] POP %s: 0x%02x%02x\n", reg, *msr, *lsr)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction POP_AF
+func (c *SM83_CPU) executeInstruction_POP_AF() error {
+
+	err := c.executeInstruction_POP_XX(&c.a, &c.flags, "AF")
+
+	//	the lower nibble of F is always zero
+	c.flags &= 0xf0
+
+	return err
+}
+
+// execute instruction ADD_SP_e
+func (c *SM83_CPU) executeInstruction_ADD_SP_e() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return nil
+
+	case EXECUTION_CYCLE_3:
+		c.setSP(c.offsetSP(c.n_lsb))
+		c.cpu_state = EXECUTION_CYCLE_4
+
+		return nil
+
+	case EXECUTION_CYCLE_4:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] ADD SP, e: 0x%02x%02x\n", c.s, c.p)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction LD_HL_SP_e
+func (c *SM83_CPU) executeInstruction_LD_HL_SP_e() error {
+	var err error
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.n_lsb, err = c.fetchInstructionArgument()
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return err
+
+	case EXECUTION_CYCLE_2:
+		result := c.offsetSP(c.n_lsb)
+		c.h = uint8((result & 0xff00) >> 8)
+		c.l = uint8(result & 0x00ff)
+		c.cpu_state = EXECUTION_CYCLE_3
+
+		return nil
+
+	case EXECUTION_CYCLE_3:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] LD HL, SP+e: 0x%02x%02x\n", c.h, c.l)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
+
+// execute instruction LD_SP_HL
+func (c *SM83_CPU) executeInstruction_LD_SP_HL() error {
+
+	switch c.cpu_state {
+	case EXECUTION_CYCLE_1:
+		c.s = c.h
+		c.p = c.l
+		c.cpu_state = EXECUTION_CYCLE_2
+
+		return nil
+
+	case EXECUTION_CYCLE_2:
+	}
+
+	if c.trace {
+		fmt.Printf("[trace] LD SP, HL: 0x%02x%02x\n", c.s, c.p)
+	}
+
+	//	fecth next instruction in the same cycle
+	return c.fetchInstruction()
+}
@@ -0,0 +1,85 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_stackInstructions_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - stack manipulation instructions
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"testing"
+)
+
+// PUSH and POP instructions unit tests
+func Test_PUSH_POP(t *testing.T) {
+
+	runProgramTests(t, "PUSH/POP", []programTest{
+		{
+			name:    "PUSH BC and POP DE",
+			program: []uint8{PUSH_BC, POP_DE, NOP},
+			cycles:  8,
+			setup:   func(cpu *SM83_CPU) { cpu.b, cpu.c = 0x12, 0x34 },
+			want:    registers(0x0003, TEST_STACK, 0x00, 0x00, 0x1234, 0x1234, 0x0000),
+		},
+		{
+			name:    "PUSH HL still running after four cycles",
+			program: []uint8{PUSH_HL, NOP},
+			cycles:  4,
+			setup:   func(cpu *SM83_CPU) { cpu.h, cpu.l = 0x56, 0x78 },
+			want:    registers(0x0001, TEST_STACK-2, 0x00, 0x00, 0x0000, 0x0000, 0x5678),
+		},
+		{
+			name:    "POP AF clears the lower nibble of F",
+			program: []uint8{POP_AF, NOP},
+			cycles:  4,
+			setup: func(cpu *SM83_CPU) {
+				cpu.setSP(TEST_STACK - 2)
+				cpu.writeByteIntoMemory(TEST_STACK-2, 0xff)
+				cpu.writeByteIntoMemory(TEST_STACK-1, 0x12)
+			},
+			want: registers(0x0002, TEST_STACK, 0xf0, 0x12, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "PUSH AF and POP BC",
+			program: []uint8{PUSH_AF, POP_BC, NOP},
+			cycles:  8,
+			setup:   func(cpu *SM83_CPU) { cpu.a, cpu.flags = 0x9a, FLAG_Z|FLAG_C },
+			want:    registers(0x0003, TEST_STACK, FLAG_Z|FLAG_C, 0x9a, 0x9a90, 0x0000, 0x0000),
+		},
+	})
+}
+
+// SP arithmetic instructions unit tests
+func Test_SP_Arithmetic(t *testing.T) {
+
+	runProgramTests(t, "SP arithmetic", []programTest{
+		{
+			name:    "ADD SP, e with carries",
+			program: []uint8{ADD_SP_e, 0x08, NOP},
+			cycles:  5,
+			setup:   func(cpu *SM83_CPU) { cpu.setSP(0xfff8); cpu.flags = FLAG_Z | FLAG_N },
+			want:    registers(0x0003, 0x0000, FLAG_H|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "ADD SP, e with a negative offset",
+			program: []uint8{ADD_SP_e, 0xfe, NOP},
+			cycles:  5,
+			setup:   nil,
+			want:    registers(0x0003, TEST_STACK-2, FLAG_H|FLAG_C, 0x00, 0x0000, 0x0000, 0x0000),
+		},
+		{
+			name:    "LD HL, SP+e",
+			program: []uint8{LD_HL_SP_e, 0x01, NOP},
+			cycles:  4,
+			setup:   nil,
+			want:    registers(0x0003, TEST_STACK, 0x00, 0x00, 0x0000, 0x0000, 0xffff),
+		},
+		{
+			name:    "LD SP, HL",
+			program: []uint8{LD_SP_HL, NOP},
+			cycles:  3,
+			setup:   func(cpu *SM83_CPU) { cpu.h, cpu.l = 0xc0, 0x00 },
+			want:    registers(0x0002, 0xc000, 0x00, 0x00, 0x0000, 0x0000, 0xc000),
+		},
+	})
+}
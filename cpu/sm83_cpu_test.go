@@ -0,0 +1,109 @@
+////////////////////////////////////////////////////////////////////////////////
+//	sm83_cpu_test_instructions_0x0i.go - Apr-24-2025 by aldebap
+//
+//	Test cases for Sharp SM83 CPU - instructions 0x00 - 0x0f
+////////////////////////////////////////////////////////////////////////////////
+
+package cpu
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aldebap/go_gbc/memory"
+)
+
+const (
+	trace bool = true
+)
+
+// RAM connected by runTestProgram (the stack starts at its top)
+const (
+	TEST_RAM_ADDRESS = uint16(0x8000)
+	TEST_RAM_SIZE    = uint16(0x8000)
+	TEST_STACK       = uint16(0xfffe)
+)
+
+// run a test program from ROM with RAM above it, returning the CPU after a number of machine cycles
+func runTestProgram(t *testing.T, program []uint8, cycles int, setup func(cpu *SM83_CPU)) (*SM83_CPU, *memory.RAM_memory) {
+
+	cpu := NewSM83_CPU(trace)
+	rom := &memory.ROM_memory{}
+	ram := memory.NewRAM_memory(TEST_RAM_SIZE)
+
+	err := rom.Load(program)
+	if err != nil {
+		t.Fatalf("fail loading test program: %s", err.Error())
+	}
+	cpu.ConnectMemory(rom, 0x0000)
+	cpu.ConnectMemory(ram, TEST_RAM_ADDRESS)
+	cpu.setSP(TEST_STACK)
+
+	if setup != nil {
+		setup(cpu)
+	}
+
+	for i := range cycles {
+		err = cpu.MachineCycle()
+		if err != nil {
+			t.Fatalf("fail on cycle %d: %s", i, err.Error())
+		}
+	}
+
+	return cpu, ram
+}
+
+// format the registers as DumpRegisters does
+func registers(pc uint16, sp uint16, flags uint8, a uint8, bc uint16, de uint16, hl uint16) string {
+	return fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
+		pc, sp, flags, a, bc, de, hl)
+}
+
+// a test program and the registers expected after running it for a number of machine cycles
+type programTest struct {
+	name    string
+	program []uint8
+	cycles  int
+	setup   func(cpu *SM83_CPU)
+	want    string
+}
+
+// run test programs checking the registers after each one
+func runProgramTests(t *testing.T, instruction string, tests []programTest) {
+
+	for i, test := range tests {
+		t.Run(fmt.Sprintf(">>> %s: scenario %d - %s", instruction, i+1, test.name), func(t *testing.T) {
+
+			cpu, _ := runTestProgram(t, test.program, test.cycles, test.setup)
+
+			got := cpu.DumpRegisters()
+
+			//	check the invocation result
+			if test.want != got {
+				t.Errorf("failed executing instruction %s: expected: %s\n\tresult: %s", instruction, test.want, got)
+			}
+		})
+	}
+}
+
+// instruction boundary unit tests
+func Test_Instruction(t *testing.T) {
+
+	t.Run(">>> instruction: scenario 1 - an operand is not an instruction", func(t *testing.T) {
+
+		var boundaries []uint8
+
+		cpu, _ := runTestProgram(t, []uint8{LD_A_n, LD_B_B, LD_B_B, NOP}, 0, nil)
+		for range 4 {
+			cpu.MachineCycle()
+			if opcode, ok := cpu.Instruction(); ok {
+				boundaries = append(boundaries, opcode)
+			}
+		}
+
+		//	LD A,n takes two cycles, and its operand 0x40 is not seen as LD B,B
+		if fmt.Sprintf("%02x", boundaries) != fmt.Sprintf("%02x", []uint8{LD_A_n, LD_B_B, NOP}) || cpu.a != LD_B_B {
+			t.Errorf("failed finding the instructions: expected: [3e 40 00]\n\tresult: %02x", boundaries)
+		}
+	})
+}
@@ -0,0 +1,139 @@
+////////////////////////////////////////////////////////////////////////////////
+//	bus.go - Oct-18-2026 by aldebap
+//
+//	system bus: maps the memory banks of all components into one address space
+////////////////////////////////////////////////////////////////////////////////
+
+package memory
+
+// bus address space (0xffff, the IE register, is connected to the CPU as a separate bank)
+const (
+	BUS_SIZE          = 0xffff
+	BUS_UNMAPPED_READ = uint8(0xff)
+)
+
+// memory bank connected to the bus
+type busRegion struct {
+	address uint16
+	bank    Memory
+}
+
+// system bus: the first bank connected to an address answers it, unmapped addresses read 0xff and ignore writes
+type Bus struct {
+	regions []busRegion
+}
+
+// create a new bus
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// connect a memory bank at an address
+func (b *Bus) ConnectMemory(bank Memory, address uint16) {
+	b.regions = append(b.regions, busRegion{address: address, bank: bank})
+}
+
+// find the bank connected to an address
+func (b *Bus) region(address uint16) *busRegion {
+	for i := range b.regions {
+		region := &b.regions[i]
+		if address >= region.address && uint32(address) < uint32(region.address)+uint32(region.bank.Len()) {
+			return region
+		}
+	}
+
+	return nil
+}
+
+// return memory bank size
+func (b *Bus) Len() uint16 {
+	return BUS_SIZE
+}
+
+// write a byte into the bank connected to an address
+func (b *Bus) WriteByte(address uint16, value uint8) error {
+	region := b.region(address)
+	if region == nil {
+		return nil
+	}
+
+	return region.bank.WriteByte(address-region.address, value)
+}
+
+// read a byte from the bank connected to an address
+func (b *Bus) ReadByte(address uint16) (uint8, error) {
+	region := b.region(address)
+	if region == nil {
+		return BUS_UNMAPPED_READ, nil
+	}
+
+	return region.bank.ReadByte(address - region.address)
+}
+
+// write a word into the bus
+func (b *Bus) WriteWord(address uint16, value uint16) error {
+	return WriteWordAsBytes(b, address, value)
+}
+
+// read a word from the bus
+func (b *Bus) ReadWord(address uint16) (uint16, error) {
+	return ReadWordAsBytes(b, address)
+}
+
+// memory bank made of read and write functions, used for the small system registers
+type RegisterBank struct {
+	size  uint16
+	read  func(address uint16) uint8
+	write func(address uint16, value uint8)
+}
+
+// create a new register bank: a nil read function reads 0xff and a nil write function ignores writes
+func NewRegisterBank(size uint16, read func(address uint16) uint8, write func(address uint16, value uint8)) *RegisterBank {
+
+	return &RegisterBank{
+		size:  size,
+		read:  read,
+		write: write,
+	}
+}
+
+// return memory bank size
+func (m *RegisterBank) Len() uint16 {
+	return m.size
+}
+
+// write a register
+func (m *RegisterBank) WriteByte(address uint16, value uint8) error {
+	if address >= m.size {
+		return ErrAddressOutOfBounds
+	}
+
+	if m.write != nil {
+		m.write(address, value)
+	}
+
+	return nil
+}
+
+// read a register
+func (m *RegisterBank) ReadByte(address uint16) (uint8, error) {
+	if address >= m.size {
+		return 0, ErrAddressOutOfBounds
+	}
+
+	if m.read == nil {
+		return BUS_UNMAPPED_READ, nil
+	}
+
+	return m.read(address), nil
+}
+
+// write a word into registers
+func (m *RegisterBank) WriteWord(address uint16, value uint16) error {
+	return WriteWordAsBytes(m, address, value)
+}
+
+// read a word from registers
+func (m *RegisterBank) ReadWord(address uint16) (uint16, error) {
+	return ReadWordAsBytes(m, address)
+}
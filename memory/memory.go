@@ -0,0 +1,49 @@
+////////////////////////////////////////////////////////////////////////////////
+//	memory.go - Apr-23-2025 by aldebap
+//
+//	interface for a memory bank
+////////////////////////////////////////////////////////////////////////////////
+
+package memory
+
+import "fmt"
+
+type Memory interface {
+	Len() uint16
+
+	WriteByte(address uint16, value uint8) error
+	ReadByte(address uint16) (uint8, error)
+
+	WriteWord(address uint16, value uint16) error
+	ReadWord(address uint16) (uint16, error)
+}
+
+// error returned when accessing an address beyond a memory bank
+var ErrAddressOutOfBounds = fmt.Errorf("address out of bounds")
+
+// write a word (little endian) using two byte writes
+func WriteWordAsBytes(m Memory, address uint16, value uint16) error {
+
+	err := m.WriteByte(address, uint8(value&0x00ff))
+	if err != nil {
+		return err
+	}
+
+	return m.WriteByte(address+1, uint8(value>>8&0x00ff))
+}
+
+// read a word (little endian) using two byte reads
+func ReadWordAsBytes(m Memory, address uint16) (uint16, error) {
+
+	lsb, err := m.ReadByte(address)
+	if err != nil {
+		return 0, err
+	}
+
+	msb, err := m.ReadByte(address + 1)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(msb)<<8 | uint16(lsb), nil
+}
@@ -4,7 +4,7 @@
 //	implementation of RAM memory bank
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package memory
 
 import "fmt"
 
@@ -4,7 +4,7 @@
 //	implementation of ROM memory bank
 ////////////////////////////////////////////////////////////////////////////////
 
-package main
+package memory
 
 import "fmt"
 
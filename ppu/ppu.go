@@ -0,0 +1,496 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the Game Boy PPU (Pixel Processing Unit)
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+)
+
+// PPU memory map
+const (
+	VRAM_ADDRESS        = 0x8000
+	VRAM_SIZE           = 0x2000
+	OAM_ADDRESS         = 0xfe00
+	OAM_SIZE            = 0xa0
+	LCD_REGISTERS       = 0xff40
+	LCD_REGISTERS_SIZE  = 0x10
+	CGB_PALETTE_ADDRESS = 0xff68
+	CGB_PALETTE_SIZE    = 0x04
+	CGB_PALETTE_RAM     = 0x40
+)
+
+// LCD registers (offset from 0xff40)
+const (
+	REG_LCDC = 0x00
+	REG_STAT = 0x01
+	REG_SCY  = 0x02
+	REG_SCX  = 0x03
+	REG_LY   = 0x04
+	REG_LYC  = 0x05
+	REG_DMA  = 0x06
+	REG_BGP  = 0x07
+	REG_OBP0 = 0x08
+	REG_OBP1 = 0x09
+	REG_WY   = 0x0a
+	REG_WX   = 0x0b
+	REG_VBK  = 0x0f
+)
+
+// CGB palette registers (offset from 0xff68)
+const (
+	REG_BCPS = 0x00
+	REG_BCPD = 0x01
+	REG_OCPS = 0x02
+	REG_OCPD = 0x03
+)
+
+// LCDC flags
+const (
+	LCDC_BG_WINDOW_ENABLE = uint8(0x01)
+	LCDC_OBJ_ENABLE       = uint8(0x02)
+	LCDC_OBJ_SIZE         = uint8(0x04)
+	LCDC_BG_TILE_MAP      = uint8(0x08)
+	LCDC_BG_WINDOW_TILES  = uint8(0x10)
+	LCDC_WINDOW_ENABLE    = uint8(0x20)
+	LCDC_WINDOW_TILE_MAP  = uint8(0x40)
+	LCDC_LCD_ENABLE       = uint8(0x80)
+)
+
+// tile data and tile maps
+const (
+	TILE_MAP_0_OFFSET   = 0x1800
+	TILE_MAP_1_OFFSET   = 0x1c00
+	TILE_MAP_SIZE       = 32
+	TILE_SIZE           = 16
+	TILES_PER_VRAM_BANK = 384
+)
+
+// CGB palette specification flags
+const (
+	CGB_PALETTE_AUTO_INCREMENT = uint8(0x80)
+)
+
+// CGB tile map attributes
+const (
+	ATTR_PALETTE  = uint8(0x07)
+	ATTR_BANK     = uint8(0x08)
+	ATTR_X_FLIP   = uint8(0x20)
+	ATTR_Y_FLIP   = uint8(0x40)
+	ATTR_PRIORITY = uint8(0x80)
+)
+
+// PPU internal registers and memories
+type PPU struct {
+	cgbMode bool
+
+	vram     [2][]uint8
+	vramBank uint8
+	oam      []uint8
+
+	lcdc uint8
+	stat uint8
+	scy  uint8
+	scx  uint8
+	ly   uint8
+	lyc  uint8
+	dma  uint8
+	bgp  uint8
+	obp0 uint8
+	obp1 uint8
+	wy   uint8
+	wx   uint8
+
+	windowLine uint8
+
+	bcps          uint8
+	ocps          uint8
+	bgPaletteRAM  []uint8
+	objPaletteRAM []uint8
+
+	framebuffer *Framebuffer
+
+	dots             int
+	statLine         bool
+	frameReady       bool
+	requestInterrupt cpu.InterruptRequester
+}
+
+// create a new PPU
+func NewPPU(cgbMode bool) *PPU {
+
+	return &PPU{
+		cgbMode: cgbMode,
+
+		vram:     [2][]uint8{make([]uint8, VRAM_SIZE), make([]uint8, VRAM_SIZE)},
+		vramBank: 0,
+		oam:      make([]uint8, OAM_SIZE),
+
+		lcdc: 0x91,
+		stat: 0x00,
+		bgp:  0xfc,
+		obp0: 0xff,
+		obp1: 0xff,
+
+		bgPaletteRAM:  make([]uint8, CGB_PALETTE_RAM),
+		objPaletteRAM: make([]uint8, CGB_PALETTE_RAM),
+
+		framebuffer: NewFramebuffer(cgbMode),
+	}
+}
+
+// return the PPU framebuffer
+func (p *PPU) Framebuffer() *Framebuffer {
+	return p.framebuffer
+}
+
+// return true if the PPU is running in CGB mode
+func (p *PPU) CGBMode() bool {
+	return p.cgbMode
+}
+
+// return the VRAM as a memory bank (0x8000 - 0x9fff)
+func (p *PPU) VRAM() memory.Memory {
+	return &ppuVRAM{ppu: p}
+}
+
+// return the OAM as a memory bank (0xfe00 - 0xfe9f)
+func (p *PPU) OAM() memory.Memory {
+	return &ppuOAM{ppu: p}
+}
+
+// return the LCD registers as a memory bank (0xff40 - 0xff4f)
+func (p *PPU) Registers() memory.Memory {
+	return &ppuRegisters{ppu: p}
+}
+
+// return the CGB palette registers as a memory bank (0xff68 - 0xff6b)
+func (p *PPU) PaletteRegisters() memory.Memory {
+	return &ppuPaletteRegisters{ppu: p}
+}
+
+// read a LCD register
+func (p *PPU) readRegister(register uint16) uint8 {
+
+	switch register {
+	case REG_LCDC:
+		return p.lcdc
+	case REG_STAT:
+		return p.stat | 0x80
+	case REG_SCY:
+		return p.scy
+	case REG_SCX:
+		return p.scx
+	case REG_LY:
+		return p.ly
+	case REG_LYC:
+		return p.lyc
+	case REG_DMA:
+		return p.dma
+	case REG_BGP:
+		return p.bgp
+	case REG_OBP0:
+		return p.obp0
+	case REG_OBP1:
+		return p.obp1
+	case REG_WY:
+		return p.wy
+	case REG_WX:
+		return p.wx
+	case REG_VBK:
+		if p.cgbMode {
+			return p.vramBank | 0xfe
+		}
+	}
+
+	return 0xff
+}
+
+// write a LCD register
+func (p *PPU) writeRegister(register uint16, value uint8) {
+
+	switch register {
+	case REG_LCDC:
+		p.writeLCDC(value)
+	case REG_STAT:
+		//	bits 0-2 are read only
+		p.stat = p.stat&0x07 | value&0x78
+	case REG_SCY:
+		p.scy = value
+	case REG_SCX:
+		p.scx = value
+	case REG_LYC:
+		p.lyc = value
+	case REG_DMA:
+		p.dma = value
+	case REG_BGP:
+		p.bgp = value
+	case REG_OBP0:
+		p.obp0 = value
+	case REG_OBP1:
+		p.obp1 = value
+	case REG_WY:
+		p.wy = value
+	case REG_WX:
+		p.wx = value
+	case REG_VBK:
+		if p.cgbMode {
+			p.vramBank = value & 0x01
+		}
+	}
+}
+
+// read a CGB palette register
+func (p *PPU) readPaletteRegister(register uint16) uint8 {
+	if !p.cgbMode {
+		return 0xff
+	}
+
+	switch register {
+	case REG_BCPS:
+		return p.bcps | 0x40
+	case REG_BCPD:
+		return p.bgPaletteRAM[p.bcps&0x3f]
+	case REG_OCPS:
+		return p.ocps | 0x40
+	case REG_OCPD:
+		return p.objPaletteRAM[p.ocps&0x3f]
+	}
+
+	return 0xff
+}
+
+// write a CGB palette register
+func (p *PPU) writePaletteRegister(register uint16, value uint8) {
+	if !p.cgbMode {
+		return
+	}
+
+	switch register {
+	case REG_BCPS:
+		p.bcps = value & 0xbf
+	case REG_BCPD:
+		p.bgPaletteRAM[p.bcps&0x3f] = value
+		if p.bcps&CGB_PALETTE_AUTO_INCREMENT != 0 {
+			p.bcps = CGB_PALETTE_AUTO_INCREMENT | (p.bcps+1)&0x3f
+		}
+	case REG_OCPS:
+		p.ocps = value & 0xbf
+	case REG_OCPD:
+		p.objPaletteRAM[p.ocps&0x3f] = value
+		if p.ocps&CGB_PALETTE_AUTO_INCREMENT != 0 {
+			p.ocps = CGB_PALETTE_AUTO_INCREMENT | (p.ocps+1)&0x3f
+		}
+	}
+}
+
+// get a CGB 15 bit color from the BG palette RAM
+func (p *PPU) bgPaletteColor(palette uint8, colorIndex uint8) uint16 {
+	var index = (palette&0x07)*8 + (colorIndex&0x03)*2
+
+	return uint16(p.bgPaletteRAM[index]) | uint16(p.bgPaletteRAM[index+1])<<8
+}
+
+// get a CGB 15 bit color from the OBJ palette RAM
+func (p *PPU) objPaletteColor(palette uint8, colorIndex uint8) uint16 {
+	var index = (palette&0x07)*8 + (colorIndex&0x03)*2
+
+	return uint16(p.objPaletteRAM[index]) | uint16(p.objPaletteRAM[index+1])<<8
+}
+
+// map a color index into a DMG shade using a palette register
+func dmgShade(paletteRegister uint8, colorIndex uint8) uint8 {
+	return paletteRegister >> ((colorIndex & 0x03) * 2) & 0x03
+}
+
+// decode one row (0-7) of a tile into color indexes
+func (p *PPU) tileRow(bank uint8, tileAddress uint16, row uint8, xFlip bool) [8]uint8 {
+	var pixels [8]uint8
+
+	lsb := p.vram[bank&0x01][tileAddress+uint16(row)*2]
+	msb := p.vram[bank&0x01][tileAddress+uint16(row)*2+1]
+
+	for i := uint8(0); i < 8; i++ {
+		bit := 7 - i
+		if xFlip {
+			bit = i
+		}
+
+		pixels[i] = (msb>>bit&0x01)<<1 | lsb>>bit&0x01
+	}
+
+	return pixels
+}
+
+// VRAM address of a BG/window tile using the LCDC addressing mode
+func (p *PPU) bgTileAddress(tileIndex uint8) uint16 {
+	if p.lcdc&LCDC_BG_WINDOW_TILES != 0 {
+		return uint16(tileIndex) * TILE_SIZE
+	}
+
+	return uint16(0x1000 + int(int8(tileIndex))*TILE_SIZE)
+}
+
+// VRAM view of the PPU
+type ppuVRAM struct {
+	ppu *PPU
+}
+
+// return memory bank size
+func (m *ppuVRAM) Len() uint16 {
+	return VRAM_SIZE
+}
+
+// write a byte into the current VRAM bank
+func (m *ppuVRAM) WriteByte(address uint16, value uint8) error {
+	if address >= VRAM_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.ppu.vram[m.ppu.vramBank][address] = value
+
+	return nil
+}
+
+// read a byte from the current VRAM bank
+func (m *ppuVRAM) ReadByte(address uint16) (uint8, error) {
+	if address >= VRAM_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.ppu.vram[m.ppu.vramBank][address], nil
+}
+
+// write a word into the current VRAM bank
+func (m *ppuVRAM) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from the current VRAM bank
+func (m *ppuVRAM) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
+
+// OAM view of the PPU
+type ppuOAM struct {
+	ppu *PPU
+}
+
+// return memory bank size
+func (m *ppuOAM) Len() uint16 {
+	return OAM_SIZE
+}
+
+// write a byte into OAM
+func (m *ppuOAM) WriteByte(address uint16, value uint8) error {
+	if address >= OAM_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.ppu.oam[address] = value
+
+	return nil
+}
+
+// read a byte from OAM
+func (m *ppuOAM) ReadByte(address uint16) (uint8, error) {
+	if address >= OAM_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.ppu.oam[address], nil
+}
+
+// write a word into OAM
+func (m *ppuOAM) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from OAM
+func (m *ppuOAM) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
+
+// LCD registers view of the PPU
+type ppuRegisters struct {
+	ppu *PPU
+}
+
+// return memory bank size
+func (m *ppuRegisters) Len() uint16 {
+	return LCD_REGISTERS_SIZE
+}
+
+// write a LCD register
+func (m *ppuRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= LCD_REGISTERS_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.ppu.writeRegister(address, value)
+
+	return nil
+}
+
+// read a LCD register
+func (m *ppuRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= LCD_REGISTERS_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.ppu.readRegister(address), nil
+}
+
+// write a word into LCD registers
+func (m *ppuRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from LCD registers
+func (m *ppuRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
+
+// CGB palette registers view of the PPU
+type ppuPaletteRegisters struct {
+	ppu *PPU
+}
+
+// return memory bank size
+func (m *ppuPaletteRegisters) Len() uint16 {
+	return CGB_PALETTE_SIZE
+}
+
+// write a CGB palette register
+func (m *ppuPaletteRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= CGB_PALETTE_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.ppu.writePaletteRegister(address, value)
+
+	return nil
+}
+
+// read a CGB palette register
+func (m *ppuPaletteRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= CGB_PALETTE_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.ppu.readPaletteRegister(address), nil
+}
+
+// write a word into CGB palette registers
+func (m *ppuPaletteRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from CGB palette registers
+func (m *ppuPaletteRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
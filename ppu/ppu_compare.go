@@ -0,0 +1,65 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_compare.go - Oct-18-2026 by aldebap
+//
+//	compare framebuffer images against reference images
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+)
+
+// colors used in diff images
+const (
+	DIFF_MISMATCH_RGBA = uint32(0xff0000)
+)
+
+// load a PNG image from a file
+func LoadPNG(fileName string) (image.Image, error) {
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+// compare two images pixel by pixel returning the number of mismatches and a diff image
+// (matching pixels are dimmed, mismatching pixels are red)
+func CompareImages(got image.Image, want image.Image) (int, *image.RGBA, error) {
+
+	if got.Bounds().Dx() != want.Bounds().Dx() || got.Bounds().Dy() != want.Bounds().Dy() {
+		return 0, nil, fmt.Errorf("image sizes differ: %dx%d and %dx%d",
+			got.Bounds().Dx(), got.Bounds().Dy(), want.Bounds().Dx(), want.Bounds().Dy())
+	}
+
+	var mismatches int
+
+	diff := image.NewRGBA(image.Rect(0, 0, want.Bounds().Dx(), want.Bounds().Dy()))
+	mismatch := rgb(DIFF_MISMATCH_RGBA)
+
+	for y := 0; y < want.Bounds().Dy(); y++ {
+		for x := 0; x < want.Bounds().Dx(); x++ {
+			gotPixel := color.RGBAModel.Convert(got.At(got.Bounds().Min.X+x, got.Bounds().Min.Y+y)).(color.RGBA)
+			wantPixel := color.RGBAModel.Convert(want.At(want.Bounds().Min.X+x, want.Bounds().Min.Y+y)).(color.RGBA)
+
+			if gotPixel != wantPixel {
+				mismatches++
+				diff.SetRGBA(x, y, mismatch)
+				continue
+			}
+
+			gray := uint8((uint16(wantPixel.R) + uint16(wantPixel.G) + uint16(wantPixel.B)) / 3 / 4)
+			diff.SetRGBA(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 0xff})
+		}
+	}
+
+	return mismatches, diff, nil
+}
@@ -0,0 +1,70 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_compare_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for image comparison
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+)
+
+// image comparison unit tests
+func Test_CompareImages(t *testing.T) {
+
+	t.Run(">>> compare images: scenario 1 - one mismatching pixel", func(t *testing.T) {
+
+		framebuffer := NewFramebuffer(false)
+		p, _ := NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+
+		want := p.Process(framebuffer)
+		framebuffer.SetDMGPixel(7, 9, 3, DMG_PALETTE_BG)
+		got := p.Process(framebuffer)
+
+		mismatches, diff, err := CompareImages(got, want)
+		if err != nil {
+			t.Errorf("fail comparing images: %s", err.Error())
+		}
+
+		if mismatches != 1 {
+			t.Errorf("failed comparing images: expected: 1 mismatch\n\tresult: %d", mismatches)
+		}
+		if diff.RGBAAt(7, 9) != rgb(DIFF_MISMATCH_RGBA) {
+			t.Errorf("failed drawing diff image: expected mismatch at (7, 9)")
+		}
+	})
+
+	t.Run(">>> compare images: scenario 2 - different sizes", func(t *testing.T) {
+
+		_, _, err := CompareImages(image.NewRGBA(image.Rect(0, 0, 2, 2)), image.NewRGBA(image.Rect(0, 0, 3, 2)))
+		if err == nil {
+			t.Errorf("expected error comparing images with different sizes")
+		}
+	})
+
+	t.Run(">>> compare images: scenario 3 - compare against a PNG file", func(t *testing.T) {
+
+		fileName := filepath.Join(t.TempDir(), "reference.png")
+		framebuffer := NewFramebuffer(true)
+
+		err := SaveScreenshot(fileName, framebuffer, nil, 1)
+		if err != nil {
+			t.Errorf("fail saving reference: %s", err.Error())
+		}
+
+		want, err := LoadPNG(fileName)
+		if err != nil {
+			t.Errorf("fail loading reference: %s", err.Error())
+		}
+
+		p, _ := NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+
+		mismatches, _, err := CompareImages(p.Process(framebuffer), want)
+		if err != nil || mismatches != 0 {
+			t.Errorf("failed comparing against PNG: %d mismatches", mismatches)
+		}
+	})
+}
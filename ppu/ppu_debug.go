@@ -0,0 +1,432 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_debug.go - Oct-18-2026 by aldebap
+//
+//	VRAM debug viewers: tile sheet, background maps and OAM
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+)
+
+// debug viewers layout
+const (
+	TILE_SHEET_COLUMNS  = 16
+	OAM_SPRITES         = 40
+	OAM_ENTRY_SIZE      = 4
+	OAM_TABLE_COLUMNS   = 8
+	BG_MAP_PIXELS       = TILE_MAP_SIZE * 8
+	DEBUG_VIEWPORT_RGBA = uint32(0xff0000)
+)
+
+// OAM attributes
+const (
+	OAM_CGB_PALETTE = uint8(0x07)
+	OAM_CGB_BANK    = uint8(0x08)
+	OAM_DMG_PALETTE = uint8(0x10)
+	OAM_X_FLIP      = uint8(0x20)
+	OAM_Y_FLIP      = uint8(0x40)
+	OAM_PRIORITY    = uint8(0x80)
+)
+
+// tile decoded from VRAM
+type DebugTile struct {
+	Bank    uint8       `json:"bank"`
+	Index   int         `json:"index"`
+	Address uint16      `json:"address"`
+	Pixels  [8][8]uint8 `json:"pixels"`
+}
+
+// background map entry
+type DebugMapEntry struct {
+	Tile     uint8 `json:"tile"`
+	Palette  uint8 `json:"palette"`
+	Bank     uint8 `json:"bank"`
+	XFlip    bool  `json:"xFlip"`
+	YFlip    bool  `json:"yFlip"`
+	Priority bool  `json:"priority"`
+}
+
+// background map with the current viewport
+type DebugBackgroundMap struct {
+	Map       int                                         `json:"map"`
+	Address   uint16                                      `json:"address"`
+	Active    bool                                        `json:"active"`
+	ViewportX uint8                                       `json:"viewportX"`
+	ViewportY uint8                                       `json:"viewportY"`
+	Entries   [TILE_MAP_SIZE][TILE_MAP_SIZE]DebugMapEntry `json:"entries"`
+}
+
+// OAM entry with its decoded pixels
+type DebugSprite struct {
+	Index    int       `json:"index"`
+	X        int       `json:"x"`
+	Y        int       `json:"y"`
+	Tile     uint8     `json:"tile"`
+	Palette  uint8     `json:"palette"`
+	Bank     uint8     `json:"bank"`
+	XFlip    bool      `json:"xFlip"`
+	YFlip    bool      `json:"yFlip"`
+	Priority bool      `json:"priority"`
+	Pixels   [][]uint8 `json:"pixels"`
+}
+
+// number of VRAM banks shown by the debug viewers
+func (p *PPU) debugBanks() int {
+	if p.cgbMode {
+		return 2
+	}
+
+	return 1
+}
+
+// convert a BG color index into RGB
+func (p *PPU) debugBGColor(postProcessor *PostProcessor, palette uint8, colorIndex uint8) color.RGBA {
+	if p.cgbMode {
+		return postProcessor.CorrectColor(p.bgPaletteColor(palette, colorIndex))
+	}
+
+	return postProcessor.dmgPalette.bg[dmgShade(p.bgp, colorIndex)]
+}
+
+// convert an OBJ color index into RGB
+func (p *PPU) debugOBJColor(postProcessor *PostProcessor, palette uint8, colorIndex uint8) color.RGBA {
+	if p.cgbMode {
+		return postProcessor.CorrectColor(p.objPaletteColor(palette, colorIndex))
+	}
+	if palette != 0 {
+		return postProcessor.dmgPalette.obj1[dmgShade(p.obp1, colorIndex)]
+	}
+
+	return postProcessor.dmgPalette.obj0[dmgShade(p.obp0, colorIndex)]
+}
+
+// use a default post processor when none is given
+func debugPostProcessor(postProcessor *PostProcessor) (*PostProcessor, error) {
+	if postProcessor != nil {
+		return postProcessor, nil
+	}
+
+	return NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+}
+
+// decode all tiles of the VRAM banks
+func (p *PPU) DebugTiles() []DebugTile {
+	tiles := make([]DebugTile, 0, p.debugBanks()*TILES_PER_VRAM_BANK)
+
+	for bank := 0; bank < p.debugBanks(); bank++ {
+		for index := 0; index < TILES_PER_VRAM_BANK; index++ {
+			tile := DebugTile{
+				Bank:    uint8(bank),
+				Index:   index,
+				Address: VRAM_ADDRESS + uint16(index*TILE_SIZE),
+			}
+
+			for row := uint8(0); row < 8; row++ {
+				tile.Pixels[row] = p.tileRow(uint8(bank), uint16(index*TILE_SIZE), row, false)
+			}
+
+			tiles = append(tiles, tile)
+		}
+	}
+
+	return tiles
+}
+
+// render the VRAM tiles side by side for each bank (using BG palette 0)
+func (p *PPU) RenderTileSheet(postProcessor *PostProcessor) (*image.RGBA, error) {
+
+	postProcessor, err := debugPostProcessor(postProcessor)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := TILES_PER_VRAM_BANK / TILE_SHEET_COLUMNS
+	img := image.NewRGBA(image.Rect(0, 0, p.debugBanks()*TILE_SHEET_COLUMNS*8, rows*8))
+
+	for _, tile := range p.DebugTiles() {
+		originX := (int(tile.Bank)*TILE_SHEET_COLUMNS + tile.Index%TILE_SHEET_COLUMNS) * 8
+		originY := tile.Index / TILE_SHEET_COLUMNS * 8
+
+		for y := range 8 {
+			for x := range 8 {
+				img.SetRGBA(originX+x, originY+y, p.debugBGColor(postProcessor, 0, tile.Pixels[y][x]))
+			}
+		}
+	}
+
+	return img, nil
+}
+
+// decode a background map (0: 0x9800, 1: 0x9c00)
+func (p *PPU) DebugBackgroundMap(mapIndex int) (*DebugBackgroundMap, error) {
+	var offset uint16
+	var active bool
+
+	switch mapIndex {
+	case 0:
+		offset = TILE_MAP_0_OFFSET
+		active = p.lcdc&LCDC_BG_TILE_MAP == 0
+
+	case 1:
+		offset = TILE_MAP_1_OFFSET
+		active = p.lcdc&LCDC_BG_TILE_MAP != 0
+
+	default:
+		return nil, fmt.Errorf("invalid background map: %d", mapIndex)
+	}
+
+	bgMap := &DebugBackgroundMap{
+		Map:       mapIndex,
+		Address:   VRAM_ADDRESS + offset,
+		Active:    active,
+		ViewportX: p.scx,
+		ViewportY: p.scy,
+	}
+
+	for row := range TILE_MAP_SIZE {
+		for column := range TILE_MAP_SIZE {
+			address := offset + uint16(row*TILE_MAP_SIZE+column)
+			entry := DebugMapEntry{
+				Tile: p.vram[0][address],
+			}
+
+			if p.cgbMode {
+				attributes := p.vram[1][address]
+
+				entry.Palette = attributes & ATTR_PALETTE
+				entry.Bank = (attributes & ATTR_BANK) >> 3
+				entry.XFlip = attributes&ATTR_X_FLIP != 0
+				entry.YFlip = attributes&ATTR_Y_FLIP != 0
+				entry.Priority = attributes&ATTR_PRIORITY != 0
+			}
+
+			bgMap.Entries[row][column] = entry
+		}
+	}
+
+	return bgMap, nil
+}
+
+// render a background map with the current viewport overlaid
+func (p *PPU) RenderBackgroundMap(mapIndex int, postProcessor *PostProcessor) (*image.RGBA, error) {
+
+	postProcessor, err := debugPostProcessor(postProcessor)
+	if err != nil {
+		return nil, err
+	}
+
+	bgMap, err := p.DebugBackgroundMap(mapIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, BG_MAP_PIXELS, BG_MAP_PIXELS))
+
+	for row := range TILE_MAP_SIZE {
+		for column := range TILE_MAP_SIZE {
+			entry := bgMap.Entries[row][column]
+
+			for y := uint8(0); y < 8; y++ {
+				tileY := y
+				if entry.YFlip {
+					tileY = 7 - y
+				}
+
+				pixels := p.tileRow(entry.Bank, p.bgTileAddress(entry.Tile), tileY, entry.XFlip)
+				for x := range 8 {
+					img.SetRGBA(column*8+x, row*8+int(y), p.debugBGColor(postProcessor, entry.Palette, pixels[x]))
+				}
+			}
+		}
+	}
+
+	//	draw the viewport outline wrapping around the map
+	viewport := rgb(DEBUG_VIEWPORT_RGBA)
+
+	for i := 0; i < SCREEN_WIDTH; i++ {
+		x := (int(bgMap.ViewportX) + i) % BG_MAP_PIXELS
+		img.SetRGBA(x, int(bgMap.ViewportY), viewport)
+		img.SetRGBA(x, (int(bgMap.ViewportY)+SCREEN_HEIGHT-1)%BG_MAP_PIXELS, viewport)
+	}
+	for i := 0; i < SCREEN_HEIGHT; i++ {
+		y := (int(bgMap.ViewportY) + i) % BG_MAP_PIXELS
+		img.SetRGBA(int(bgMap.ViewportX), y, viewport)
+		img.SetRGBA((int(bgMap.ViewportX)+SCREEN_WIDTH-1)%BG_MAP_PIXELS, y, viewport)
+	}
+
+	return img, nil
+}
+
+// decode all OAM entries with their pixels
+func (p *PPU) DebugOAM() []DebugSprite {
+	sprites := make([]DebugSprite, 0, OAM_SPRITES)
+
+	height := 8
+	if p.lcdc&LCDC_OBJ_SIZE != 0 {
+		height = 16
+	}
+
+	for index := range OAM_SPRITES {
+		entry := p.oam[index*OAM_ENTRY_SIZE : (index+1)*OAM_ENTRY_SIZE]
+		attributes := entry[3]
+
+		sprite := DebugSprite{
+			Index:    index,
+			Y:        int(entry[0]) - 16,
+			X:        int(entry[1]) - 8,
+			Tile:     entry[2],
+			XFlip:    attributes&OAM_X_FLIP != 0,
+			YFlip:    attributes&OAM_Y_FLIP != 0,
+			Priority: attributes&OAM_PRIORITY != 0,
+		}
+
+		if p.cgbMode {
+			sprite.Palette = attributes & OAM_CGB_PALETTE
+			sprite.Bank = (attributes & OAM_CGB_BANK) >> 3
+		} else {
+			sprite.Palette = (attributes & OAM_DMG_PALETTE) >> 4
+		}
+
+		sprite.Pixels = make([][]uint8, height)
+		for y := range height {
+			pixels := p.objTileRow(sprite.Tile, sprite.Bank, uint8(y), uint8(height), sprite.XFlip, sprite.YFlip)
+			sprite.Pixels[y] = pixels[:]
+		}
+
+		sprites = append(sprites, sprite)
+	}
+
+	return sprites
+}
+
+// decode one row of an OBJ tile (8x8 or 8x16) applying flips
+func (p *PPU) objTileRow(tile uint8, bank uint8, row uint8, height uint8, xFlip bool, yFlip bool) [8]uint8 {
+
+	if yFlip {
+		row = height - 1 - row
+	}
+
+	//	8x16 objects ignore bit 0 of the tile index
+	if height == 16 {
+		tile &= 0xfe
+	}
+
+	return p.tileRow(bank, uint16(tile)*TILE_SIZE+uint16(row/8)*TILE_SIZE, row%8, xFlip)
+}
+
+// render a grid with a preview of every OAM entry (color 0 is transparent)
+func (p *PPU) RenderOAMTable(postProcessor *PostProcessor) (*image.RGBA, error) {
+
+	postProcessor, err := debugPostProcessor(postProcessor)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := OAM_SPRITES / OAM_TABLE_COLUMNS
+	img := image.NewRGBA(image.Rect(0, 0, OAM_TABLE_COLUMNS*8, rows*16))
+
+	for _, sprite := range p.DebugOAM() {
+		originX := sprite.Index % OAM_TABLE_COLUMNS * 8
+		originY := sprite.Index / OAM_TABLE_COLUMNS * 16
+
+		for y, pixels := range sprite.Pixels {
+			for x, colorIndex := range pixels {
+				if colorIndex == 0 {
+					continue
+				}
+				img.SetRGBA(originX+x, originY+y, p.debugOBJColor(postProcessor, sprite.Palette, colorIndex))
+			}
+		}
+	}
+
+	return img, nil
+}
+
+// write a PNG image into a file
+func SavePNG(fileName string, img image.Image) error {
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = png.Encode(file, img)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// write a value as JSON into a file
+func saveJSON(fileName string, value any) error {
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// export all VRAM debug views as PNG images and JSON files into a directory
+func (p *PPU) ExportVRAMDebug(directory string, postProcessor *PostProcessor) error {
+
+	err := os.MkdirAll(directory, 0755)
+	if err != nil {
+		return err
+	}
+
+	tileSheet, err := p.RenderTileSheet(postProcessor)
+	if err != nil {
+		return err
+	}
+	err = SavePNG(filepath.Join(directory, "tiles.png"), tileSheet)
+	if err != nil {
+		return err
+	}
+	err = saveJSON(filepath.Join(directory, "tiles.json"), p.DebugTiles())
+	if err != nil {
+		return err
+	}
+
+	for mapIndex := range 2 {
+		bgMapImage, err := p.RenderBackgroundMap(mapIndex, postProcessor)
+		if err != nil {
+			return err
+		}
+		err = SavePNG(filepath.Join(directory, fmt.Sprintf("bgmap%d.png", mapIndex)), bgMapImage)
+		if err != nil {
+			return err
+		}
+
+		bgMap, err := p.DebugBackgroundMap(mapIndex)
+		if err != nil {
+			return err
+		}
+		err = saveJSON(filepath.Join(directory, fmt.Sprintf("bgmap%d.json", mapIndex)), bgMap)
+		if err != nil {
+			return err
+		}
+	}
+
+	oamTable, err := p.RenderOAMTable(postProcessor)
+	if err != nil {
+		return err
+	}
+	err = SavePNG(filepath.Join(directory, "oam.png"), oamTable)
+	if err != nil {
+		return err
+	}
+
+	return saveJSON(filepath.Join(directory, "oam.json"), p.DebugOAM())
+}
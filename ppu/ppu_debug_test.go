@@ -0,0 +1,152 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_debug_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for VRAM debug viewers
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tile sheet unit tests
+func Test_DebugTiles(t *testing.T) {
+
+	t.Run(">>> debug tiles: scenario 1 - decode tiles from both banks", func(t *testing.T) {
+
+		ppu := NewPPU(true)
+		vram := ppu.VRAM()
+		registers := ppu.Registers()
+
+		//	tile 1 of bank 1: first row 0b10000001 / 0b10000000
+		registers.WriteByte(REG_VBK, 0x01)
+		vram.WriteByte(TILE_SIZE, 0x81)
+		vram.WriteByte(TILE_SIZE+1, 0x80)
+
+		tiles := ppu.DebugTiles()
+		if len(tiles) != 2*TILES_PER_VRAM_BANK {
+			t.Errorf("failed decoding tiles: expected: %d\n\tresult: %d", 2*TILES_PER_VRAM_BANK, len(tiles))
+		}
+
+		want := [8]uint8{3, 0, 0, 0, 0, 0, 0, 1}
+		got := tiles[TILES_PER_VRAM_BANK+1].Pixels[0]
+		if want != got {
+			t.Errorf("failed decoding tile row: expected: %v\n\tresult: %v", want, got)
+		}
+
+		img, err := ppu.RenderTileSheet(nil)
+		if err != nil {
+			t.Errorf("fail rendering tile sheet: %s", err.Error())
+		}
+		if img.Bounds().Dx() != 2*TILE_SHEET_COLUMNS*8 {
+			t.Errorf("failed rendering tile sheet: expected width: %d\n\tresult: %d", 2*TILE_SHEET_COLUMNS*8, img.Bounds().Dx())
+		}
+	})
+}
+
+// background map unit tests
+func Test_DebugBackgroundMap(t *testing.T) {
+
+	t.Run(">>> debug background map: scenario 1 - map entries and viewport", func(t *testing.T) {
+
+		ppu := NewPPU(false)
+		ppu.VRAM().WriteByte(TILE_MAP_1_OFFSET+TILE_MAP_SIZE+2, 0x42)
+		ppu.Registers().WriteByte(REG_SCX, 0xf8)
+		ppu.Registers().WriteByte(REG_LCDC, LCDC_LCD_ENABLE|LCDC_BG_TILE_MAP)
+
+		bgMap, err := ppu.DebugBackgroundMap(1)
+		if err != nil {
+			t.Errorf("fail decoding background map: %s", err.Error())
+		}
+
+		if !bgMap.Active || bgMap.ViewportX != 0xf8 || bgMap.Entries[1][2].Tile != 0x42 {
+			t.Errorf("failed decoding background map: %+v", bgMap.Entries[1][2])
+		}
+
+		img, err := ppu.RenderBackgroundMap(1, nil)
+		if err != nil {
+			t.Errorf("fail rendering background map: %s", err.Error())
+		}
+
+		//	the viewport wraps around the right edge of the map
+		want := rgb(DEBUG_VIEWPORT_RGBA)
+		got := img.RGBAAt(10, 0)
+		if want != got {
+			t.Errorf("failed drawing viewport: expected: %v\n\tresult: %v", want, got)
+		}
+	})
+
+	t.Run(">>> debug background map: scenario 2 - invalid map", func(t *testing.T) {
+
+		_, err := NewPPU(false).DebugBackgroundMap(2)
+		if err == nil {
+			t.Errorf("expected error for invalid background map")
+		}
+	})
+}
+
+// OAM dump unit tests
+func Test_DebugOAM(t *testing.T) {
+
+	t.Run(">>> debug OAM: scenario 1 - 8x16 sprite with Y flip", func(t *testing.T) {
+
+		ppu := NewPPU(false)
+		ppu.Registers().WriteByte(REG_LCDC, LCDC_LCD_ENABLE|LCDC_OBJ_SIZE)
+
+		//	tile 2 (top) is empty, tile 3 (bottom) has its last row filled
+		ppu.VRAM().WriteByte(3*TILE_SIZE+14, 0xff)
+
+		oam := ppu.OAM()
+		oam.WriteByte(4, 16)
+		oam.WriteByte(5, 8)
+		oam.WriteByte(6, 0x03)
+		oam.WriteByte(7, OAM_Y_FLIP|OAM_DMG_PALETTE)
+
+		sprites := ppu.DebugOAM()
+		sprite := sprites[1]
+
+		if sprite.X != 0 || sprite.Y != 0 || sprite.Palette != 1 || len(sprite.Pixels) != 16 {
+			t.Errorf("failed decoding sprite: %+v", sprite)
+		}
+		if sprite.Pixels[0][0] != 1 {
+			t.Errorf("failed flipping sprite: expected first row color 1\n\tresult: %v", sprite.Pixels[0])
+		}
+	})
+}
+
+// export unit tests
+func Test_ExportVRAMDebug(t *testing.T) {
+
+	t.Run(">>> export VRAM debug: scenario 1 - write PNG and JSON files", func(t *testing.T) {
+
+		directory := t.TempDir()
+
+		err := NewPPU(true).ExportVRAMDebug(directory, nil)
+		if err != nil {
+			t.Errorf("fail exporting VRAM debug: %s", err.Error())
+		}
+
+		for _, fileName := range []string{"tiles.png", "bgmap0.png", "bgmap1.png", "oam.png", "tiles.json", "bgmap1.json"} {
+			_, err = os.Stat(filepath.Join(directory, fileName))
+			if err != nil {
+				t.Errorf("missing exported file: %s", fileName)
+			}
+		}
+
+		data, err := os.ReadFile(filepath.Join(directory, "oam.json"))
+		if err != nil {
+			t.Errorf("fail reading OAM JSON: %s", err.Error())
+		}
+
+		var sprites []DebugSprite
+
+		err = json.Unmarshal(data, &sprites)
+		if err != nil || len(sprites) != OAM_SPRITES {
+			t.Errorf("failed exporting OAM JSON")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_framebuffer.go - Oct-18-2026 by aldebap
+//
+//	PPU framebuffer
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"hash/fnv"
+)
+
+// LCD screen dimensions
+const (
+	SCREEN_WIDTH  = 160
+	SCREEN_HEIGHT = 144
+)
+
+// DMG palette used to draw a pixel
+const (
+	DMG_PALETTE_BG   = uint8(0)
+	DMG_PALETTE_OBJ0 = uint8(1)
+	DMG_PALETTE_OBJ1 = uint8(2)
+)
+
+// PPU framebuffer: in CGB mode every pixel is a 15 bit color (0bbbbbgggggrrrrr),
+// in DMG mode every pixel is a shade (bits 0-1) and the palette it came from (bits 2-3)
+type Framebuffer struct {
+	cgbMode bool
+	pixels  []uint16
+}
+
+// create a new framebuffer
+func NewFramebuffer(cgbMode bool) *Framebuffer {
+
+	return &Framebuffer{
+		cgbMode: cgbMode,
+		pixels:  make([]uint16, SCREEN_WIDTH*SCREEN_HEIGHT),
+	}
+}
+
+// return true if the framebuffer holds CGB colors
+func (f *Framebuffer) CGBMode() bool {
+	return f.cgbMode
+}
+
+// set a CGB 15 bit color pixel
+func (f *Framebuffer) SetPixel(x int, y int, color uint16) {
+	if x < 0 || x >= SCREEN_WIDTH || y < 0 || y >= SCREEN_HEIGHT {
+		return
+	}
+
+	f.pixels[y*SCREEN_WIDTH+x] = color & 0x7fff
+}
+
+// set a DMG pixel shade and the palette it came from
+func (f *Framebuffer) SetDMGPixel(x int, y int, shade uint8, palette uint8) {
+	if x < 0 || x >= SCREEN_WIDTH || y < 0 || y >= SCREEN_HEIGHT {
+		return
+	}
+
+	f.pixels[y*SCREEN_WIDTH+x] = uint16(palette&0x03)<<2 | uint16(shade&0x03)
+}
+
+// get a raw pixel value
+func (f *Framebuffer) Pixel(x int, y int) uint16 {
+	if x < 0 || x >= SCREEN_WIDTH || y < 0 || y >= SCREEN_HEIGHT {
+		return 0
+	}
+
+	return f.pixels[y*SCREEN_WIDTH+x]
+}
+
+// get a DMG pixel shade and the palette it came from
+func (f *Framebuffer) DMGPixel(x int, y int) (uint8, uint8) {
+	var pixel = f.Pixel(x, y)
+
+	return uint8(pixel & 0x03), uint8(pixel >> 2 & 0x03)
+}
+
+// clear the framebuffer
+func (f *Framebuffer) Clear() {
+	for i := range f.pixels {
+		f.pixels[i] = 0
+	}
+}
+
+// return a 64 bit FNV-1a hash of the raw pixels
+func (f *Framebuffer) Hash() uint64 {
+	var hash = fnv.New64a()
+	var pixel [2]uint8
+
+	for _, value := range f.pixels {
+		pixel[0] = uint8(value)
+		pixel[1] = uint8(value >> 8)
+		hash.Write(pixel[:])
+	}
+
+	return hash.Sum64()
+}
@@ -0,0 +1,505 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_postprocessing.go - Oct-18-2026 by aldebap
+//
+//	PPU framebuffer post processing: color correction and DMG palettes
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/aldebap/go_gbc/cartridge"
+)
+
+// color correction curves
+const (
+	COLOR_CORRECTION_NONE    = uint8(0)
+	COLOR_CORRECTION_GBC_LCD = uint8(1)
+	COLOR_CORRECTION_GAMMA   = uint8(2)
+)
+
+// DMG palette presets
+const (
+	DMG_PALETTE_PRESET_GREEN     = uint8(0)
+	DMG_PALETTE_PRESET_GRAYSCALE = uint8(1)
+	DMG_PALETTE_PRESET_POCKET    = uint8(2)
+)
+
+// shades of the three DMG palettes converted to RGB
+type DMG_palette struct {
+	bg   [4]color.RGBA
+	obj0 [4]color.RGBA
+	obj1 [4]color.RGBA
+}
+
+// create a new DMG palette with custom colors
+func NewDMG_palette(bg [4]color.RGBA, obj0 [4]color.RGBA, obj1 [4]color.RGBA) *DMG_palette {
+
+	return &DMG_palette{
+		bg:   bg,
+		obj0: obj0,
+		obj1: obj1,
+	}
+}
+
+// create a new DMG palette from a preset
+func NewDMG_palettePreset(preset uint8) (*DMG_palette, error) {
+	var shades [4]color.RGBA
+
+	switch preset {
+	case DMG_PALETTE_PRESET_GREEN:
+		shades = [4]color.RGBA{rgb(0x9bbc0f), rgb(0x8bac0f), rgb(0x306230), rgb(0x0f380f)}
+
+	case DMG_PALETTE_PRESET_GRAYSCALE:
+		shades = [4]color.RGBA{rgb(0xffffff), rgb(0xaaaaaa), rgb(0x555555), rgb(0x000000)}
+
+	case DMG_PALETTE_PRESET_POCKET:
+		shades = [4]color.RGBA{rgb(0xc4cfa1), rgb(0x8b956d), rgb(0x4d533c), rgb(0x1f1f1f)}
+
+	default:
+		return nil, fmt.Errorf("invalid DMG palette preset: %d", preset)
+	}
+
+	return NewDMG_palette(shades, shades, shades), nil
+}
+
+// parse a DMG palette from a preset name or four comma separated RGB colors (e.g. "e0f8d0,88c070,346856,081820")
+func ParseDMG_palette(value string) (*DMG_palette, error) {
+
+	switch strings.ToLower(value) {
+	case "green":
+		return NewDMG_palettePreset(DMG_PALETTE_PRESET_GREEN)
+
+	case "grayscale", "gray", "grey":
+		return NewDMG_palettePreset(DMG_PALETTE_PRESET_GRAYSCALE)
+
+	case "pocket":
+		return NewDMG_palettePreset(DMG_PALETTE_PRESET_POCKET)
+	}
+
+	var shades [4]color.RGBA
+
+	items := strings.Split(value, ",")
+	if len(items) != len(shades) {
+		return nil, fmt.Errorf("invalid DMG palette: %s", value)
+	}
+
+	for i, item := range items {
+		aux, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(item), "#"), 16, 24)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DMG palette color: %s", item)
+		}
+		shades[i] = rgb(uint32(aux))
+	}
+
+	return NewDMG_palette(shades, shades, shades), nil
+}
+
+// parse a color correction curve name
+func ParseColorCorrection(value string) (uint8, error) {
+
+	switch strings.ToLower(value) {
+	case "none":
+		return COLOR_CORRECTION_NONE, nil
+
+	case "gbc", "lcd":
+		return COLOR_CORRECTION_GBC_LCD, nil
+
+	case "gamma":
+		return COLOR_CORRECTION_GAMMA, nil
+	}
+
+	return 0, fmt.Errorf("invalid color correction: %s", value)
+}
+
+// framebuffer post processor
+type PostProcessor struct {
+	colorCorrection uint8
+	dmgPalette      *DMG_palette
+	colorTable      []color.RGBA
+}
+
+// create a new framebuffer post processor
+func NewPostProcessor(colorCorrection uint8, dmgPalette *DMG_palette) (*PostProcessor, error) {
+	var err error
+
+	if dmgPalette == nil {
+		dmgPalette, err = NewDMG_palettePreset(DMG_PALETTE_PRESET_GREEN)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p := &PostProcessor{
+		dmgPalette: dmgPalette,
+		colorTable: make([]color.RGBA, 0x8000),
+	}
+
+	err = p.SetColorCorrection(colorCorrection)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// set the color correction curve applied to CGB colors
+func (p *PostProcessor) SetColorCorrection(colorCorrection uint8) error {
+
+	switch colorCorrection {
+	case COLOR_CORRECTION_NONE, COLOR_CORRECTION_GBC_LCD, COLOR_CORRECTION_GAMMA:
+	default:
+		return fmt.Errorf("invalid color correction: %d", colorCorrection)
+	}
+
+	p.colorCorrection = colorCorrection
+
+	//	the color table is built once for all 32768 CGB colors
+	for i := range p.colorTable {
+		p.colorTable[i] = correctColor(uint16(i), colorCorrection)
+	}
+
+	return nil
+}
+
+// set the palette used to draw DMG framebuffers
+func (p *PostProcessor) SetDMGPalette(dmgPalette *DMG_palette) {
+	p.dmgPalette = dmgPalette
+}
+
+// use the CGB boot ROM compatibility palette chosen by the cartridge header
+func (p *PostProcessor) UseCompatibilityPalette(rom []uint8) error {
+
+	palette, err := chooseCompatibilityPalette(rom)
+	if err != nil {
+		return err
+	}
+
+	var dmgPalette DMG_palette
+
+	for i := range 4 {
+		dmgPalette.bg[i] = p.CorrectColor(palette.bg[i])
+		dmgPalette.obj0[i] = p.CorrectColor(palette.obj0[i])
+		dmgPalette.obj1[i] = p.CorrectColor(palette.obj1[i])
+	}
+	p.dmgPalette = &dmgPalette
+
+	return nil
+}
+
+// convert a CGB 15 bit color using the current color correction
+func (p *PostProcessor) CorrectColor(value uint16) color.RGBA {
+	return p.colorTable[value&0x7fff]
+}
+
+// convert a framebuffer into a new RGBA image
+func (p *PostProcessor) Process(framebuffer *Framebuffer) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, SCREEN_WIDTH, SCREEN_HEIGHT))
+
+	p.ProcessInto(framebuffer, img)
+
+	return img
+}
+
+// convert a framebuffer into an existing RGBA image
+func (p *PostProcessor) ProcessInto(framebuffer *Framebuffer, img *image.RGBA) {
+
+	for y := 0; y < SCREEN_HEIGHT; y++ {
+		for x := 0; x < SCREEN_WIDTH; x++ {
+			var pixel color.RGBA
+
+			if framebuffer.CGBMode() {
+				pixel = p.CorrectColor(framebuffer.Pixel(x, y))
+			} else {
+				shade, palette := framebuffer.DMGPixel(x, y)
+
+				switch palette {
+				case DMG_PALETTE_OBJ0:
+					pixel = p.dmgPalette.obj0[shade]
+
+				case DMG_PALETTE_OBJ1:
+					pixel = p.dmgPalette.obj1[shade]
+
+				default:
+					pixel = p.dmgPalette.bg[shade]
+				}
+			}
+
+			img.SetRGBA(x, y, pixel)
+		}
+	}
+}
+
+// convert a 24 bit RGB value into a color
+func rgb(value uint32) color.RGBA {
+
+	return color.RGBA{
+		R: uint8(value >> 16 & 0xff),
+		G: uint8(value >> 8 & 0xff),
+		B: uint8(value & 0xff),
+		A: 0xff,
+	}
+}
+
+// convert a CGB 15 bit color into RGB applying a color correction curve
+func correctColor(value uint16, colorCorrection uint8) color.RGBA {
+	var r = uint32(value & 0x1f)
+	var g = uint32(value >> 5 & 0x1f)
+	var b = uint32(value >> 10 & 0x1f)
+
+	switch colorCorrection {
+	case COLOR_CORRECTION_GBC_LCD:
+		//	channel mixing approximating the GBC LCD response (by Near)
+		R := min(960, r*26+g*4+b*2) >> 2
+		G := min(960, g*24+b*8) >> 2
+		B := min(960, r*6+g*4+b*22) >> 2
+
+		return color.RGBA{R: uint8(R), G: uint8(G), B: uint8(B), A: 0xff}
+
+	case COLOR_CORRECTION_GAMMA:
+		//	linearize using the LCD gamma, mix channels and encode for a sRGB display
+		const (
+			lcdGamma     = 4.0
+			displayGamma = 2.2
+			scale        = 255.0 * 255.0 / 280.0
+		)
+
+		lr := math.Pow(float64(r)/31.0, lcdGamma)
+		lg := math.Pow(float64(g)/31.0, lcdGamma)
+		lb := math.Pow(float64(b)/31.0, lcdGamma)
+
+		R := math.Pow((0*lb+50*lg+255*lr)/255.0, 1/displayGamma) * scale
+		G := math.Pow((30*lb+230*lg+10*lr)/255.0, 1/displayGamma) * scale
+		B := math.Pow((220*lb+10*lg+50*lr)/255.0, 1/displayGamma) * scale
+
+		return color.RGBA{R: uint8(math.Round(R)), G: uint8(math.Round(G)), B: uint8(math.Round(B)), A: 0xff}
+	}
+
+	//	expand 5 bit channels to 8 bits
+	return color.RGBA{
+		R: uint8(r<<3 | r>>2),
+		G: uint8(g<<3 | g>>2),
+		B: uint8(b<<3 | b>>2),
+		A: 0xff,
+	}
+}
+
+// CGB boot ROM compatibility palette (15 bit colors)
+type compatibilityPalette struct {
+	bg   [4]uint16
+	obj0 [4]uint16
+	obj1 [4]uint16
+}
+
+// compatibility palette selected by a title checksum (fourth letter 0 matches any title)
+type compatibilityPaletteEntry struct {
+	checksum     uint8
+	fourthLetter uint8
+	palette      compatibilityPalette
+}
+
+// colors of the compatibility palettes stored in the CGB boot ROM (four 15 bit colors each)
+var compatibilityColors = [...]uint16{
+	0x7fff, 0x32bf, 0x00d0, 0x0000,
+	0x639f, 0x4279, 0x15b0, 0x04cb,
+	0x7fff, 0x6e31, 0x454a, 0x0000,
+	0x7fff, 0x1bef, 0x0200, 0x0000,
+	0x7fff, 0x421f, 0x1cf2, 0x0000,
+	0x7fff, 0x5294, 0x294a, 0x0000,
+	0x7fff, 0x03ff, 0x012f, 0x0000,
+	0x7fff, 0x03ef, 0x01d6, 0x0000,
+	0x7fff, 0x42b5, 0x3dc8, 0x0000,
+	0x7e74, 0x03ff, 0x0180, 0x0000,
+	0x67ff, 0x77ac, 0x1a13, 0x2d6b,
+	0x7ed6, 0x4bff, 0x2175, 0x0000,
+	0x53ff, 0x4a5f, 0x7e52, 0x0000,
+	0x4fff, 0x7ed2, 0x3a4c, 0x1ce0,
+	0x03ed, 0x7fff, 0x255f, 0x0000,
+	0x036a, 0x021f, 0x03ff, 0x7fff,
+	0x7fff, 0x01df, 0x0112, 0x0000,
+	0x231f, 0x035f, 0x00f2, 0x0009,
+	0x7fff, 0x03ea, 0x011f, 0x0000,
+	0x299f, 0x001a, 0x000c, 0x0000,
+	0x7fff, 0x027f, 0x001f, 0x0000,
+	0x7fff, 0x03e0, 0x0206, 0x0120,
+	0x7fff, 0x7eeb, 0x001f, 0x7c00,
+	0x7fff, 0x3fff, 0x7e00, 0x001f,
+	0x7fff, 0x03ff, 0x001f, 0x0000,
+	0x03ff, 0x001f, 0x000c, 0x0000,
+	0x7fff, 0x033f, 0x0193, 0x0000,
+	0x0000, 0x4200, 0x037f, 0x7fff,
+	0x7fff, 0x7e8c, 0x7c00, 0x0000,
+	0x7fff, 0x1bef, 0x6180, 0x0000,
+}
+
+// build a compatibility palette from the boot ROM palettes used for OBJ0, OBJ1 and BG
+func bootROMPalette(obj0 int, obj1 int, bg int) compatibilityPalette {
+	return shiftedBootROMPalette(obj0*4, obj1*4, bg*4)
+}
+
+// build a compatibility palette from offsets (in colors) into the boot ROM palettes: a few combinations
+// start in the middle of a palette, taking its last color and the first three of the next one
+func shiftedBootROMPalette(obj0 int, obj1 int, bg int) compatibilityPalette {
+	var palette compatibilityPalette
+
+	copy(palette.obj0[:], compatibilityColors[obj0:obj0+4])
+	copy(palette.obj1[:], compatibilityColors[obj1:obj1+4])
+	copy(palette.bg[:], compatibilityColors[bg:bg+4])
+
+	return palette
+}
+
+// palette used by the boot ROM for non Nintendo titles and unknown checksums
+var defaultCompatibilityPalette = bootROMPalette(4, 4, 29)
+
+// compatibility palettes chosen by the title checksum, in the boot ROM order: the last entries share
+// their checksums and are told apart by the fourth letter of the title
+var compatibilityPaletteTable = []compatibilityPaletteEntry{
+	{checksum: 0x88, fourthLetter: 0, palette: bootROMPalette(9, 9, 9)},            // ALLEY WAY
+	{checksum: 0x16, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},            // YAKUMAN
+	{checksum: 0x36, fourthLetter: 0, palette: shiftedBootROMPalette(111, 16, 60)}, // BASEBALL, (GAME AND WATCH 2)
+	{checksum: 0xd1, fourthLetter: 0, palette: shiftedBootROMPalette(111, 0, 56)},  // TENNIS
+	{checksum: 0xdb, fourthLetter: 0, palette: bootROMPalette(24, 24, 24)},         // TETRIS
+	{checksum: 0xf2, fourthLetter: 0, palette: bootROMPalette(24, 22, 24)},         // QIX
+	{checksum: 0x3c, fourthLetter: 0, palette: bootROMPalette(28, 4, 28)},          // DR.MARIO
+	{checksum: 0x8c, fourthLetter: 0, palette: bootROMPalette(16, 8, 8)},           // RADARMISSION
+	{checksum: 0x92, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},            // F1RACE
+	{checksum: 0x3d, fourthLetter: 0, palette: bootROMPalette(4, 4, 18)},           // YOSSY NO TAMAGO
+	{checksum: 0x5c, fourthLetter: 0, palette: bootROMPalette(19, 22, 9)},
+	{checksum: 0x58, fourthLetter: 0, palette: bootROMPalette(5, 5, 5)},    // X
+	{checksum: 0xc9, fourthLetter: 0, palette: bootROMPalette(16, 28, 10)}, // MARIOLAND2
+	{checksum: 0x3e, fourthLetter: 0, palette: bootROMPalette(20, 22, 20)}, // YOSSY NO COOKIE
+	{checksum: 0x70, fourthLetter: 0, palette: bootROMPalette(21, 28, 4)},  // ZELDA
+	{checksum: 0x1d, fourthLetter: 0, palette: bootROMPalette(19, 19, 9)},
+	{checksum: 0x59, fourthLetter: 0, palette: bootROMPalette(16, 22, 8)},
+	{checksum: 0x69, fourthLetter: 0, palette: bootROMPalette(24, 22, 24)}, // TETRIS FLASH
+	{checksum: 0x19, fourthLetter: 0, palette: bootROMPalette(4, 4, 20)},   // DONKEY KONG
+	{checksum: 0x35, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},    // MARIO'S PICROSS
+	{checksum: 0xa8, fourthLetter: 0, palette: bootROMPalette(17, 4, 13)},
+	{checksum: 0x14, fourthLetter: 0, palette: bootROMPalette(3, 4, 4)},    // POKEMON RED, (GAMEBOYCAMERA G)
+	{checksum: 0xaa, fourthLetter: 0, palette: bootROMPalette(4, 29, 29)},  // POKEMON GREEN
+	{checksum: 0x75, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},    // PICROSS 2
+	{checksum: 0x95, fourthLetter: 0, palette: bootROMPalette(18, 22, 18)}, // YOSSY NO PANEPON
+	{checksum: 0x99, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},    // KIRAKIRA KIDS
+	{checksum: 0x34, fourthLetter: 0, palette: bootROMPalette(4, 4, 7)},    // GAMEBOY GALLERY
+	{checksum: 0x6f, fourthLetter: 0, palette: bootROMPalette(26, 26, 26)}, // POCKETCAMERA
+	{checksum: 0x15, fourthLetter: 0, palette: bootROMPalette(24, 24, 24)},
+	{checksum: 0xff, fourthLetter: 0, palette: bootROMPalette(20, 20, 20)}, // BALLOON KID
+	{checksum: 0x97, fourthLetter: 0, palette: bootROMPalette(28, 28, 0)},  // KINGOFTHEZOO
+	{checksum: 0x4b, fourthLetter: 0, palette: bootROMPalette(4, 4, 3)},    // DMG FOOTBALL
+	{checksum: 0x90, fourthLetter: 0, palette: bootROMPalette(4, 4, 3)},    // WORLD CUP
+	{checksum: 0x17, fourthLetter: 0, palette: bootROMPalette(4, 28, 3)},   // OTHELLO
+	{checksum: 0x10, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},   // SUPER RC PRO-AM
+	{checksum: 0x39, fourthLetter: 0, palette: bootROMPalette(28, 28, 0)},  // DYNABLASTER
+	{checksum: 0xf7, fourthLetter: 0, palette: bootROMPalette(3, 28, 0)},   // BOY AND BLOB GB2
+	{checksum: 0xf6, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},   // MEGAMAN
+	{checksum: 0xa2, fourthLetter: 0, palette: bootROMPalette(3, 28, 0)},   // STAR WARS-NOA
+	{checksum: 0x49, fourthLetter: 0, palette: bootROMPalette(19, 22, 9)},
+	{checksum: 0x4e, fourthLetter: 0, palette: bootROMPalette(4, 23, 28)}, // WAVERACE
+	{checksum: 0x43, fourthLetter: 0, palette: bootROMPalette(28, 28, 0)},
+	{checksum: 0x68, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},   // LOLO2
+	{checksum: 0xe0, fourthLetter: 0, palette: bootROMPalette(20, 22, 20)}, // YOSHI'S COOKIE
+	{checksum: 0x8b, fourthLetter: 0, palette: bootROMPalette(4, 28, 3)},   // MYSTIC QUEST
+	{checksum: 0xf0, fourthLetter: 0, palette: shiftedBootROMPalette(111, 0, 56)},
+	{checksum: 0xce, fourthLetter: 0, palette: shiftedBootROMPalette(111, 0, 56)}, // TOPRANKINGTENNIS
+	{checksum: 0x0c, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},           // MANSELL
+	{checksum: 0x29, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},          // MEGAMAN3
+	{checksum: 0xe8, fourthLetter: 0, palette: bootROMPalette(27, 27, 27)},        // SPACE INVADERS
+	{checksum: 0xb7, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},           // GAME&WATCH
+	{checksum: 0x86, fourthLetter: 0, palette: bootROMPalette(17, 4, 13)},         // DONKEYKONGLAND95
+	{checksum: 0x9a, fourthLetter: 0, palette: bootROMPalette(4, 4, 3)},           // ASTEROIDS/MISCMD
+	{checksum: 0x52, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},          // STREET FIGHTER 2
+	{checksum: 0x01, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)},          // DEFENDER/JOUST
+	{checksum: 0x9d, fourthLetter: 0, palette: bootROMPalette(4, 0, 2)},           // KILLERINSTINCT95
+	{checksum: 0x71, fourthLetter: 0, palette: bootROMPalette(20, 20, 20)},        // TETRIS BLAST
+	{checksum: 0x9c, fourthLetter: 0, palette: bootROMPalette(2, 17, 2)},          // PINOCCHIO
+	{checksum: 0xbd, fourthLetter: 0, palette: bootROMPalette(4, 4, 3)},
+	{checksum: 0x5d, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)}, // BA.TOSHINDEN
+	{checksum: 0x6d, fourthLetter: 0, palette: bootROMPalette(28, 3, 0)}, // NETTOU KOF 95
+	{checksum: 0x67, fourthLetter: 0, palette: bootROMPalette(0, 0, 0)},
+	{checksum: 0x3f, fourthLetter: 0, palette: bootROMPalette(4, 4, 29)},  // TETRIS PLUS
+	{checksum: 0x6b, fourthLetter: 0, palette: bootROMPalette(17, 22, 2)}, // DONKEYKONGLAND 3
+	{checksum: 0xb3, fourthLetter: 'B', palette: bootROMPalette(19, 22, 9)},
+	{checksum: 0x46, fourthLetter: 'E', palette: shiftedBootROMPalette(15, 15, 44)}, // SUPER MARIOLAND
+	{checksum: 0x28, fourthLetter: 'F', palette: bootROMPalette(4, 4, 3)},           // GOLF
+	{checksum: 0xa5, fourthLetter: 'A', palette: bootROMPalette(27, 27, 27)},        // SOLARSTRIKER
+	{checksum: 0xc6, fourthLetter: 'A', palette: bootROMPalette(16, 22, 8)},         // GBWARS
+	{checksum: 0xd3, fourthLetter: 'R', palette: bootROMPalette(4, 2, 2)},           // KAERUNOTAMENI
+	{checksum: 0x27, fourthLetter: 'B', palette: bootROMPalette(19, 22, 9)},
+	{checksum: 0x61, fourthLetter: 'E', palette: bootROMPalette(4, 28, 28)},  // POKEMON BLUE
+	{checksum: 0x18, fourthLetter: 'K', palette: bootROMPalette(17, 22, 2)},  // DONKEYKONGLAND
+	{checksum: 0x66, fourthLetter: 'E', palette: bootROMPalette(4, 4, 7)},    // GAMEBOY GALLERY2
+	{checksum: 0x6a, fourthLetter: 'K', palette: bootROMPalette(17, 22, 2)},  // DONKEYKONGLAND 2
+	{checksum: 0xbf, fourthLetter: ' ', palette: bootROMPalette(4, 4, 2)},    // KID ICARUS
+	{checksum: 0x0d, fourthLetter: 'R', palette: bootROMPalette(24, 22, 24)}, // TETRIS2
+	{checksum: 0xf4, fourthLetter: '-', palette: bootROMPalette(4, 28, 29)},
+	{checksum: 0xb3, fourthLetter: 'U', palette: bootROMPalette(16, 16, 8)}, // MOGURANYA
+	{checksum: 0x46, fourthLetter: 'R', palette: bootROMPalette(25, 3, 28)},
+	{checksum: 0x28, fourthLetter: 'A', palette: bootROMPalette(27, 27, 27)}, // GALAGA&GALAXIAN
+	{checksum: 0xa5, fourthLetter: 'R', palette: bootROMPalette(3, 3, 0)},    // BT2RAGNAROKWORLD
+	{checksum: 0xc6, fourthLetter: ' ', palette: bootROMPalette(4, 4, 29)},   // KEN GRIFFEY JR
+	{checksum: 0xd3, fourthLetter: 'I', palette: bootROMPalette(0, 28, 8)},
+	{checksum: 0x27, fourthLetter: 'N', palette: bootROMPalette(4, 28, 3)}, // MAGNETIC SOCCER
+	{checksum: 0x61, fourthLetter: 'A', palette: bootROMPalette(4, 28, 3)}, // VEGAS STAKES
+	{checksum: 0x18, fourthLetter: 'I', palette: bootROMPalette(4, 4, 29)},
+	{checksum: 0x66, fourthLetter: 'L', palette: bootROMPalette(4, 4, 29)},          // MILLI/CENTI/PEDE
+	{checksum: 0x6a, fourthLetter: 'I', palette: bootROMPalette(4, 4, 18)},          // MARIO & YOSHI
+	{checksum: 0xbf, fourthLetter: 'C', palette: shiftedBootROMPalette(111, 0, 56)}, // SOCCER
+	{checksum: 0x0d, fourthLetter: 'E', palette: bootROMPalette(17, 17, 2)},         // POKEBOM
+	{checksum: 0xf4, fourthLetter: ' ', palette: bootROMPalette(4, 4, 7)},           // G&W GALLERY
+	{checksum: 0xb3, fourthLetter: 'R', palette: bootROMPalette(18, 22, 18)},        // TETRIS ATTACK
+}
+
+// calculate the cartridge title checksum used by the CGB boot ROM
+func CartridgeTitleChecksum(rom []uint8) (uint8, error) {
+	if len(rom) < cartridge.CARTRIDGE_HEADER_END_ADDRESS {
+		return 0, fmt.Errorf("cartridge header is too short")
+	}
+
+	var checksum uint8
+
+	for _, value := range rom[cartridge.CARTRIDGE_TITLE_ADDRESS : cartridge.CARTRIDGE_TITLE_ADDRESS+cartridge.CARTRIDGE_TITLE_LENGTH] {
+		checksum += value
+	}
+
+	return checksum, nil
+}
+
+// choose the CGB boot ROM compatibility palette for a DMG cartridge
+func chooseCompatibilityPalette(rom []uint8) (compatibilityPalette, error) {
+
+	checksum, err := CartridgeTitleChecksum(rom)
+	if err != nil {
+		return compatibilityPalette{}, err
+	}
+
+	//	only titles published by Nintendo get a dedicated palette
+	switch rom[cartridge.CARTRIDGE_OLD_LICENSEE_ADDRESS] {
+	case cartridge.CARTRIDGE_NINTENDO_LICENSEE:
+
+	case cartridge.CARTRIDGE_USE_NEW_LICENSEE_CODE:
+		newLicensee := rom[cartridge.CARTRIDGE_NEW_LICENSEE_ADDRESS : cartridge.CARTRIDGE_NEW_LICENSEE_ADDRESS+cartridge.CARTRIDGE_NEW_LICENSEE_LENGTH]
+		if string(newLicensee) != cartridge.CARTRIDGE_NINTENDO_NEW_LICENSEE {
+			return defaultCompatibilityPalette, nil
+		}
+
+	default:
+		return defaultCompatibilityPalette, nil
+	}
+
+	fourthLetter := rom[cartridge.CARTRIDGE_TITLE_ADDRESS+3]
+
+	for _, entry := range compatibilityPaletteTable {
+		if entry.checksum == checksum && (entry.fourthLetter == 0 || entry.fourthLetter == fourthLetter) {
+			return entry.palette, nil
+		}
+	}
+
+	return defaultCompatibilityPalette, nil
+}
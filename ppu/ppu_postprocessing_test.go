@@ -0,0 +1,229 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_postprocessing_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for PPU framebuffer post processing
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cartridge"
+)
+
+// build a ROM with a cartridge header
+func newCartridgeHeader(title string, oldLicensee uint8) []uint8 {
+	rom := make([]uint8, cartridge.CARTRIDGE_HEADER_END_ADDRESS)
+
+	copy(rom[cartridge.CARTRIDGE_TITLE_ADDRESS:cartridge.CARTRIDGE_TITLE_ADDRESS+cartridge.CARTRIDGE_TITLE_LENGTH], title)
+	rom[cartridge.CARTRIDGE_OLD_LICENSEE_ADDRESS] = oldLicensee
+
+	return rom
+}
+
+// color correction unit tests
+func Test_ColorCorrection(t *testing.T) {
+
+	t.Run(">>> color correction: scenario 1 - no correction expands 5 bit channels", func(t *testing.T) {
+
+		p, err := NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+		if err != nil {
+			t.Errorf("fail creating post processor: %s", err.Error())
+		}
+
+		want := color.RGBA{R: 0xff, G: 0x00, B: 0x84, A: 0xff}
+		got := p.CorrectColor(0x1f | 0x00<<5 | 0x10<<10)
+
+		if want != got {
+			t.Errorf("failed converting color: expected: %v\n\tresult: %v", want, got)
+		}
+	})
+
+	t.Run(">>> color correction: scenario 2 - GBC LCD keeps white and black", func(t *testing.T) {
+
+		p, err := NewPostProcessor(COLOR_CORRECTION_GBC_LCD, nil)
+		if err != nil {
+			t.Errorf("fail creating post processor: %s", err.Error())
+		}
+
+		want := color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+		got := p.CorrectColor(0x7fff)
+		if want != got {
+			t.Errorf("failed converting white: expected: %v\n\tresult: %v", want, got)
+		}
+
+		want = color.RGBA{A: 0xff}
+		got = p.CorrectColor(0x0000)
+		if want != got {
+			t.Errorf("failed converting black: expected: %v\n\tresult: %v", want, got)
+		}
+	})
+
+	t.Run(">>> color correction: scenario 3 - gamma darkens mid tones", func(t *testing.T) {
+
+		p, err := NewPostProcessor(COLOR_CORRECTION_GAMMA, nil)
+		if err != nil {
+			t.Errorf("fail creating post processor: %s", err.Error())
+		}
+
+		gray := uint16(0x10 | 0x10<<5 | 0x10<<10)
+		got := p.CorrectColor(gray)
+
+		if got.G >= 0x84 {
+			t.Errorf("failed converting gray: expected green below 0x84\n\tresult: %v", got)
+		}
+	})
+
+	t.Run(">>> color correction: scenario 4 - invalid curve", func(t *testing.T) {
+
+		_, err := NewPostProcessor(0xff, nil)
+		if err == nil {
+			t.Errorf("expected error for invalid color correction")
+		}
+	})
+}
+
+// DMG palette unit tests
+func Test_DMGPalette(t *testing.T) {
+
+	t.Run(">>> DMG palette: scenario 1 - grayscale preset", func(t *testing.T) {
+
+		palette, err := ParseDMG_palette("grayscale")
+		if err != nil {
+			t.Errorf("fail parsing DMG palette: %s", err.Error())
+		}
+
+		p, err := NewPostProcessor(COLOR_CORRECTION_NONE, palette)
+		if err != nil {
+			t.Errorf("fail creating post processor: %s", err.Error())
+		}
+
+		framebuffer := NewFramebuffer(false)
+		framebuffer.SetDMGPixel(0, 0, 0, DMG_PALETTE_BG)
+		framebuffer.SetDMGPixel(1, 0, 2, DMG_PALETTE_OBJ1)
+
+		img := p.Process(framebuffer)
+
+		want := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+		got := img.RGBAAt(0, 0)
+		if want != got {
+			t.Errorf("failed drawing shade 0: expected: %v\n\tresult: %v", want, got)
+		}
+
+		want = color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+		got = img.RGBAAt(1, 0)
+		if want != got {
+			t.Errorf("failed drawing shade 2: expected: %v\n\tresult: %v", want, got)
+		}
+	})
+
+	t.Run(">>> DMG palette: scenario 2 - custom RGB palette", func(t *testing.T) {
+
+		palette, err := ParseDMG_palette("e0f8d0,88c070,#346856,081820")
+		if err != nil {
+			t.Errorf("fail parsing DMG palette: %s", err.Error())
+		}
+
+		want := color.RGBA{R: 0x34, G: 0x68, B: 0x56, A: 0xff}
+		got := palette.bg[2]
+		if want != got {
+			t.Errorf("failed parsing custom palette: expected: %v\n\tresult: %v", want, got)
+		}
+	})
+
+	t.Run(">>> DMG palette: scenario 3 - invalid palette", func(t *testing.T) {
+
+		_, err := ParseDMG_palette("e0f8d0,88c070")
+		if err == nil {
+			t.Errorf("expected error for invalid palette")
+		}
+	})
+}
+
+// compatibility palette unit tests
+func Test_CompatibilityPalette(t *testing.T) {
+
+	t.Run(">>> compatibility palette: scenario 1 - title checksum", func(t *testing.T) {
+
+		checksum, err := CartridgeTitleChecksum(newCartridgeHeader("POKEMON RED", cartridge.CARTRIDGE_NINTENDO_LICENSEE))
+		if err != nil {
+			t.Errorf("fail calculating checksum: %s", err.Error())
+		}
+
+		if checksum != 0x14 {
+			t.Errorf("failed calculating checksum: expected: 0x14\n\tresult: 0x%02x", checksum)
+		}
+	})
+
+	t.Run(">>> compatibility palette: scenario 2 - Nintendo title", func(t *testing.T) {
+
+		palette, err := chooseCompatibilityPalette(newCartridgeHeader("POKEMON RED", cartridge.CARTRIDGE_NINTENDO_LICENSEE))
+		if err != nil {
+			t.Errorf("fail choosing palette: %s", err.Error())
+		}
+
+		//	POKEMON RED has a red background (0xff8484) and green OBJ0 sprites (0x7bff31)
+		want := [2]uint16{0x421f, 0x1bef}
+		got := [2]uint16{palette.bg[1], palette.obj0[1]}
+		if want != got {
+			t.Errorf("failed choosing palette: expected: %04x\n\tresult: %04x", want, got)
+		}
+	})
+
+	t.Run(">>> compatibility palette: scenario 3 - non Nintendo title uses default", func(t *testing.T) {
+
+		palette, err := chooseCompatibilityPalette(newCartridgeHeader("POKEMON RED", 0x08))
+		if err != nil {
+			t.Errorf("fail choosing palette: %s", err.Error())
+		}
+
+		if palette != defaultCompatibilityPalette {
+			t.Errorf("failed choosing palette: expected default palette")
+		}
+
+		//	the new licensee code is used when the old one is 0x33
+		rom := newCartridgeHeader("POKEMON RED", cartridge.CARTRIDGE_USE_NEW_LICENSEE_CODE)
+		copy(rom[cartridge.CARTRIDGE_NEW_LICENSEE_ADDRESS:], cartridge.CARTRIDGE_NINTENDO_NEW_LICENSEE)
+
+		palette, _ = chooseCompatibilityPalette(rom)
+		if palette == defaultCompatibilityPalette {
+			t.Errorf("failed choosing palette: expected the POKEMON RED palette for the new licensee code")
+		}
+	})
+
+	t.Run(">>> compatibility palette: scenario 4 - titles sharing a checksum", func(t *testing.T) {
+
+		for _, test := range []struct {
+			title string
+			want  compatibilityPalette
+		}{
+			{title: "POKEMON BLUE", want: bootROMPalette(4, 28, 28)},
+			{title: "VEGAS STAKES", want: bootROMPalette(4, 28, 3)},
+			{title: "POKXMON BLBE", want: defaultCompatibilityPalette},
+		} {
+			palette, err := chooseCompatibilityPalette(newCartridgeHeader(test.title, cartridge.CARTRIDGE_NINTENDO_LICENSEE))
+			if err != nil {
+				t.Errorf("fail choosing palette: %s", err.Error())
+			}
+
+			if palette != test.want {
+				t.Errorf("failed choosing palette for %s: expected: %v\n\tresult: %v", test.title, test.want, palette)
+			}
+		}
+	})
+
+	t.Run(">>> compatibility palette: scenario 5 - short header", func(t *testing.T) {
+
+		p, err := NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+		if err != nil {
+			t.Errorf("fail creating post processor: %s", err.Error())
+		}
+
+		err = p.UseCompatibilityPalette([]uint8{0x00})
+		if err == nil {
+			t.Errorf("expected error for short cartridge header")
+		}
+	})
+}
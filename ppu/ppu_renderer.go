@@ -0,0 +1,126 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_renderer.go - Oct-18-2026 by aldebap
+//
+//	PPU scanline renderer: background, window and sprites
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+// BG/window pixels of a line
+type bgLinePixels struct {
+	colorIndex [SCREEN_WIDTH]uint8
+	palette    [SCREEN_WIDTH]uint8
+	priority   [SCREEN_WIDTH]bool
+}
+
+// render one line of the screen into the framebuffer
+func (p *PPU) renderScanline(line uint8) {
+	if line >= SCREEN_HEIGHT {
+		return
+	}
+	if line == 0 {
+		p.windowLine = 0
+	}
+
+	bg := p.bgLine(line)
+	sprites := p.spriteLine(line)
+	bgEnabled := p.cgbMode || p.lcdc&LCDC_BG_WINDOW_ENABLE != 0
+
+	for x := 0; x < SCREEN_WIDTH; x++ {
+		sprite := sprites[x]
+
+		if p.spriteOverBG(sprite, bg.colorIndex[x], bg.priority[x]) {
+			if p.cgbMode {
+				p.framebuffer.SetPixel(x, int(line), p.objPaletteColor(sprite.palette, sprite.colorIndex))
+				continue
+			}
+
+			if sprite.palette != 0 {
+				p.framebuffer.SetDMGPixel(x, int(line), dmgShade(p.obp1, sprite.colorIndex), DMG_PALETTE_OBJ1)
+			} else {
+				p.framebuffer.SetDMGPixel(x, int(line), dmgShade(p.obp0, sprite.colorIndex), DMG_PALETTE_OBJ0)
+			}
+			continue
+		}
+
+		switch {
+		case p.cgbMode:
+			p.framebuffer.SetPixel(x, int(line), p.bgPaletteColor(bg.palette[x], bg.colorIndex[x]))
+
+		case bgEnabled:
+			p.framebuffer.SetDMGPixel(x, int(line), dmgShade(p.bgp, bg.colorIndex[x]), DMG_PALETTE_BG)
+
+		default:
+			//	on DMG clearing LCDC bit 0 blanks BG and window
+			p.framebuffer.SetDMGPixel(x, int(line), 0, DMG_PALETTE_BG)
+		}
+	}
+}
+
+// resolve the BG and window pixels of a line
+func (p *PPU) bgLine(line uint8) *bgLinePixels {
+	var pixels bgLinePixels
+
+	//	on DMG clearing LCDC bit 0 disables both BG and window
+	if !p.cgbMode && p.lcdc&LCDC_BG_WINDOW_ENABLE == 0 {
+		return &pixels
+	}
+
+	windowVisible := p.lcdc&LCDC_WINDOW_ENABLE != 0 && line >= p.wy && p.wx <= SCREEN_WIDTH+6
+	windowDrawn := false
+
+	for x := 0; x < SCREEN_WIDTH; x++ {
+		var mapOffset uint16
+		var mapX, mapY uint8
+
+		if windowVisible && x+7 >= int(p.wx) {
+			mapOffset = TILE_MAP_0_OFFSET
+			if p.lcdc&LCDC_WINDOW_TILE_MAP != 0 {
+				mapOffset = TILE_MAP_1_OFFSET
+			}
+			mapX = uint8(x + 7 - int(p.wx))
+			mapY = p.windowLine
+			windowDrawn = true
+		} else {
+			mapOffset = TILE_MAP_0_OFFSET
+			if p.lcdc&LCDC_BG_TILE_MAP != 0 {
+				mapOffset = TILE_MAP_1_OFFSET
+			}
+			mapX = uint8(x) + p.scx
+			mapY = line + p.scy
+		}
+
+		address := mapOffset + uint16(mapY/8)*TILE_MAP_SIZE + uint16(mapX/8)
+		tile := p.vram[0][address]
+
+		var attributes uint8
+		if p.cgbMode {
+			attributes = p.vram[1][address]
+		}
+
+		row := mapY % 8
+		if attributes&ATTR_Y_FLIP != 0 {
+			row = 7 - row
+		}
+
+		tilePixels := p.tileRow((attributes&ATTR_BANK)>>3, p.bgTileAddress(tile), row, attributes&ATTR_X_FLIP != 0)
+
+		pixels.colorIndex[x] = tilePixels[mapX%8]
+		pixels.palette[x] = attributes & ATTR_PALETTE
+		pixels.priority[x] = attributes&ATTR_PRIORITY != 0
+	}
+
+	//	the window keeps its own line counter, only incremented when it is drawn
+	if windowDrawn {
+		p.windowLine++
+	}
+
+	return &pixels
+}
+
+// render all lines of the screen into the framebuffer
+func (p *PPU) RenderFrame() {
+	for line := uint8(0); line < SCREEN_HEIGHT; line++ {
+		p.renderScanline(line)
+	}
+}
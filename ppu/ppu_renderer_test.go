@@ -0,0 +1,76 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_renderer_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the PPU scanline renderer
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"testing"
+)
+
+// background and window rendering unit tests
+func Test_RenderScanline(t *testing.T) {
+
+	t.Run(">>> render scanline: scenario 1 - background scrolling wraps around", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x01, 3)
+
+		//	last column of the first map row
+		ppu.vram[0][TILE_MAP_0_OFFSET+31] = 0x01
+		ppu.scx = 0xfc
+
+		ppu.renderScanline(0)
+
+		for x, want := range []uint8{3, 3, 3, 3, 0} {
+			got, _ := ppu.framebuffer.DMGPixel(x, 0)
+			if want != got {
+				t.Errorf("failed scrolling pixel %d: expected: %d\n\tresult: %d", x, want, got)
+			}
+		}
+	})
+
+	t.Run(">>> render scanline: scenario 2 - window uses its own line counter", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x01, 2)
+
+		//	window map at 0x9c00 with tile 1 only in its first row
+		ppu.lcdc |= LCDC_WINDOW_ENABLE | LCDC_WINDOW_TILE_MAP
+		ppu.vram[0][TILE_MAP_1_OFFSET] = 0x01
+		ppu.wx = 7 + 80
+		ppu.wy = 10
+
+		for line := uint8(0); line < 20; line++ {
+			ppu.renderScanline(line)
+		}
+
+		if shade, _ := ppu.framebuffer.DMGPixel(80, 9); shade != 0 {
+			t.Errorf("failed drawing window: window visible above WY")
+		}
+		if shade, _ := ppu.framebuffer.DMGPixel(80, 17); shade != 2 {
+			t.Errorf("failed drawing window: expected window row 7 on line 17\n\tresult: %d", shade)
+		}
+		if shade, _ := ppu.framebuffer.DMGPixel(79, 17); shade != 0 {
+			t.Errorf("failed drawing window: window visible left of WX")
+		}
+		if shade, _ := ppu.framebuffer.DMGPixel(80, 18); shade != 0 {
+			t.Errorf("failed drawing window: expected window row 8 on line 18")
+		}
+	})
+
+	t.Run(">>> render scanline: scenario 3 - DMG LCDC bit 0 blanks the background", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x00, 3)
+		ppu.lcdc &^= LCDC_BG_WINDOW_ENABLE
+
+		ppu.renderScanline(0)
+
+		if shade, _ := ppu.framebuffer.DMGPixel(0, 0); shade != 0 {
+			t.Errorf("failed blanking background: expected shade 0\n\tresult: %d", shade)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_savestate.go - Oct-19-2026 by aldebap
+//
+//	PPU save state: memories, registers, timing and the framebuffer
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"fmt"
+
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// LCD registers in save state order
+func (p *PPU) stateRegisters() []*uint8 {
+	return []*uint8{&p.lcdc, &p.stat, &p.scy, &p.scx, &p.ly, &p.lyc, &p.dma, &p.bgp, &p.obp0, &p.obp1, &p.wy, &p.wx,
+		&p.windowLine, &p.vramBank, &p.bcps, &p.ocps}
+}
+
+// write the PPU state
+func (p *PPU) SaveState(w *savestate.Writer) {
+	w.Section("PPU")
+
+	w.Bool(p.cgbMode)
+	w.Bytes(p.vram[0])
+	w.Bytes(p.vram[1])
+	w.Bytes(p.oam)
+	w.Bytes(p.bgPaletteRAM)
+	w.Bytes(p.objPaletteRAM)
+
+	for _, register := range p.stateRegisters() {
+		w.Uint8(*register)
+	}
+
+	w.Int(p.dots)
+	w.Bool(p.statLine)
+	w.Bool(p.frameReady)
+
+	//	the framebuffer keeps the last frame on the screen after loading
+	for _, pixel := range p.framebuffer.pixels {
+		w.Uint16(pixel)
+	}
+}
+
+// read the PPU state
+func (p *PPU) LoadState(r *savestate.Reader) error {
+	r.Section("PPU")
+
+	if cgbMode := r.Bool(); r.Err() == nil && cgbMode != p.cgbMode {
+		r.Fail(fmt.Errorf("invalid save state: PPU CGB mode %t, expected %t", cgbMode, p.cgbMode))
+	}
+
+	r.Bytes(p.vram[0])
+	r.Bytes(p.vram[1])
+	r.Bytes(p.oam)
+	r.Bytes(p.bgPaletteRAM)
+	r.Bytes(p.objPaletteRAM)
+
+	for _, register := range p.stateRegisters() {
+		*register = r.Uint8()
+	}
+	p.vramBank &= 0x01
+
+	p.dots = r.Int()
+	p.statLine = r.Bool()
+	p.frameReady = r.Bool()
+
+	for i := range p.framebuffer.pixels {
+		p.framebuffer.pixels[i] = r.Uint16()
+	}
+
+	if r.Err() == nil && (p.dots < 0 || p.dots >= DOTS_PER_LINE || p.ly >= LINES_PER_FRAME) {
+		r.Fail(fmt.Errorf("invalid save state: PPU at line %d dot %d", p.ly, p.dots))
+	}
+
+	return r.Err()
+}
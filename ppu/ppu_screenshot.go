@@ -0,0 +1,86 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_screenshot.go - Oct-18-2026 by aldebap
+//
+//	export the PPU framebuffer to PNG images
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+)
+
+// maximum integer scale of a screenshot
+const (
+	SCREENSHOT_MAX_SCALE = 16
+)
+
+// scale an image by an integer factor (nearest neighbor)
+func ScaleImage(img *image.RGBA, scale int) (*image.RGBA, error) {
+	if scale < 1 || scale > SCREENSHOT_MAX_SCALE {
+		return nil, fmt.Errorf("invalid screenshot scale: %d", scale)
+	}
+	if scale == 1 {
+		return img, nil
+	}
+
+	bounds := img.Bounds()
+	scaled := image.NewRGBA(image.Rect(0, 0, bounds.Dx()*scale, bounds.Dy()*scale))
+
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			pixel := img.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+
+			for i := 0; i < scale; i++ {
+				for j := 0; j < scale; j++ {
+					scaled.SetRGBA(x*scale+j, y*scale+i, pixel)
+				}
+			}
+		}
+	}
+
+	return scaled, nil
+}
+
+// write the framebuffer as a PNG image
+func WriteScreenshot(writer io.Writer, framebuffer *Framebuffer, postProcessor *PostProcessor, scale int) error {
+	var err error
+
+	if framebuffer == nil {
+		return fmt.Errorf("no framebuffer to export")
+	}
+	if postProcessor == nil {
+		postProcessor, err = NewPostProcessor(COLOR_CORRECTION_NONE, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	img, err := ScaleImage(postProcessor.Process(framebuffer), scale)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(writer, img)
+}
+
+// save the framebuffer into a PNG file
+func SaveScreenshot(fileName string, framebuffer *Framebuffer, postProcessor *PostProcessor, scale int) error {
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = WriteScreenshot(file, framebuffer, postProcessor, scale)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,70 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_screenshot_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for PNG screenshot export
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"path/filepath"
+	"testing"
+)
+
+// screenshot unit tests
+func Test_Screenshot(t *testing.T) {
+
+	t.Run(">>> screenshot: scenario 1 - write a scaled PNG", func(t *testing.T) {
+
+		framebuffer := NewFramebuffer(true)
+		framebuffer.SetPixel(1, 1, 0x001f)
+
+		var buffer bytes.Buffer
+
+		err := WriteScreenshot(&buffer, framebuffer, nil, 3)
+		if err != nil {
+			t.Errorf("fail writing screenshot: %s", err.Error())
+		}
+
+		img, err := png.Decode(&buffer)
+		if err != nil {
+			t.Errorf("fail decoding screenshot: %s", err.Error())
+		}
+
+		if img.Bounds().Dx() != SCREEN_WIDTH*3 || img.Bounds().Dy() != SCREEN_HEIGHT*3 {
+			t.Errorf("failed scaling screenshot: expected: %dx%d\n\tresult: %dx%d",
+				SCREEN_WIDTH*3, SCREEN_HEIGHT*3, img.Bounds().Dx(), img.Bounds().Dy())
+		}
+
+		want := color.RGBAModel.Convert(color.RGBA{R: 0xff, A: 0xff})
+		for _, xy := range [][2]int{{3, 3}, {5, 5}} {
+			got := color.RGBAModel.Convert(img.At(xy[0], xy[1]))
+			if want != got {
+				t.Errorf("failed drawing pixel (%d, %d): expected: %v\n\tresult: %v", xy[0], xy[1], want, got)
+			}
+		}
+	})
+
+	t.Run(">>> screenshot: scenario 2 - save into a file", func(t *testing.T) {
+
+		fileName := filepath.Join(t.TempDir(), "screen.png")
+
+		err := SaveScreenshot(fileName, NewFramebuffer(false), nil, 1)
+		if err != nil {
+			t.Errorf("fail saving screenshot: %s", err.Error())
+		}
+	})
+
+	t.Run(">>> screenshot: scenario 3 - invalid scale", func(t *testing.T) {
+
+		var buffer bytes.Buffer
+
+		err := WriteScreenshot(&buffer, NewFramebuffer(false), nil, 0)
+		if err == nil {
+			t.Errorf("expected error for invalid scale")
+		}
+	})
+}
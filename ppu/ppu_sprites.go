@@ -0,0 +1,154 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_sprites.go - Oct-18-2026 by aldebap
+//
+//	PPU sprites (objects): OAM scan, priorities and pixel mixing
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"sort"
+)
+
+// OAM scan limits
+const (
+	MAX_SPRITES_PER_LINE = 10
+)
+
+// sprite selected by the OAM scan of a line
+type lineSprite struct {
+	index      int
+	x          int
+	y          int
+	tile       uint8
+	attributes uint8
+}
+
+// sprite pixel found for a line position
+type spritePixel struct {
+	colorIndex uint8
+	palette    uint8
+	bgPriority bool
+}
+
+// height of the objects (8 or 16 pixels) selected in LCDC
+func (p *PPU) spriteHeight() int {
+	if p.lcdc&LCDC_OBJ_SIZE != 0 {
+		return 16
+	}
+
+	return 8
+}
+
+// select the first 10 sprites in OAM order which cover a line
+func (p *PPU) scanOAM(line uint8) []lineSprite {
+	var height = p.spriteHeight()
+
+	sprites := make([]lineSprite, 0, MAX_SPRITES_PER_LINE)
+
+	for index := 0; index < OAM_SPRITES && len(sprites) < MAX_SPRITES_PER_LINE; index++ {
+		entry := p.oam[index*OAM_ENTRY_SIZE : (index+1)*OAM_ENTRY_SIZE]
+		y := int(entry[0]) - 16
+
+		//	the X coordinate is not checked: off screen sprites still count for the limit
+		if int(line) < y || int(line) >= y+height {
+			continue
+		}
+
+		sprites = append(sprites, lineSprite{
+			index:      index,
+			x:          int(entry[1]) - 8,
+			y:          y,
+			tile:       entry[2],
+			attributes: entry[3],
+		})
+	}
+
+	return sprites
+}
+
+// order the selected sprites from the highest to the lowest drawing priority:
+// DMG uses the X coordinate and then the OAM index, CGB uses only the OAM index
+func (p *PPU) sortSpritesByPriority(sprites []lineSprite) {
+
+	if p.cgbMode {
+		sort.SliceStable(sprites, func(i, j int) bool {
+			return sprites[i].index < sprites[j].index
+		})
+		return
+	}
+
+	sort.SliceStable(sprites, func(i, j int) bool {
+		if sprites[i].x != sprites[j].x {
+			return sprites[i].x < sprites[j].x
+		}
+		return sprites[i].index < sprites[j].index
+	})
+}
+
+// resolve the sprite pixels of a line: for each position keep the highest priority non transparent pixel
+func (p *PPU) spriteLine(line uint8) [SCREEN_WIDTH]*spritePixel {
+	var pixels [SCREEN_WIDTH]*spritePixel
+	var height = p.spriteHeight()
+
+	if p.lcdc&LCDC_OBJ_ENABLE == 0 {
+		return pixels
+	}
+
+	sprites := p.scanOAM(line)
+	p.sortSpritesByPriority(sprites)
+
+	for i := range sprites {
+		sprite := &sprites[i]
+
+		var bank uint8
+		var palette uint8
+
+		if p.cgbMode {
+			bank = (sprite.attributes & OAM_CGB_BANK) >> 3
+			palette = sprite.attributes & OAM_CGB_PALETTE
+		} else {
+			palette = (sprite.attributes & OAM_DMG_PALETTE) >> 4
+		}
+
+		row := p.objTileRow(sprite.tile, bank, uint8(int(line)-sprite.y), uint8(height),
+			sprite.attributes&OAM_X_FLIP != 0, sprite.attributes&OAM_Y_FLIP != 0)
+
+		for column, colorIndex := range row {
+			x := sprite.x + column
+
+			//	color 0 is transparent and lower priority sprites never overwrite a pixel
+			if x < 0 || x >= SCREEN_WIDTH || colorIndex == 0 || pixels[x] != nil {
+				continue
+			}
+
+			pixels[x] = &spritePixel{
+				colorIndex: colorIndex,
+				palette:    palette,
+				bgPriority: sprite.attributes&OAM_PRIORITY != 0,
+			}
+		}
+	}
+
+	return pixels
+}
+
+// decide if a sprite pixel is drawn over the BG/window pixel
+func (p *PPU) spriteOverBG(sprite *spritePixel, bgColorIndex uint8, bgAttributePriority bool) bool {
+
+	if sprite == nil {
+		return false
+	}
+
+	//	BG color 0 is always behind sprites
+	if bgColorIndex == 0 {
+		return true
+	}
+
+	//	on CGB clearing LCDC bit 0 gives sprites the master priority
+	if p.cgbMode && p.lcdc&LCDC_BG_WINDOW_ENABLE == 0 {
+		return true
+	}
+
+	return !sprite.bgPriority && !bgAttributePriority
+}
@@ -0,0 +1,236 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_sprites_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for PPU sprites rendering rules
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"testing"
+)
+
+// place a sprite in OAM using screen coordinates
+func setSprite(ppu *PPU, index int, x int, y int, tile uint8, attributes uint8) {
+	ppu.oam[index*OAM_ENTRY_SIZE] = uint8(y + 16)
+	ppu.oam[index*OAM_ENTRY_SIZE+1] = uint8(x + 8)
+	ppu.oam[index*OAM_ENTRY_SIZE+2] = tile
+	ppu.oam[index*OAM_ENTRY_SIZE+3] = attributes
+}
+
+// fill all pixels of a tile with the same color index
+func fillTile(ppu *PPU, bank uint8, tile uint8, colorIndex uint8) {
+	for row := uint16(0); row < 8; row++ {
+		ppu.vram[bank][uint16(tile)*TILE_SIZE+row*2] = 0xff * (colorIndex & 0x01)
+		ppu.vram[bank][uint16(tile)*TILE_SIZE+row*2+1] = 0xff * (colorIndex >> 1 & 0x01)
+	}
+}
+
+// create a DMG PPU with identity palettes (shade = color index) and sprites enabled
+func newSpriteTestPPU(cgbMode bool) *PPU {
+	ppu := NewPPU(cgbMode)
+
+	ppu.lcdc = LCDC_LCD_ENABLE | LCDC_BG_WINDOW_TILES | LCDC_OBJ_ENABLE | LCDC_BG_WINDOW_ENABLE
+	ppu.bgp = 0xe4
+	ppu.obp0 = 0xe4
+	ppu.obp1 = 0xe4
+
+	return ppu
+}
+
+// OAM scan unit tests
+func Test_ScanOAM(t *testing.T) {
+
+	t.Run(">>> OAM scan: scenario 1 - only the first 10 sprites of a line", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+
+		//	off screen sprites (X = -8) still count for the limit
+		for index := range 12 {
+			x := index * 8
+			if index == 0 {
+				x = -8
+			}
+			setSprite(ppu, index, x, 4, 0x01, 0x00)
+		}
+
+		sprites := ppu.scanOAM(4)
+		if len(sprites) != MAX_SPRITES_PER_LINE {
+			t.Errorf("failed scanning OAM: expected: %d sprites\n\tresult: %d", MAX_SPRITES_PER_LINE, len(sprites))
+		}
+		if sprites[0].index != 0 || sprites[9].index != 9 {
+			t.Errorf("failed scanning OAM: expected sprites 0-9\n\tresult: %d-%d", sprites[0].index, sprites[9].index)
+		}
+	})
+
+	t.Run(">>> OAM scan: scenario 2 - 8x16 sprites cover 16 lines", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		setSprite(ppu, 0, 0, 0, 0x01, 0x00)
+
+		if len(ppu.scanOAM(12)) != 0 {
+			t.Errorf("failed scanning OAM: 8x8 sprite selected on line 12")
+		}
+
+		ppu.lcdc |= LCDC_OBJ_SIZE
+		if len(ppu.scanOAM(12)) != 1 {
+			t.Errorf("failed scanning OAM: 8x16 sprite not selected on line 12")
+		}
+	})
+}
+
+// sprite priority unit tests
+func Test_SpritePriority(t *testing.T) {
+
+	t.Run(">>> sprite priority: scenario 1 - DMG orders overlapping sprites by X", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x01, 1)
+		fillTile(ppu, 0, 0x02, 2)
+		setSprite(ppu, 0, 12, 0, 0x01, 0x00)
+		setSprite(ppu, 1, 10, 0, 0x02, 0x00)
+
+		ppu.renderScanline(0)
+
+		shade, _ := ppu.framebuffer.DMGPixel(12, 0)
+		if shade != 2 {
+			t.Errorf("failed sprite priority: expected lower X sprite (shade 2)\n\tresult: %d", shade)
+		}
+	})
+
+	t.Run(">>> sprite priority: scenario 2 - DMG uses OAM index for equal X", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x01, 1)
+		fillTile(ppu, 0, 0x02, 2)
+		setSprite(ppu, 0, 10, 0, 0x01, 0x00)
+		setSprite(ppu, 1, 10, 0, 0x02, 0x00)
+
+		ppu.renderScanline(0)
+
+		shade, _ := ppu.framebuffer.DMGPixel(10, 0)
+		if shade != 1 {
+			t.Errorf("failed sprite priority: expected first OAM sprite (shade 1)\n\tresult: %d", shade)
+		}
+	})
+
+	t.Run(">>> sprite priority: scenario 3 - CGB uses only the OAM index", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(true)
+		fillTile(ppu, 0, 0x01, 1)
+		fillTile(ppu, 0, 0x02, 2)
+		setSprite(ppu, 0, 12, 0, 0x01, 0x00)
+		setSprite(ppu, 1, 10, 0, 0x02, 0x00)
+		ppu.objPaletteRAM[2] = 0x11
+		ppu.objPaletteRAM[4] = 0x22
+
+		ppu.renderScanline(0)
+
+		got := ppu.framebuffer.Pixel(12, 0)
+		if got != 0x0011 {
+			t.Errorf("failed sprite priority: expected first OAM sprite (color 0x0011)\n\tresult: 0x%04x", got)
+		}
+	})
+
+	t.Run(">>> sprite priority: scenario 4 - transparent pixels show lower priority sprites", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x02, 2)
+		setSprite(ppu, 0, 10, 0, 0x00, 0x00)
+		setSprite(ppu, 1, 10, 0, 0x02, 0x00)
+
+		ppu.renderScanline(0)
+
+		shade, _ := ppu.framebuffer.DMGPixel(10, 0)
+		if shade != 2 {
+			t.Errorf("failed sprite transparency: expected shade 2\n\tresult: %d", shade)
+		}
+	})
+}
+
+// sprite tiles unit tests
+func Test_SpriteTiles(t *testing.T) {
+
+	t.Run(">>> sprite tiles: scenario 1 - 8x16 mode ignores tile bit 0", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		ppu.lcdc |= LCDC_OBJ_SIZE
+		fillTile(ppu, 0, 0x02, 1)
+		fillTile(ppu, 0, 0x03, 3)
+		setSprite(ppu, 0, 0, 0, 0x03, 0x00)
+
+		ppu.renderScanline(0)
+		ppu.renderScanline(8)
+
+		top, _ := ppu.framebuffer.DMGPixel(0, 0)
+		bottom, _ := ppu.framebuffer.DMGPixel(0, 8)
+		if top != 1 || bottom != 3 {
+			t.Errorf("failed 8x16 sprite: expected top 1 and bottom 3\n\tresult: %d and %d", top, bottom)
+		}
+	})
+
+	t.Run(">>> sprite tiles: scenario 2 - X and Y flips", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+
+		//	tile 1: only the top left pixel is set
+		ppu.vram[0][TILE_SIZE] = 0x80
+		setSprite(ppu, 0, 0, 0, 0x01, OAM_X_FLIP|OAM_Y_FLIP|OAM_DMG_PALETTE)
+
+		ppu.renderScanline(7)
+
+		shade, palette := ppu.framebuffer.DMGPixel(7, 7)
+		if shade != 1 || palette != DMG_PALETTE_OBJ1 {
+			t.Errorf("failed flipping sprite: expected shade 1 from OBJ1\n\tresult: %d from %d", shade, palette)
+		}
+	})
+}
+
+// OBJ to BG priority unit tests
+func Test_SpriteBGPriority(t *testing.T) {
+
+	t.Run(">>> sprite BG priority: scenario 1 - BG colors 1-3 cover a background priority sprite", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(false)
+		fillTile(ppu, 0, 0x01, 3)
+		fillTile(ppu, 0, 0x02, 2)
+
+		//	BG tile 2 on the first map column, BG tile 0 (color 0) on the others
+		ppu.vram[0][TILE_MAP_0_OFFSET] = 0x02
+		setSprite(ppu, 0, 4, 0, 0x01, OAM_PRIORITY)
+
+		ppu.renderScanline(0)
+
+		shade, palette := ppu.framebuffer.DMGPixel(4, 0)
+		if shade != 2 || palette != DMG_PALETTE_BG {
+			t.Errorf("failed BG priority: expected BG shade 2\n\tresult: %d from %d", shade, palette)
+		}
+
+		shade, palette = ppu.framebuffer.DMGPixel(8, 0)
+		if shade != 3 || palette != DMG_PALETTE_OBJ0 {
+			t.Errorf("failed BG priority over color 0: expected OBJ shade 3\n\tresult: %d from %d", shade, palette)
+		}
+	})
+
+	t.Run(">>> sprite BG priority: scenario 2 - CGB LCDC bit 0 gives sprites master priority", func(t *testing.T) {
+
+		ppu := newSpriteTestPPU(true)
+		fillTile(ppu, 0, 0x01, 3)
+		fillTile(ppu, 0, 0x02, 2)
+		ppu.vram[0][TILE_MAP_0_OFFSET] = 0x02
+		ppu.vram[1][TILE_MAP_0_OFFSET] = ATTR_PRIORITY
+		ppu.objPaletteRAM[6] = 0x33
+		setSprite(ppu, 0, 0, 0, 0x01, 0x00)
+
+		ppu.renderScanline(0)
+		if ppu.framebuffer.Pixel(0, 0) == 0x0033 {
+			t.Errorf("failed BG attribute priority: sprite drawn over BG")
+		}
+
+		ppu.lcdc &^= LCDC_BG_WINDOW_ENABLE
+		ppu.renderScanline(0)
+		if ppu.framebuffer.Pixel(0, 0) != 0x0033 {
+			t.Errorf("failed master priority: expected sprite color 0x0033\n\tresult: 0x%04x", ppu.framebuffer.Pixel(0, 0))
+		}
+	})
+}
@@ -0,0 +1,84 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy PPU
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"testing"
+)
+
+// PPU memory mapped registers unit tests
+func Test_PPURegisters(t *testing.T) {
+
+	t.Run(">>> PPU registers: scenario 1 - VRAM bank switching on CGB", func(t *testing.T) {
+
+		ppu := NewPPU(true)
+		vram := ppu.VRAM()
+		registers := ppu.Registers()
+
+		vram.WriteByte(0x0010, 0x11)
+		registers.WriteByte(REG_VBK, 0x01)
+		vram.WriteByte(0x0010, 0x22)
+
+		got, _ := vram.ReadByte(0x0010)
+		if got != 0x22 {
+			t.Errorf("failed reading VRAM bank 1: expected: 0x22\n\tresult: 0x%02x", got)
+		}
+
+		registers.WriteByte(REG_VBK, 0x00)
+		got, _ = vram.ReadByte(0x0010)
+		if got != 0x11 {
+			t.Errorf("failed reading VRAM bank 0: expected: 0x11\n\tresult: 0x%02x", got)
+		}
+
+		got, _ = registers.ReadByte(REG_VBK)
+		if got != 0xfe {
+			t.Errorf("failed reading VBK: expected: 0xfe\n\tresult: 0x%02x", got)
+		}
+	})
+
+	t.Run(">>> PPU registers: scenario 2 - STAT read only bits", func(t *testing.T) {
+
+		ppu := NewPPU(false)
+		ppu.stat = 0x03
+
+		ppu.Registers().WriteByte(REG_STAT, 0xff)
+
+		got, _ := ppu.Registers().ReadByte(REG_STAT)
+		if got != 0xfb {
+			t.Errorf("failed writing STAT: expected: 0xfb\n\tresult: 0x%02x", got)
+		}
+	})
+
+	t.Run(">>> PPU registers: scenario 3 - CGB palette auto increment", func(t *testing.T) {
+
+		ppu := NewPPU(true)
+		palettes := ppu.PaletteRegisters()
+
+		palettes.WriteByte(REG_BCPS, CGB_PALETTE_AUTO_INCREMENT|0x0a)
+		palettes.WriteByte(REG_BCPD, 0x1f)
+		palettes.WriteByte(REG_BCPD, 0x7c)
+
+		want := uint16(0x7c1f)
+		got := ppu.bgPaletteColor(1, 1)
+		if want != got {
+			t.Errorf("failed writing BG palette: expected: 0x%04x\n\tresult: 0x%04x", want, got)
+		}
+
+		bcps, _ := palettes.ReadByte(REG_BCPS)
+		if bcps != 0xcc {
+			t.Errorf("failed incrementing BCPS: expected: 0xcc\n\tresult: 0x%02x", bcps)
+		}
+	})
+
+	t.Run(">>> PPU registers: scenario 4 - address out of bounds", func(t *testing.T) {
+
+		err := NewPPU(false).OAM().WriteByte(OAM_SIZE, 0x00)
+		if err == nil {
+			t.Errorf("expected error writing beyond OAM")
+		}
+	})
+}
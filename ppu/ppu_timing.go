@@ -0,0 +1,159 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_timing.go - Oct-18-2026 by aldebap
+//
+//	PPU timing: modes, LY, STAT and the LCD interrupts
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// PPU timing in dots (T-cycles)
+const (
+	DOTS_PER_LINE     = 456
+	OAM_SCAN_DOTS     = 80
+	DRAWING_DOTS      = 172
+	LINES_PER_FRAME   = 154
+	FRAME_CYCLES      = DOTS_PER_LINE * LINES_PER_FRAME
+	VBLANK_FIRST_LINE = SCREEN_HEIGHT
+)
+
+// PPU modes (STAT bits 0-1)
+const (
+	PPU_MODE_HBLANK   = uint8(0)
+	PPU_MODE_VBLANK   = uint8(1)
+	PPU_MODE_OAM_SCAN = uint8(2)
+	PPU_MODE_DRAWING  = uint8(3)
+)
+
+// STAT flags
+const (
+	STAT_MODE             = uint8(0x03)
+	STAT_LYC_EQUAL        = uint8(0x04)
+	STAT_HBLANK_INTERRUPT = uint8(0x08)
+	STAT_VBLANK_INTERRUPT = uint8(0x10)
+	STAT_OAM_INTERRUPT    = uint8(0x20)
+	STAT_LYC_INTERRUPT    = uint8(0x40)
+)
+
+// connect the interrupt requester
+func (p *PPU) ConnectInterrupt(requestInterrupt cpu.InterruptRequester) {
+	p.requestInterrupt = requestInterrupt
+}
+
+// return true once after every frame completed (the start of VBlank)
+func (p *PPU) FrameReady() bool {
+	var ready = p.frameReady
+
+	p.frameReady = false
+
+	return ready
+}
+
+// return true if the LCD is on
+func (p *PPU) LCDEnabled() bool {
+	return p.lcdc&LCDC_LCD_ENABLE != 0
+}
+
+// write LCDC: turning the LCD off resets LY and the mode, turning it on restarts the frame
+func (p *PPU) writeLCDC(value uint8) {
+	var wasEnabled = p.LCDEnabled()
+
+	p.lcdc = value
+
+	switch {
+	case wasEnabled && !p.LCDEnabled():
+		p.ly = 0
+		p.dots = 0
+		p.setMode(PPU_MODE_HBLANK)
+		p.statLine = false
+
+	case !wasEnabled && p.LCDEnabled():
+		p.ly = 0
+		p.dots = 0
+		p.setMode(PPU_MODE_OAM_SCAN)
+		p.updateStat()
+	}
+}
+
+// change the PPU mode
+func (p *PPU) setMode(mode uint8) {
+	p.stat = p.stat&^STAT_MODE | mode
+}
+
+// return the PPU mode
+func (p *PPU) mode() uint8 {
+	return p.stat & STAT_MODE
+}
+
+// update the LYC flag and request the STAT interrupt on the rising edge of the STAT interrupt line
+func (p *PPU) updateStat() {
+	var line bool
+
+	p.stat &^= STAT_LYC_EQUAL
+	if p.ly == p.lyc {
+		p.stat |= STAT_LYC_EQUAL
+		line = p.stat&STAT_LYC_INTERRUPT != 0
+	}
+
+	switch p.mode() {
+	case PPU_MODE_HBLANK:
+		line = line || p.stat&STAT_HBLANK_INTERRUPT != 0
+	case PPU_MODE_VBLANK:
+		line = line || p.stat&STAT_VBLANK_INTERRUPT != 0
+	case PPU_MODE_OAM_SCAN:
+		line = line || p.stat&STAT_OAM_INTERRUPT != 0
+	}
+
+	if line && !p.statLine && p.requestInterrupt != nil {
+		p.requestInterrupt(cpu.INTERRUPT_LCD_STAT)
+	}
+	p.statLine = line
+}
+
+// run the PPU for a number of T-cycles: a line is rendered at the end of the drawing mode
+func (p *PPU) Step(cycles int) {
+	if !p.LCDEnabled() {
+		return
+	}
+
+	for range cycles {
+		p.dots++
+
+		if p.ly < VBLANK_FIRST_LINE {
+			switch p.dots {
+			case OAM_SCAN_DOTS:
+				p.setMode(PPU_MODE_DRAWING)
+
+			case OAM_SCAN_DOTS + DRAWING_DOTS:
+				p.setMode(PPU_MODE_HBLANK)
+				p.renderScanline(p.ly)
+			}
+		}
+
+		if p.dots == DOTS_PER_LINE {
+			p.dots = 0
+			p.ly++
+
+			switch {
+			case p.ly == VBLANK_FIRST_LINE:
+				p.setMode(PPU_MODE_VBLANK)
+				p.frameReady = true
+				if p.requestInterrupt != nil {
+					p.requestInterrupt(cpu.INTERRUPT_VBLANK)
+				}
+
+			case p.ly == LINES_PER_FRAME:
+				p.ly = 0
+				p.setMode(PPU_MODE_OAM_SCAN)
+
+			case p.ly < VBLANK_FIRST_LINE:
+				p.setMode(PPU_MODE_OAM_SCAN)
+			}
+		}
+
+		p.updateStat()
+	}
+}
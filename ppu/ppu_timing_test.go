@@ -0,0 +1,114 @@
+////////////////////////////////////////////////////////////////////////////////
+//	ppu_timing_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the PPU timing and LCD interrupts
+////////////////////////////////////////////////////////////////////////////////
+
+package ppu
+
+import (
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// PPU timing unit tests
+func Test_PPUTiming(t *testing.T) {
+
+	t.Run(">>> ppu timing: scenario 1 - modes of a visible line", func(t *testing.T) {
+
+		ppu := NewPPU(false)
+		ppu.Registers().WriteByte(REG_LCDC, 0x00)
+		ppu.Registers().WriteByte(REG_LCDC, 0x91)
+
+		for _, test := range []struct {
+			cycles   int
+			expected uint8
+		}{
+			{cycles: OAM_SCAN_DOTS - 1, expected: PPU_MODE_OAM_SCAN},
+			{cycles: 1, expected: PPU_MODE_DRAWING},
+			{cycles: DRAWING_DOTS, expected: PPU_MODE_HBLANK},
+			{cycles: DOTS_PER_LINE - OAM_SCAN_DOTS - DRAWING_DOTS, expected: PPU_MODE_OAM_SCAN},
+		} {
+			ppu.Step(test.cycles)
+			if ppu.mode() != test.expected {
+				t.Errorf("failed PPU mode after %d dots: expected: %d\n\tresult: %d", ppu.dots, test.expected, ppu.mode())
+			}
+		}
+
+		ly, _ := ppu.Registers().ReadByte(REG_LY)
+		if ly != 1 {
+			t.Errorf("failed LY progression: expected: 1\n\tresult: %d", ly)
+		}
+	})
+
+	t.Run(">>> ppu timing: scenario 2 - VBlank and frame completion", func(t *testing.T) {
+
+		var vblank int
+
+		ppu := NewPPU(false)
+		ppu.ConnectInterrupt(func(interrupt uint8) {
+			if interrupt == cpu.INTERRUPT_VBLANK {
+				vblank++
+			}
+		})
+
+		ppu.Step(VBLANK_FIRST_LINE*DOTS_PER_LINE - 1)
+		if ppu.FrameReady() || vblank != 0 {
+			t.Errorf("failed VBlank timing: frame completed too early")
+		}
+
+		ppu.Step(1)
+		if !ppu.FrameReady() || vblank != 1 || ppu.mode() != PPU_MODE_VBLANK {
+			t.Errorf("failed VBlank timing: expected: a frame and one interrupt\n\tresult: %d interrupts and mode %d", vblank, ppu.mode())
+		}
+		if ppu.FrameReady() {
+			t.Errorf("failed consuming frame ready: expected: false\n\tresult: true")
+		}
+
+		ppu.Step(FRAME_CYCLES - VBLANK_FIRST_LINE*DOTS_PER_LINE)
+		ly, _ := ppu.Registers().ReadByte(REG_LY)
+		if ly != 0 || vblank != 1 {
+			t.Errorf("failed wrapping LY: expected: LY 0 and one interrupt\n\tresult: LY %d and %d", ly, vblank)
+		}
+	})
+
+	t.Run(">>> ppu timing: scenario 3 - LYC STAT interrupt", func(t *testing.T) {
+
+		var requests int
+
+		ppu := NewPPU(false)
+		ppu.ConnectInterrupt(func(interrupt uint8) {
+			if interrupt == cpu.INTERRUPT_LCD_STAT {
+				requests++
+			}
+		})
+
+		ppu.Registers().WriteByte(REG_LYC, 10)
+		ppu.Registers().WriteByte(REG_STAT, STAT_LYC_INTERRUPT)
+
+		ppu.Step(10*DOTS_PER_LINE - 1)
+		if requests != 0 {
+			t.Errorf("failed LYC interrupt timing: requested too early")
+		}
+
+		ppu.Step(DOTS_PER_LINE)
+		stat, _ := ppu.Registers().ReadByte(REG_STAT)
+		if requests != 1 || stat&STAT_LYC_EQUAL == 0 {
+			t.Errorf("failed LYC interrupt: expected: one interrupt and LYC flag\n\tresult: %d and STAT 0x%02x", requests, stat)
+		}
+	})
+
+	t.Run(">>> ppu timing: scenario 4 - LCD off stops the PPU", func(t *testing.T) {
+
+		ppu := NewPPU(false)
+		ppu.Step(3 * DOTS_PER_LINE)
+		ppu.Registers().WriteByte(REG_LCDC, 0x11)
+
+		ppu.Step(FRAME_CYCLES)
+		ly, _ := ppu.Registers().ReadByte(REG_LY)
+		if ly != 0 || ppu.FrameReady() || ppu.mode() != PPU_MODE_HBLANK {
+			t.Errorf("failed turning the LCD off: expected: LY 0, no frame and HBlank\n\tresult: LY %d and mode %d", ly, ppu.mode())
+		}
+	})
+}
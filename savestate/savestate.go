@@ -0,0 +1,201 @@
+////////////////////////////////////////////////////////////////////////////////
+//	savestate.go - Oct-19-2026 by aldebap
+//
+//	binary encoding of the emulator state
+////////////////////////////////////////////////////////////////////////////////
+
+package savestate
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// every component state starts with a 4 characters section tag
+const (
+	SECTION_TAG_SIZE = 4
+)
+
+// sequential little endian encoder of the components state
+type Writer struct {
+	data []uint8
+}
+
+// create a new state writer
+func NewWriter() *Writer {
+	return &Writer{}
+}
+
+// start the state of a component
+func (w *Writer) Section(tag string) {
+	w.data = append(w.data, fmt.Sprintf("%-*.*s", SECTION_TAG_SIZE, SECTION_TAG_SIZE, tag)...)
+}
+
+// write a byte
+func (w *Writer) Uint8(value uint8) {
+	w.data = append(w.data, value)
+}
+
+// write a boolean as a byte
+func (w *Writer) Bool(value bool) {
+	if value {
+		w.data = append(w.data, 1)
+	} else {
+		w.data = append(w.data, 0)
+	}
+}
+
+// write a 16 bit value
+func (w *Writer) Uint16(value uint16) {
+	w.data = binary.LittleEndian.AppendUint16(w.data, value)
+}
+
+// write a 32 bit value
+func (w *Writer) Uint32(value uint32) {
+	w.data = binary.LittleEndian.AppendUint32(w.data, value)
+}
+
+// write a 64 bit value
+func (w *Writer) Uint64(value uint64) {
+	w.data = binary.LittleEndian.AppendUint64(w.data, value)
+}
+
+// write an int as a 64 bit value
+func (w *Writer) Int(value int) {
+	w.Uint64(uint64(int64(value)))
+}
+
+// write a block of bytes preceded by its length
+func (w *Writer) Bytes(value []uint8) {
+	w.Uint32(uint32(len(value)))
+	w.data = append(w.data, value...)
+}
+
+// return the encoded state
+func (w *Writer) Data() []uint8 {
+	return w.data
+}
+
+// sequential decoder of the components state: the first error is kept and the following reads return zero
+type Reader struct {
+	data    []uint8
+	offset  int
+	version uint16
+	err     error
+}
+
+// create a new state reader for a state written by a format version
+func NewReader(data []uint8, version uint16) *Reader {
+	return &Reader{data: data, version: version}
+}
+
+// return the format version of the state
+func (r *Reader) Version() uint16 {
+	return r.version
+}
+
+// return the first error found
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// set the error (unless an earlier one was found)
+func (r *Reader) Fail(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// return the number of bytes not read yet
+func (r *Reader) Remaining() int {
+	return len(r.data) - r.offset
+}
+
+// read the next bytes
+func (r *Reader) next(length int) []uint8 {
+
+	if r.err != nil {
+		return nil
+	}
+	if length < 0 || length > len(r.data)-r.offset {
+		r.err = fmt.Errorf("truncated save state at offset %d", r.offset)
+		return nil
+	}
+
+	value := r.data[r.offset : r.offset+length]
+	r.offset += length
+
+	return value
+}
+
+// check the start of the state of a component
+func (r *Reader) Section(tag string) {
+	var expected = fmt.Sprintf("%-*.*s", SECTION_TAG_SIZE, SECTION_TAG_SIZE, tag)
+
+	found := r.next(SECTION_TAG_SIZE)
+	if found != nil && string(found) != expected {
+		r.Fail(fmt.Errorf("invalid save state: expected section %q, found %q", expected, found))
+	}
+}
+
+// read a byte
+func (r *Reader) Uint8() uint8 {
+	value := r.next(1)
+	if value == nil {
+		return 0
+	}
+
+	return value[0]
+}
+
+// read a boolean
+func (r *Reader) Bool() bool {
+	return r.Uint8() != 0
+}
+
+// read a 16 bit value
+func (r *Reader) Uint16() uint16 {
+	value := r.next(2)
+	if value == nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint16(value)
+}
+
+// read a 32 bit value
+func (r *Reader) Uint32() uint32 {
+	value := r.next(4)
+	if value == nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint32(value)
+}
+
+// read a 64 bit value
+func (r *Reader) Uint64() uint64 {
+	value := r.next(8)
+	if value == nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(value)
+}
+
+// read an int written as a 64 bit value
+func (r *Reader) Int() int {
+	return int(int64(r.Uint64()))
+}
+
+// read a block of bytes into a slice of the same length
+func (r *Reader) Bytes(destination []uint8) {
+
+	length := r.Uint32()
+	if r.err == nil && int(length) != len(destination) {
+		r.Fail(fmt.Errorf("invalid save state: expected %d bytes at offset %d, found %d", len(destination), r.offset, length))
+		return
+	}
+
+	copy(destination, r.next(int(length)))
+}
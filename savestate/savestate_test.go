@@ -0,0 +1,73 @@
+////////////////////////////////////////////////////////////////////////////////
+//	savestate_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the save state encoding
+////////////////////////////////////////////////////////////////////////////////
+
+package savestate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// save state encoding unit tests
+func Test_SaveState(t *testing.T) {
+
+	t.Run(">>> save state: scenario 1 - write and read back", func(t *testing.T) {
+
+		w := NewWriter()
+		w.Section("CPU")
+		w.Uint8(0x12)
+		w.Bool(true)
+		w.Uint16(0x3456)
+		w.Uint32(0x789abcde)
+		w.Uint64(0x0123456789abcdef)
+		w.Int(-2)
+		w.Bytes([]uint8{1, 2, 3})
+
+		var block = make([]uint8, 3)
+
+		r := NewReader(w.Data(), 1)
+		r.Section("CPU")
+		result := []any{r.Uint8(), r.Bool(), r.Uint16(), r.Uint32(), r.Uint64(), r.Int()}
+		r.Bytes(block)
+
+		expected := []any{uint8(0x12), true, uint16(0x3456), uint32(0x789abcde), uint64(0x0123456789abcdef), -2}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("failed reading value %d: expected: %v\n\tresult: %v", i, expected[i], result[i])
+			}
+		}
+		if !bytes.Equal(block, []uint8{1, 2, 3}) || r.Err() != nil || r.Remaining() != 0 {
+			t.Errorf("failed reading bytes: expected: [1 2 3]\n\tresult: %v (%v, %d remaining)", block, r.Err(), r.Remaining())
+		}
+	})
+
+	t.Run(">>> save state: scenario 2 - the first error is kept", func(t *testing.T) {
+
+		w := NewWriter()
+		w.Section("PPU")
+		w.Bytes([]uint8{1, 2})
+
+		for _, test := range []struct {
+			read     func(r *Reader)
+			expected string
+		}{
+			{read: func(r *Reader) { r.Section("APU"); r.Uint8() }, expected: `expected section "APU "`},
+			{read: func(r *Reader) { r.Section("PPU"); r.Bytes(make([]uint8, 4)) }, expected: "expected 4 bytes"},
+			{read: func(r *Reader) { r.Section("PPU"); r.Uint64(); r.Bytes(make([]uint8, 2)) }, expected: "truncated save state at offset 4"},
+		} {
+			r := NewReader(w.Data(), 1)
+			test.read(r)
+
+			if r.Err() == nil || !strings.Contains(r.Err().Error(), test.expected) {
+				t.Errorf("failed reporting error: expected: %s\n\tresult: %v", test.expected, r.Err())
+			}
+			if r.Uint16() != 0 {
+				t.Errorf("failed reading after an error: expected: 0")
+			}
+		}
+	})
+}
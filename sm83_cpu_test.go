@@ -1,11 +0,0 @@
-////////////////////////////////////////////////////////////////////////////////
-//	sm83_cpu_test_instructions_0x0i.go - Apr-24-2025 by aldebap
-//
-//	Test cases for Sharp SM83 CPU - instructions 0x00 - 0x0f
-////////////////////////////////////////////////////////////////////////////////
-
-package main
-
-const (
-	trace bool = true
-)
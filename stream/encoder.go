@@ -0,0 +1,141 @@
+////////////////////////////////////////////////////////////////////////////////
+//	encoder.go - Oct-19-2026 by aldebap
+//
+//	encode the frames and audio sent to the streaming clients
+////////////////////////////////////////////////////////////////////////////////
+
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+)
+
+// binary message types (first byte of every binary WebSocket message)
+const (
+	MESSAGE_FRAME_RGBA  = uint8(0x01)
+	MESSAGE_FRAME_DELTA = uint8(0x02)
+	MESSAGE_FRAME_PNG   = uint8(0x03)
+	MESSAGE_AUDIO       = uint8(0x04)
+)
+
+// frame formats requested by the clients
+const (
+	FRAME_FORMAT_RGBA = "rgba"
+	FRAME_FORMAT_PNG  = "png"
+)
+
+// delta runs: unchanged gaps up to this many pixels are sent as part of the run,
+// since a run header (offset and length) costs as much as one pixel
+const (
+	DELTA_MAX_GAP     = 1
+	DELTA_HEADER_SIZE = 4
+)
+
+// encode the frames for a client: the rgba format sends the first frame in full and then only
+// the runs of changed pixels, the png format sends every changed frame as a PNG image
+type frameEncoder struct {
+	format   string
+	previous []uint8
+	png      png.Encoder
+}
+
+// create a new frame encoder
+func newFrameEncoder(format string) (*frameEncoder, error) {
+
+	switch format {
+	case "", FRAME_FORMAT_RGBA:
+		format = FRAME_FORMAT_RGBA
+	case FRAME_FORMAT_PNG:
+	default:
+		return nil, fmt.Errorf("invalid frame format: %s", format)
+	}
+
+	return &frameEncoder{
+		format: format,
+		png:    png.Encoder{CompressionLevel: png.BestSpeed},
+	}, nil
+}
+
+// encode a frame (RGBA pixels of a ppu.SCREEN_WIDTH x ppu.SCREEN_HEIGHT image), returning nil when it didn't change
+func (e *frameEncoder) Encode(pixels []uint8, width int, height int) ([]byte, error) {
+
+	if e.previous != nil && bytes.Equal(pixels, e.previous) {
+		return nil, nil
+	}
+
+	var message []byte
+
+	switch {
+	case e.format == FRAME_FORMAT_PNG:
+		var buffer bytes.Buffer
+
+		img := &image.RGBA{Pix: pixels, Stride: 4 * width, Rect: image.Rect(0, 0, width, height)}
+		buffer.WriteByte(MESSAGE_FRAME_PNG)
+		err := e.png.Encode(&buffer, img)
+		if err != nil {
+			return nil, err
+		}
+		message = buffer.Bytes()
+
+	case e.previous == nil:
+		message = append([]byte{MESSAGE_FRAME_RGBA}, pixels...)
+
+	default:
+		message = encodeDelta(e.previous, pixels)
+	}
+
+	e.previous = append(e.previous[:0], pixels...)
+
+	return message, nil
+}
+
+// encode the runs of changed pixels: each run is the offset of its first pixel and its length
+// in pixels (16 bit little endian) followed by the run RGBA pixels
+func encodeDelta(previous []uint8, pixels []uint8) []byte {
+	var message = []byte{MESSAGE_FRAME_DELTA}
+
+	count := len(pixels) / 4
+	changed := func(pixel int) bool {
+		return !bytes.Equal(previous[4*pixel:4*pixel+4], pixels[4*pixel:4*pixel+4])
+	}
+
+	for pixel := 0; pixel < count; {
+		if !changed(pixel) {
+			pixel++
+			continue
+		}
+
+		//	extend the run while the next changed pixel is close enough
+		start, end := pixel, pixel+1
+		for next := end; next < count && next <= end+DELTA_MAX_GAP; next++ {
+			if changed(next) {
+				end = next + 1
+			}
+		}
+
+		message = binary.LittleEndian.AppendUint16(message, uint16(start))
+		message = binary.LittleEndian.AppendUint16(message, uint16(end-start))
+		message = append(message, pixels[4*start:4*end]...)
+
+		pixel = end
+	}
+
+	return message
+}
+
+// encode interleaved stereo 16 bit samples: the sample rate (32 bit little endian) followed by the samples
+func encodeAudio(samples []int16, sampleRate int) []byte {
+	var message = make([]byte, 0, 5+2*len(samples))
+
+	message = append(message, MESSAGE_AUDIO)
+	message = binary.LittleEndian.AppendUint32(message, uint32(sampleRate))
+	for _, sample := range samples {
+		message = binary.LittleEndian.AppendUint16(message, uint16(sample))
+	}
+
+	return message
+}
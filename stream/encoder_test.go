@@ -0,0 +1,95 @@
+////////////////////////////////////////////////////////////////////////////////
+//	encoder_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the streaming frame and audio encoding
+////////////////////////////////////////////////////////////////////////////////
+
+package stream
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+	"testing"
+)
+
+// frame encoder unit tests
+func Test_FrameEncoder(t *testing.T) {
+
+	t.Run(">>> frame encoder: scenario 1 - full frame, unchanged frame and delta runs", func(t *testing.T) {
+
+		encoder, err := newFrameEncoder(FRAME_FORMAT_RGBA)
+		if err != nil {
+			t.Fatalf("failed creating encoder: %v", err)
+		}
+
+		pixels := make([]uint8, 4*8*2)
+		message, _ := encoder.Encode(pixels, 8, 2)
+		if len(message) != 1+len(pixels) || message[0] != MESSAGE_FRAME_RGBA {
+			t.Errorf("failed encoding the first frame: expected: %d bytes full frame\n\tresult: %d bytes type %d", 1+len(pixels), len(message), message[0])
+		}
+
+		message, _ = encoder.Encode(pixels, 8, 2)
+		if message != nil {
+			t.Errorf("failed skipping an unchanged frame: expected: nil\n\tresult: %v", message)
+		}
+
+		//	pixels 2 and 4 are merged into a single run (the gap is one pixel), pixel 12 starts another run
+		pixels = append([]uint8(nil), pixels...)
+		pixels[4*2] = 0x11
+		pixels[4*4+1] = 0x22
+		pixels[4*12+2] = 0x33
+
+		message, _ = encoder.Encode(pixels, 8, 2)
+
+		expected := []uint8{MESSAGE_FRAME_DELTA}
+		expected = binary.LittleEndian.AppendUint16(expected, 2)
+		expected = binary.LittleEndian.AppendUint16(expected, 3)
+		expected = append(expected, pixels[4*2:4*5]...)
+		expected = binary.LittleEndian.AppendUint16(expected, 12)
+		expected = binary.LittleEndian.AppendUint16(expected, 1)
+		expected = append(expected, pixels[4*12:4*13]...)
+
+		if !bytes.Equal(message, expected) {
+			t.Errorf("failed encoding delta: expected: %v\n\tresult: %v", expected, message)
+		}
+	})
+
+	t.Run(">>> frame encoder: scenario 2 - PNG frames", func(t *testing.T) {
+
+		encoder, _ := newFrameEncoder(FRAME_FORMAT_PNG)
+
+		pixels := make([]uint8, 4*8*2)
+		pixels[4*3] = 0xff
+		pixels[4*3+3] = 0xff
+
+		message, err := encoder.Encode(pixels, 8, 2)
+		if err != nil || message[0] != MESSAGE_FRAME_PNG {
+			t.Fatalf("failed encoding PNG frame: %v", err)
+		}
+
+		img, err := png.Decode(bytes.NewReader(message[1:]))
+		if err != nil {
+			t.Fatalf("failed decoding PNG frame: %v", err)
+		}
+
+		r, _, _, _ := img.At(3, 0).RGBA()
+		if img.Bounds().Dx() != 8 || img.Bounds().Dy() != 2 || r != 0xffff {
+			t.Errorf("failed encoding PNG frame: expected: 8x2 with a red pixel\n\tresult: %v %v", img.Bounds(), img.At(3, 0))
+		}
+	})
+
+	t.Run(">>> frame encoder: scenario 3 - invalid format and audio", func(t *testing.T) {
+
+		_, err := newFrameEncoder("jpeg")
+		if err == nil {
+			t.Errorf("failed rejecting an invalid format")
+		}
+
+		message := encodeAudio([]int16{1, -1}, 48000)
+		expected := []uint8{MESSAGE_AUDIO, 0x80, 0xbb, 0x00, 0x00, 0x01, 0x00, 0xff, 0xff}
+		if !bytes.Equal(message, expected) {
+			t.Errorf("failed encoding audio: expected: %v\n\tresult: %v", expected, message)
+		}
+	})
+}
@@ -0,0 +1,793 @@
+////////////////////////////////////////////////////////////////////////////////
+//	server.go - Oct-19-2026 by aldebap
+//
+//	HTTP server streaming a headless system to browsers over WebSockets
+////////////////////////////////////////////////////////////////////////////////
+
+package stream
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io/fs"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aldebap/go_gbc/apu"
+	"github.com/aldebap/go_gbc/ppu"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// server settings
+const (
+	AUDIO_LATENCY     = 200 * time.Millisecond
+	CLIENT_QUEUE_SIZE = 8
+
+	//	control requests must use this content type, which browsers only send to another origin after
+	//	a CORS preflight the server never allows
+	CONTROL_CONTENT_TYPE = "application/json"
+)
+
+// operations refused while recording a movie, since the movie couldn't replay them
+var ErrRecording = errors.New("not allowed while recording a movie")
+
+// host page
+//
+//go:embed web
+var webFiles embed.FS
+
+// create the system served (called again on every reset)
+type SystemFactory func() (*system.System, error)
+
+// persist a system before it is discarded (e.g. save its battery RAM)
+type SystemSaver func(*system.System) error
+
+// return the file of a save state slot
+type StateFileName func(slot int) string
+
+// create a movie recorder for the current system reading the buttons from an input
+type MovieRecorderFactory func(input system.InputSource) *system.MovieSession
+
+// frame and audio produced by the system, shared read only by all clients
+type update struct {
+	pixels []uint8
+	audio  []byte
+}
+
+// a connected browser and the buttons it holds
+type client struct {
+	socket  *WebSocket
+	encoder *frameEncoder
+	updates chan update
+	buttons uint8
+}
+
+// joypad event sent by the clients
+type buttonEvent struct {
+	Button  string `json:"button"`
+	Pressed bool   `json:"pressed"`
+}
+
+// stream parameters sent to a client when it connects
+type helloMessage struct {
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	SampleRate int    `json:"sampleRate"`
+	Format     string `json:"format"`
+	Title      string `json:"title"`
+}
+
+// emulator status
+type statusMessage struct {
+	Title   string  `json:"title"`
+	CGBMode bool    `json:"cgbMode"`
+	Frame   uint64  `json:"frame"`
+	Paused  bool    `json:"paused"`
+	Speed   float64 `json:"speed"`
+	Clients int     `json:"clients"`
+}
+
+// cheat listed to the clients
+type cheatMessage struct {
+	Index       int    `json:"index"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// streaming server: runs the system, broadcasting every frame and its audio to the WebSocket
+// clients and merging the buttons they hold into the joypad input
+type Server struct {
+	newSystem       SystemFactory
+	saveSystem      SystemSaver
+	colorCorrection uint8
+	palette         *ppu.DMG_palette
+	audioRate       int
+	stateFile       StateFileName
+	handler         http.Handler
+	operationMutex  sync.Mutex
+
+	mutex         sync.Mutex
+	system        *system.System
+	postProcessor *ppu.PostProcessor
+	resampler     *apu.AudioResampler
+	screen        *image.RGBA
+	samples       []int16
+	frame         uint64
+	buttons       uint8
+	clients       map[*client]bool
+	movie         *system.MovieSession
+
+	running   bool
+	cancelRun context.CancelFunc
+	operation func() error
+	done      chan error
+}
+
+// create a new streaming server
+func NewServer(newSystem SystemFactory, saveSystem SystemSaver, colorCorrection uint8, palette *ppu.DMG_palette,
+	audioRate int) (*Server, error) {
+
+	s := &Server{
+		newSystem:       newSystem,
+		saveSystem:      saveSystem,
+		colorCorrection: colorCorrection,
+		palette:         palette,
+		audioRate:       audioRate,
+		screen:          image.NewRGBA(image.Rect(0, 0, ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT)),
+		clients:         make(map[*client]bool),
+	}
+
+	err := s.start()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(page))
+	mux.HandleFunc("GET /ws", s.serveWebSocket)
+	mux.HandleFunc("GET /api/status", s.serveStatus)
+	mux.HandleFunc("GET /api/screenshot", s.serveScreenshot)
+	mux.HandleFunc("POST /api/pause", control(s.servePause))
+	mux.HandleFunc("POST /api/resume", control(s.servePause))
+	mux.HandleFunc("POST /api/step", control(s.serveStep))
+	mux.HandleFunc("POST /api/speed", control(s.serveSpeed))
+	mux.HandleFunc("POST /api/reset", control(s.serveReset))
+	mux.HandleFunc("POST /api/state/{slot}/save", control(s.serveState))
+	mux.HandleFunc("POST /api/state/{slot}/load", control(s.serveState))
+	mux.HandleFunc("GET /api/cheats", s.serveCheats)
+	mux.HandleFunc("POST /api/cheats/{index}/enable", control(s.serveEnableCheat))
+	mux.HandleFunc("POST /api/cheats/{index}/disable", control(s.serveEnableCheat))
+	s.handler = mux
+
+	return s, nil
+}
+
+// create the system and connect it to the server (called with the mutex locked, or before serving)
+func (s *Server) start() error {
+
+	gbc, err := s.newSystem()
+	if err != nil {
+		return err
+	}
+
+	postProcessor, err := gbc.NewPostProcessor(s.colorCorrection, s.palette)
+	if err != nil {
+		return err
+	}
+
+	resampler, err := apu.NewAudioResampler(s.audioRate, AUDIO_LATENCY, gbc.CGBMode())
+	if err != nil {
+		return err
+	}
+
+	gbc.Joypad().ConnectInput(system.InputFunc(s.clientButtons))
+	gbc.APU().ConnectSink(resampler)
+	gbc.ConnectFrameHandler(s.frameDone)
+
+	//	a reset keeps the pause and the speed
+	if s.system != nil {
+		gbc.SetSpeed(s.system.Speed())
+		if s.system.Paused() {
+			gbc.Pause()
+		}
+	}
+
+	s.system = gbc
+	s.postProcessor = postProcessor
+	s.resampler = resampler
+	s.frame = gbc.Frame()
+	s.postProcessor.ProcessInto(s.system.PPU().Framebuffer(), s.screen)
+
+	return nil
+}
+
+// save the current system and replace it with a new one (called with the mutex locked, while not running)
+func (s *Server) restart() error {
+
+	err := s.saveSystem(s.system)
+	if err != nil {
+		return err
+	}
+
+	return s.start()
+}
+
+// return the buttons held by the clients (the joypad is polled by the run loop without the mutex locked)
+func (s *Server) clientButtons(uint64) uint8 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buttons
+}
+
+// record the buttons held by the clients into a movie from the power on: resets, state loads and
+// cheat changes are refused with ErrRecording from then on (called before running the server)
+func (s *Server) RecordMovie(newRecorder MovieRecorderFactory) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running || s.system.Frame() != 0 {
+		return fmt.Errorf("a movie must be recorded from the power on")
+	}
+
+	s.movie = newRecorder(system.InputFunc(s.clientButtons))
+	s.system.Joypad().ConnectInput(s.movie)
+
+	return nil
+}
+
+// return the movie recorded (nil when not recording)
+func (s *Server) Movie() *system.Movie {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.movie == nil {
+		return nil
+	}
+
+	return s.movie.Movie()
+}
+
+// connect the save state slots (without them the state endpoints are not implemented)
+func (s *Server) ConnectStateFiles(stateFile StateFileName) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stateFile = stateFile
+}
+
+// handle the HTTP requests (http.Handler interface)
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// run the system until the context is cancelled, then disconnect the clients and save the system;
+// operations like a reset stop the run loop, which restarts with the resulting system
+func (s *Server) Run(ctx context.Context) error {
+
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+
+		s.mutex.Lock()
+		gbc := s.system
+		s.running = true
+		s.cancelRun = cancel
+		s.mutex.Unlock()
+
+		err := gbc.Run(runCtx)
+		cancel()
+
+		s.mutex.Lock()
+		s.running = false
+		if s.operation != nil {
+			s.done <- s.operation()
+			s.operation = nil
+		}
+		s.mutex.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("frame %d: %w", gbc.Frame(), errors.Join(err, s.stop()))
+		}
+		if ctx.Err() != nil {
+			return s.stop()
+		}
+	}
+}
+
+// broadcast a frame and its audio (frame handler called by the run loop)
+func (s *Server) frameDone(frame uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.frame = frame
+	if s.movie != nil {
+		err := s.movie.EndFrame(s.system.PPU().Framebuffer())
+		if err != nil {
+			return err
+		}
+	}
+	s.postProcessor.ProcessInto(s.system.PPU().Framebuffer(), s.screen)
+
+	next := update{pixels: append([]uint8(nil), s.screen.Pix...)}
+
+	frames := s.resampler.Buffered()
+	if frames > 0 {
+		if cap(s.samples) < 2*frames {
+			s.samples = make([]int16, 2*frames)
+		}
+		s.resampler.ReadSamples(s.samples[:2*frames])
+		next.audio = encodeAudio(s.samples[:2*frames], s.audioRate)
+	}
+
+	s.broadcast(next)
+
+	return nil
+}
+
+// send an update to the clients (called with the mutex locked)
+func (s *Server) broadcast(next update) {
+
+	//	slow clients skip frames (the delta encoding is relative to the last frame they were sent)
+	for c := range s.clients {
+		select {
+		case c.updates <- next:
+		default:
+		}
+	}
+}
+
+// disconnect the clients and save the system
+func (s *Server) stop() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for c := range s.clients {
+		c.socket.Close()
+	}
+
+	return s.saveSystem(s.system)
+}
+
+// return the current system (its run controls can be used from any goroutine)
+func (s *Server) System() *system.System {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.system
+}
+
+// return the number of frames run by the current system
+func (s *Server) Frame() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.frame
+}
+
+// call an operation with the mutex locked while the system is not running
+func (s *Server) whileStopped(operation func() error) error {
+	s.operationMutex.Lock()
+	defer s.operationMutex.Unlock()
+
+	s.mutex.Lock()
+	if !s.running {
+		defer s.mutex.Unlock()
+		return operation()
+	}
+
+	//	the run loop calls the operation once its run stops
+	done := make(chan error, 1)
+	s.operation = operation
+	s.done = done
+	s.cancelRun()
+	s.mutex.Unlock()
+
+	return <-done
+}
+
+// save the current system and replace it with a new one
+func (s *Server) Reset() error {
+	return s.whileStopped(func() error {
+		if s.movie != nil {
+			return ErrRecording
+		}
+
+		return s.restart()
+	})
+}
+
+// enable or disable a cheat of the current system
+func (s *Server) EnableCheat(index int, enabled bool) error {
+	return s.whileStopped(func() error {
+		if s.movie != nil {
+			return ErrRecording
+		}
+
+		return s.system.EnableCheat(index, enabled)
+	})
+}
+
+// save the state of the system into a slot
+func (s *Server) SaveState(slot int) error {
+	return s.whileStopped(func() error {
+		if s.stateFile == nil {
+			return errors.ErrUnsupported
+		}
+
+		return s.system.SaveStateFile(s.stateFile(slot))
+	})
+}
+
+// load the state of the system from a slot, sending its last frame to the clients
+func (s *Server) LoadState(slot int) error {
+	return s.whileStopped(func() error {
+		if s.stateFile == nil {
+			return errors.ErrUnsupported
+		}
+		if s.movie != nil {
+			return ErrRecording
+		}
+
+		err := s.system.LoadStateFile(s.stateFile(slot))
+		if err != nil {
+			return err
+		}
+
+		s.frame = s.system.Frame()
+		s.postProcessor.ProcessInto(s.system.PPU().Framebuffer(), s.screen)
+		s.broadcast(update{pixels: append([]uint8(nil), s.screen.Pix...)})
+
+		return nil
+	})
+}
+
+// recompute the buttons held by all clients (called with the mutex locked)
+func (s *Server) updateButtons() {
+	s.buttons = 0
+
+	for c := range s.clients {
+		s.buttons |= c.buttons
+	}
+}
+
+// apply a joypad event sent by a client
+func (s *Server) buttonEvent(c *client, event *buttonEvent) error {
+
+	button, err := system.ParseButtons(event.Button)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if event.Pressed {
+		c.buttons |= button
+	} else {
+		c.buttons &^= button
+	}
+	s.updateButtons()
+
+	return nil
+}
+
+// connect a client
+func (s *Server) connect(socket *WebSocket, encoder *frameEncoder) *client {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	c := &client{
+		socket:  socket,
+		encoder: encoder,
+		updates: make(chan update, CLIENT_QUEUE_SIZE),
+	}
+
+	//	the current frame is sent right away (a paused system sends no updates)
+	c.updates <- update{pixels: append([]uint8(nil), s.screen.Pix...)}
+	s.clients[c] = true
+
+	return c
+}
+
+// disconnect a client, releasing its buttons
+func (s *Server) disconnect(c *client) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.clients, c)
+	close(c.updates)
+	s.updateButtons()
+}
+
+// send the updates to a client until it is disconnected
+func (s *Server) writeUpdates(c *client) {
+	var err error
+
+	for next := range c.updates {
+		if err != nil {
+			continue
+		}
+
+		var message []byte
+
+		message, err = c.encoder.Encode(next.pixels, ppu.SCREEN_WIDTH, ppu.SCREEN_HEIGHT)
+		if err == nil && message != nil {
+			err = c.socket.WriteMessage(WEBSOCKET_BINARY, message)
+		}
+		if err == nil && next.audio != nil {
+			err = c.socket.WriteMessage(WEBSOCKET_BINARY, next.audio)
+		}
+
+		//	closing the socket ends the reader, which disconnects the client
+		if err != nil {
+			c.socket.Close()
+		}
+	}
+}
+
+// stream the frames and audio to a client, reading its joypad events (GET /ws?format=rgba|png)
+func (s *Server) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+
+	encoder, err := newFrameEncoder(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	socket, err := AcceptWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	defer socket.Close()
+
+	s.mutex.Lock()
+	hello, _ := json.Marshal(&helloMessage{
+		Width:      ppu.SCREEN_WIDTH,
+		Height:     ppu.SCREEN_HEIGHT,
+		SampleRate: s.audioRate,
+		Format:     encoder.format,
+		Title:      s.system.Cartridge().Title(),
+	})
+	s.mutex.Unlock()
+
+	err = socket.WriteMessage(WEBSOCKET_TEXT, hello)
+	if err != nil {
+		return
+	}
+
+	c := s.connect(socket, encoder)
+	defer s.disconnect(c)
+	go s.writeUpdates(c)
+
+	for {
+		opcode, message, err := socket.ReadMessage()
+		if err != nil {
+			return
+		}
+		if opcode != WEBSOCKET_TEXT {
+			continue
+		}
+
+		var event buttonEvent
+
+		err = json.Unmarshal(message, &event)
+		if err == nil {
+			err = s.buttonEvent(c, &event)
+		}
+		if err != nil {
+			socket.WriteMessage(WEBSOCKET_TEXT, []byte(strconv.Quote(err.Error())))
+		}
+	}
+}
+
+// refuse the control requests a page from another origin could send: they must come from the same
+// origin (or from a client sending no Origin) with the control content type
+func control(handler http.HandlerFunc) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		if !sameOrigin(r) {
+			http.Error(w, "cross-origin request refused", http.StatusForbidden)
+			return
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if mediaType != CONTROL_CONTENT_TYPE {
+			http.Error(w, "control requests must be sent as "+CONTROL_CONTENT_TYPE, http.StatusUnsupportedMediaType)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// write a JSON response
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+// report the emulator status (GET /api/status)
+func (s *Server) serveStatus(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	writeJSON(w, &statusMessage{
+		Title:   s.system.Cartridge().Title(),
+		CGBMode: s.system.CGBMode(),
+		Frame:   s.frame,
+		Paused:  s.system.Paused(),
+		Speed:   s.system.Speed(),
+		Clients: len(s.clients),
+	})
+}
+
+// send a PNG screenshot of the last frame (GET /api/screenshot?scale=n)
+func (s *Server) serveScreenshot(w http.ResponseWriter, r *http.Request) {
+	var buffer bytes.Buffer
+	var scale = 1
+
+	if value := r.URL.Query().Get("scale"); value != "" {
+		var err error
+
+		scale, err = strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "invalid scale: "+value, http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	img, err := ppu.ScaleImage(s.screen, scale)
+	if err == nil {
+		err = png.Encode(&buffer, img)
+	}
+	s.mutex.Unlock()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	buffer.WriteTo(w)
+}
+
+// pause or resume the system (POST /api/pause, POST /api/resume)
+func (s *Server) servePause(w http.ResponseWriter, r *http.Request) {
+
+	if r.URL.Path == "/api/pause" {
+		s.System().Pause()
+	} else {
+		s.System().Resume()
+	}
+
+	s.serveStatus(w, r)
+}
+
+// pause the system and run one frame (POST /api/step)
+func (s *Server) serveStep(w http.ResponseWriter, r *http.Request) {
+	gbc := s.System()
+
+	if !gbc.Paused() {
+		gbc.Pause()
+	}
+	gbc.AdvanceFrame()
+
+	s.serveStatus(w, r)
+}
+
+// set the speed multiplier, 0 runs unthrottled (POST /api/speed?value=n)
+func (s *Server) serveSpeed(w http.ResponseWriter, r *http.Request) {
+
+	speed, err := strconv.ParseFloat(r.URL.Query().Get("value"), 64)
+	if err == nil {
+		err = s.System().SetSpeed(speed)
+	}
+	if err != nil {
+		http.Error(w, "invalid speed: "+r.URL.Query().Get("value"), http.StatusBadRequest)
+		return
+	}
+
+	s.serveStatus(w, r)
+}
+
+// reset the system (POST /api/reset)
+func (s *Server) serveReset(w http.ResponseWriter, r *http.Request) {
+
+	err := s.Reset()
+	if errors.Is(err, ErrRecording) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	s.serveStatus(w, r)
+}
+
+// save or load a state slot (POST /api/state/{slot}/save, POST /api/state/{slot}/load)
+func (s *Server) serveState(w http.ResponseWriter, r *http.Request) {
+
+	slot, err := strconv.Atoi(r.PathValue("slot"))
+	if err != nil || slot < 0 || slot >= system.STATE_SLOTS {
+		http.Error(w, fmt.Sprintf("invalid save state slot: %s (expected 0-%d)", r.PathValue("slot"), system.STATE_SLOTS-1),
+			http.StatusBadRequest)
+		return
+	}
+
+	//	a state that can't be loaded leaves the system unchanged
+	status := http.StatusConflict
+	if strings.HasSuffix(r.URL.Path, "/save") {
+		err = s.SaveState(slot)
+		status = http.StatusInternalServerError
+	} else {
+		err = s.LoadState(slot)
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		http.Error(w, "save states are not supported by this server", http.StatusNotImplemented)
+		return
+
+	case errors.Is(err, fs.ErrNotExist):
+		http.Error(w, fmt.Sprintf("save state slot %d is empty", slot), http.StatusNotFound)
+		return
+
+	case err != nil:
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	s.serveStatus(w, r)
+}
+
+// list the cheats of the system (GET /api/cheats)
+func (s *Server) serveCheats(w http.ResponseWriter, r *http.Request) {
+	var cheats = []cheatMessage{}
+
+	s.mutex.Lock()
+	for i, cheat := range s.system.Cheats() {
+		cheats = append(cheats, cheatMessage{Index: i, Code: cheat.Code, Description: cheat.Description, Enabled: cheat.Enabled})
+	}
+	s.mutex.Unlock()
+
+	writeJSON(w, cheats)
+}
+
+// enable or disable a cheat (POST /api/cheats/{index}/enable, POST /api/cheats/{index}/disable)
+func (s *Server) serveEnableCheat(w http.ResponseWriter, r *http.Request) {
+
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err == nil {
+		err = s.EnableCheat(index, strings.HasSuffix(r.URL.Path, "/enable"))
+	}
+	if errors.Is(err, ErrRecording) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid cheat: %s", r.PathValue("index")), http.StatusBadRequest)
+		return
+	}
+
+	s.serveCheats(w, r)
+}
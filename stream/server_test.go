@@ -0,0 +1,578 @@
+////////////////////////////////////////////////////////////////////////////////
+//	server_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the streaming server and its WebSocket connection
+////////////////////////////////////////////////////////////////////////////////
+
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldebap/go_gbc/cartridge"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/system"
+)
+
+// build a 32KB ROM looping forever at the entry point
+func buildLoopROM() []uint8 {
+	rom := make([]uint8, 2*cartridge.ROM_BANK_SIZE)
+
+	copy(rom[cartridge.CARTRIDGE_TITLE_ADDRESS:], "STREAM")
+	rom[system.CARTRIDGE_ENTRY] = cpu.JR_e
+	rom[system.CARTRIDGE_ENTRY+1] = 0xfe
+
+	return rom
+}
+
+// build a 32KB ROM adding the joypad button lines into the work RAM in a loop
+func buildJoypadROM() []uint8 {
+	rom := buildLoopROM()
+
+	copy(rom[system.CARTRIDGE_ENTRY:], []uint8{
+		cpu.LD_A_n, system.P1_SELECT_DIRECTIONS,
+		cpu.LDH_ADDR_n_A, 0x00,
+		cpu.LDH_A_ADDR_n, 0x00,
+		cpu.LD_B_A,
+		cpu.LD_A_ADDR_nn, 0x00, 0xc0,
+		cpu.ADD_B,
+		cpu.LD_ADDR_nn_A, 0x00, 0xc0,
+		cpu.JR_e, 0xf4,
+	})
+
+	return rom
+}
+
+// create a server for the loop ROM counting the saves
+func newTestServer(t *testing.T, saves *int) *Server {
+
+	server, err := NewServer(
+		func() (*system.System, error) { return system.NewSystem(buildLoopROM(), system.MODEL_DMG, nil, false) },
+		func(*system.System) error { *saves++; return nil },
+		0, nil, 48000)
+	if err != nil {
+		t.Fatalf("failed creating server: %v", err)
+	}
+
+	return server
+}
+
+// WebSocket client side used by the tests (client frames are masked)
+type testClient struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// connect a WebSocket client to a test HTTP server
+func dialTestClient(t *testing.T, url string, path string) *testClient {
+
+	conn, err := net.Dial("tcp", strings.TrimPrefix(url, "http://"))
+	if err != nil {
+		t.Fatalf("failed connecting: %v", err)
+	}
+
+	request := "GET " + path + " HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n" +
+		"Sec-WebSocket-Version: 13\r\nSec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n\r\n"
+	conn.Write([]byte(request))
+
+	reader := bufio.NewReader(conn)
+	response, err := http.ReadResponse(reader, nil)
+	if err != nil {
+		t.Fatalf("failed reading handshake: %v", err)
+	}
+
+	//	accept key from the RFC 6455 example
+	if response.StatusCode != http.StatusSwitchingProtocols || response.Header.Get("Sec-WebSocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Fatalf("failed handshake: expected: 101\n\tresult: %d %v", response.StatusCode, response.Header)
+	}
+
+	return &testClient{conn: conn, reader: reader}
+}
+
+// send a masked frame
+func (c *testClient) write(opcode uint8, payload []byte) {
+	var mask = [4]uint8{0x12, 0x34, 0x56, 0x78}
+
+	frame := []uint8{WEBSOCKET_FINAL_FRAGMENT | opcode, WEBSOCKET_MASKED | uint8(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, value := range payload {
+		frame = append(frame, value^mask[i%4])
+	}
+
+	c.conn.Write(frame)
+}
+
+// read an unmasked server frame
+func (c *testClient) read(t *testing.T) (uint8, []byte) {
+	var header [2]uint8
+
+	c.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	_, err := io.ReadFull(c.reader, header[:])
+	if err != nil {
+		t.Fatalf("failed reading frame: %v", err)
+	}
+
+	length := int(header[1])
+	switch length {
+	case 126:
+		var extended [2]uint8
+		io.ReadFull(c.reader, extended[:])
+		length = int(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]uint8
+		io.ReadFull(c.reader, extended[:])
+		length = int(binary.BigEndian.Uint64(extended[:]))
+	}
+
+	payload := make([]byte, length)
+	io.ReadFull(c.reader, payload)
+
+	return header[0] & 0x0f, payload
+}
+
+// post a control request returning the status
+func postStatus(t *testing.T, url string) statusMessage {
+	var status statusMessage
+
+	response, err := http.Post(url, CONTROL_CONTENT_TYPE, nil)
+	if err != nil {
+		t.Fatalf("failed posting %s: %v", url, err)
+	}
+	defer response.Body.Close()
+
+	err = json.NewDecoder(response.Body).Decode(&status)
+	if err != nil {
+		t.Fatalf("failed decoding status of %s: %v", url, err)
+	}
+
+	return status
+}
+
+// send a joypad event, waiting for the server to apply it
+func (c *testClient) press(t *testing.T, button string, pressed bool) {
+
+	c.write(WEBSOCKET_TEXT, []byte(fmt.Sprintf(`{"button":%q,"pressed":%t}`, button, pressed)))
+	c.write(WEBSOCKET_PING, nil)
+
+	//	the frames streamed meanwhile are skipped
+	for {
+		opcode, _ := c.read(t)
+		if opcode == WEBSOCKET_PONG {
+			return
+		}
+	}
+}
+
+// streaming server unit tests
+func Test_Server(t *testing.T) {
+
+	t.Run(">>> server: scenario 1 - WebSocket stream and joypad events", func(t *testing.T) {
+		var saves int
+
+		server := newTestServer(t, &saves)
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		client := dialTestClient(t, httpServer.URL, "/ws?format=rgba")
+		defer client.conn.Close()
+
+		opcode, payload := client.read(t)
+
+		var hello helloMessage
+		if opcode != WEBSOCKET_TEXT || json.Unmarshal(payload, &hello) != nil || hello.Title != "STREAM" || hello.Width != 160 {
+			t.Fatalf("failed receiving hello: %d %s", opcode, payload)
+		}
+
+		opcode, payload = client.read(t)
+		if opcode != WEBSOCKET_BINARY || payload[0] != MESSAGE_FRAME_RGBA || len(payload) != 1+4*160*144 {
+			t.Errorf("failed receiving the first frame: expected: full RGBA frame\n\tresult: opcode %d, %d bytes", opcode, len(payload))
+		}
+
+		client.write(WEBSOCKET_PING, []byte("ping"))
+		opcode, payload = client.read(t)
+		if opcode != WEBSOCKET_PONG || string(payload) != "ping" {
+			t.Errorf("failed answering ping: expected: pong\n\tresult: %d %s", opcode, payload)
+		}
+
+		client.write(WEBSOCKET_TEXT, []byte(`{"button":"start","pressed":true}`))
+		client.write(WEBSOCKET_TEXT, []byte(`{"button":"a","pressed":true}`))
+		client.write(WEBSOCKET_TEXT, []byte(`{"button":"start","pressed":false}`))
+		client.write(WEBSOCKET_PING, nil)
+		client.read(t)
+
+		server.mutex.Lock()
+		buttons := server.buttons
+		server.mutex.Unlock()
+		if buttons != system.BUTTON_A {
+			t.Errorf("failed applying joypad events: expected: A\n\tresult: %s", system.FormatButtons(buttons))
+		}
+
+		//	the frames run by the server are streamed as deltas (or skipped when unchanged)
+		err := server.System().RunFrame()
+		if err == nil {
+			err = server.frameDone(server.System().Frame())
+		}
+		if err != nil {
+			t.Fatalf("failed running frame: %v", err)
+		}
+
+		opcode, payload = client.read(t)
+		if opcode != WEBSOCKET_BINARY || (payload[0] != MESSAGE_FRAME_DELTA && payload[0] != MESSAGE_AUDIO) {
+			t.Errorf("failed streaming frame: expected: delta or audio\n\tresult: opcode %d type %d", opcode, payload[0])
+		}
+
+		//	closing releases the buttons held by the client
+		client.write(WEBSOCKET_CLOSE, nil)
+		opcode, _ = client.read(t)
+		if opcode != WEBSOCKET_CLOSE {
+			t.Errorf("failed closing: expected: close frame\n\tresult: %d", opcode)
+		}
+
+		for range 100 {
+			server.mutex.Lock()
+			buttons, clients := server.buttons, len(server.clients)
+			server.mutex.Unlock()
+			if buttons == 0 && clients == 0 {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Errorf("failed disconnecting client")
+	})
+
+	t.Run(">>> server: scenario 2 - control endpoints", func(t *testing.T) {
+		var saves int
+
+		server := newTestServer(t, &saves)
+		server.System().Pause()
+
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error)
+		go func() { result <- server.Run(ctx) }()
+
+		postStatus(t, httpServer.URL+"/api/step")
+		postStatus(t, httpServer.URL+"/api/step")
+		for i := 0; i < 100 && server.Frame() != 2; i++ {
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		status := postStatus(t, httpServer.URL+"/api/speed?value=0")
+		if !status.Paused || status.Frame != 2 || status.Speed != system.SPEED_UNTHROTTLED {
+			t.Errorf("failed advancing frames: expected: paused at frame 2, unthrottled\n\tresult: %+v", status)
+		}
+
+		response, _ := http.Post(httpServer.URL+"/api/speed?value=-1", CONTROL_CONTENT_TYPE, nil)
+		response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("failed rejecting speed: expected: %d\n\tresult: %d", http.StatusBadRequest, response.StatusCode)
+		}
+
+		//	the reset keeps the pause
+		status = postStatus(t, httpServer.URL+"/api/reset")
+		if status.Frame != 0 || !status.Paused || saves != 1 {
+			t.Errorf("failed resetting: expected: paused at frame 0 and 1 save\n\tresult: %+v and %d saves", status, saves)
+		}
+
+		response, _ = http.Get(httpServer.URL + "/api/screenshot?scale=2")
+		img, err := png.Decode(response.Body)
+		response.Body.Close()
+		if err != nil || img.Bounds().Dx() != 320 {
+			t.Errorf("failed taking screenshot: expected: 320 pixels wide PNG\n\tresult: %v", err)
+		}
+
+		response, _ = http.Post(httpServer.URL+"/api/state/1/save", CONTROL_CONTENT_TYPE, nil)
+		response.Body.Close()
+		if response.StatusCode != http.StatusNotImplemented {
+			t.Errorf("failed reporting save states: expected: %d\n\tresult: %d", http.StatusNotImplemented, response.StatusCode)
+		}
+
+		response, _ = http.Get(httpServer.URL + "/")
+		page, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		if !strings.Contains(string(page), "/ws?format=") {
+			t.Errorf("failed serving the page")
+		}
+
+		cancel()
+		err = <-result
+		if err != nil || saves != 2 {
+			t.Errorf("failed stopping: expected: 2 saves\n\tresult: %d saves (%v)", saves, err)
+		}
+	})
+
+	t.Run(">>> server: scenario 3 - run until cancelled", func(t *testing.T) {
+		var saves int
+
+		server := newTestServer(t, &saves)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*system.FRAME_DURATION)
+		defer cancel()
+
+		err := server.Run(ctx)
+		if err != nil || server.Frame() == 0 || saves != 1 {
+			t.Errorf("failed running: expected: frames run and 1 save\n\tresult: %d frames, %d saves (%v)", server.Frame(), saves, err)
+		}
+	})
+	t.Run(">>> server: scenario 4 - save state slots while running", func(t *testing.T) {
+		var saves int
+
+		directory := t.TempDir()
+		server := newTestServer(t, &saves)
+		server.ConnectStateFiles(func(slot int) string { return filepath.Join(directory, fmt.Sprintf("loop.ss%d", slot)) })
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error, 1)
+		go func() { result <- server.Run(ctx) }()
+
+		for server.Frame() < 3 {
+			time.Sleep(time.Millisecond)
+		}
+
+		for _, test := range []struct {
+			path     string
+			expected int
+		}{
+			{path: "/api/state/2/load", expected: http.StatusNotFound},
+			{path: "/api/state/10/save", expected: http.StatusBadRequest},
+			{path: "/api/state/x/load", expected: http.StatusBadRequest},
+			{path: "/api/state/2/save", expected: http.StatusOK},
+		} {
+			response, _ := http.Post(httpServer.URL+test.path, CONTROL_CONTENT_TYPE, nil)
+			response.Body.Close()
+			if response.StatusCode != test.expected {
+				t.Errorf("failed posting %s: expected: %d\n\tresult: %d", test.path, test.expected, response.StatusCode)
+			}
+		}
+
+		state, err := os.ReadFile(filepath.Join(directory, "loop.ss2"))
+		if err != nil {
+			t.Fatalf("failed saving state slot: %v", err)
+		}
+
+		//	the run loop resumes after loading, from the frame of the state
+		server.System().Pause()
+		status := postStatus(t, httpServer.URL+"/api/state/2/load")
+		restored, _ := system.NewSystem(buildLoopROM(), system.MODEL_DMG, nil, false)
+		restored.LoadState(state)
+		if status.Frame != restored.Frame() || status.Frame < 3 || !status.Paused {
+			t.Errorf("failed loading state slot: expected: paused at frame %d\n\tresult: %+v", restored.Frame(), status)
+		}
+
+		server.System().Resume()
+		for server.Frame() <= status.Frame {
+			time.Sleep(time.Millisecond)
+		}
+
+		cancel()
+		err = <-result
+		if err != nil {
+			t.Errorf("failed stopping: %v", err)
+		}
+	})
+	t.Run(">>> server: scenario 5 - enable and disable cheats", func(t *testing.T) {
+		var saves int
+		var cheats []cheatMessage
+
+		server := newTestServer(t, &saves)
+		cheat, _ := system.ParseCheat("01AA00C1", "fill c100")
+		server.System().AddCheat(cheat)
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error, 1)
+		go func() { result <- server.Run(ctx) }()
+
+		response, _ := http.Post(httpServer.URL+"/api/cheats/0/disable", CONTROL_CONTENT_TYPE, nil)
+		json.NewDecoder(response.Body).Decode(&cheats)
+		response.Body.Close()
+		if len(cheats) != 1 || cheats[0].Enabled || cheats[0].Description != "fill c100" {
+			t.Errorf("failed disabling cheat: expected: 1 disabled cheat\n\tresult: %+v", cheats)
+		}
+
+		response, _ = http.Post(httpServer.URL+"/api/cheats/1/enable", CONTROL_CONTENT_TYPE, nil)
+		response.Body.Close()
+		if response.StatusCode != http.StatusBadRequest {
+			t.Errorf("failed rejecting cheat: expected: %d\n\tresult: %d", http.StatusBadRequest, response.StatusCode)
+		}
+
+		cancel()
+		<-result
+
+		response, _ = http.Get(httpServer.URL + "/api/cheats")
+		json.NewDecoder(response.Body).Decode(&cheats)
+		response.Body.Close()
+		if len(cheats) != 1 || cheats[0].Enabled {
+			t.Errorf("failed listing cheats: expected: 1 disabled cheat\n\tresult: %+v", cheats)
+		}
+	})
+
+	t.Run(">>> server: scenario 6 - cross-origin requests refused", func(t *testing.T) {
+		var saves int
+
+		server := newTestServer(t, &saves)
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		for _, test := range []struct {
+			origin   string
+			expected int
+		}{
+			{origin: "http://evil.example", expected: http.StatusForbidden},
+			{origin: "null", expected: http.StatusForbidden},
+			{origin: httpServer.URL, expected: http.StatusSwitchingProtocols},
+		} {
+			request, _ := http.NewRequest(http.MethodGet, httpServer.URL+"/ws?format=png", nil)
+			request.Header.Set("Origin", test.origin)
+			request.Header.Set("Connection", "Upgrade")
+			request.Header.Set("Upgrade", "websocket")
+			request.Header.Set("Sec-WebSocket-Version", "13")
+			request.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("failed connecting: %v", err)
+			}
+			response.Body.Close()
+			if response.StatusCode != test.expected {
+				t.Errorf("failed upgrading from %s: expected: %d\n\tresult: %d", test.origin, test.expected, response.StatusCode)
+			}
+		}
+
+		//	a form or a simple fetch from another page can't reset the emulator
+		for _, test := range []struct {
+			origin      string
+			contentType string
+			expected    int
+		}{
+			{origin: "", contentType: "", expected: http.StatusUnsupportedMediaType},
+			{origin: "http://evil.example", contentType: "application/x-www-form-urlencoded", expected: http.StatusForbidden},
+			{origin: "", contentType: "text/plain", expected: http.StatusUnsupportedMediaType},
+			{origin: "http://evil.example", contentType: CONTROL_CONTENT_TYPE, expected: http.StatusForbidden},
+		} {
+			request, _ := http.NewRequest(http.MethodPost, httpServer.URL+"/api/reset", nil)
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+			if test.contentType != "" {
+				request.Header.Set("Content-Type", test.contentType)
+			}
+
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatalf("failed posting: %v", err)
+			}
+			response.Body.Close()
+			if response.StatusCode != test.expected {
+				t.Errorf("failed refusing %q from %q: expected: %d\n\tresult: %d", test.contentType, test.origin, test.expected, response.StatusCode)
+			}
+		}
+
+		if saves != 0 {
+			t.Errorf("failed refusing the reset: expected: no save\n\tresult: %d saves", saves)
+		}
+	})
+
+	t.Run(">>> server: scenario 7 - record the joypad events into a movie", func(t *testing.T) {
+		var expected, result []uint64
+
+		rom := buildJoypadROM()
+		server, err := NewServer(
+			func() (*system.System, error) { return system.NewSystem(rom, system.MODEL_DMG, nil, false) },
+			func(*system.System) error { return nil },
+			0, nil, 48000)
+		if err != nil {
+			t.Fatalf("failed creating server: %v", err)
+		}
+
+		err = server.RecordMovie(func(input system.InputSource) *system.MovieSession {
+			return system.NewMovieRecorder(rom, nil, server.system.Cheats(), nil, input)
+		})
+		if err != nil {
+			t.Fatalf("failed recording movie: %v", err)
+		}
+
+		httpServer := httptest.NewServer(server)
+		defer httpServer.Close()
+
+		client := dialTestClient(t, httpServer.URL, "/ws?format=rgba")
+		defer client.conn.Close()
+
+		//	the frames are run here, pressing and releasing buttons in between
+		for frame := range 30 {
+			switch frame {
+			case 5:
+				client.press(t, "right", true)
+			case 12:
+				client.press(t, "down", true)
+			case 20:
+				client.press(t, "right", false)
+			}
+
+			err := server.System().RunFrame()
+			if err == nil {
+				err = server.frameDone(server.System().Frame())
+			}
+			if err != nil {
+				t.Fatalf("failed running frame: %v", err)
+			}
+			expected = append(expected, server.System().StateHash())
+		}
+
+		//	a reset or a state load would break the movie
+		response, _ := http.Post(httpServer.URL+"/api/reset", CONTROL_CONTENT_TYPE, nil)
+		response.Body.Close()
+		if response.StatusCode != http.StatusConflict {
+			t.Errorf("failed refusing the reset: expected: %d\n\tresult: %d", http.StatusConflict, response.StatusCode)
+		}
+
+		movie := server.Movie()
+		if movie.Len() != 30 || movie.Buttons(4) != 0 || movie.Buttons(15) != system.BUTTON_RIGHT|system.BUTTON_DOWN {
+			t.Errorf("failed recording the buttons: expected: 30 frames, right and down on frame 15\n\tresult: %d frames, %s",
+				movie.Len(), system.FormatButtons(movie.Buttons(15)))
+		}
+
+		player, err := system.NewMoviePlayer(movie, rom, nil, nil, true)
+		if err != nil {
+			t.Fatalf("failed replaying movie: %v", err)
+		}
+
+		replay, _ := system.NewSystem(rom, system.MODEL_DMG, nil, false)
+		replay.Joypad().ConnectInput(player)
+		for !player.Finished() {
+			err := replay.RunFrame()
+			if err == nil {
+				err = player.EndFrame(replay.PPU().Framebuffer())
+			}
+			if err != nil {
+				t.Fatalf("failed replaying frame: %v", err)
+			}
+			result = append(result, replay.StateHash())
+		}
+
+		if !slices.Equal(expected, result) {
+			t.Errorf("failed replaying the movie: expected: %x\n\tresult: %x", expected, result)
+		}
+	})
+}
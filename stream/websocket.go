@@ -0,0 +1,288 @@
+////////////////////////////////////////////////////////////////////////////////
+//	websocket.go - Oct-19-2026 by aldebap
+//
+//	minimal WebSocket (RFC 6455) server connection
+////////////////////////////////////////////////////////////////////////////////
+
+package stream
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// WebSocket opcodes
+const (
+	WEBSOCKET_CONTINUATION = uint8(0x00)
+	WEBSOCKET_TEXT         = uint8(0x01)
+	WEBSOCKET_BINARY       = uint8(0x02)
+	WEBSOCKET_CLOSE        = uint8(0x08)
+	WEBSOCKET_PING         = uint8(0x09)
+	WEBSOCKET_PONG         = uint8(0x0a)
+)
+
+// WebSocket settings
+const (
+	WEBSOCKET_GUID            = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	WEBSOCKET_MAX_MESSAGE     = 64 * 1024
+	WEBSOCKET_FINAL_FRAGMENT  = 0x80
+	WEBSOCKET_MASKED          = 0x80
+	WEBSOCKET_CLOSE_NORMAL    = 1000
+	WEBSOCKET_CLOSE_TOO_LARGE = 1009
+)
+
+// server side of a WebSocket connection: messages are read by a single goroutine,
+// writes are serialized so frames and control replies can be sent from any goroutine
+type WebSocket struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	writeMutex sync.Mutex
+	closed     bool
+}
+
+// compute the Sec-WebSocket-Accept value of a handshake key
+func acceptKey(key string) string {
+	hash := sha1.Sum([]byte(key + WEBSOCKET_GUID))
+
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// check whether a comma separated header contains a token
+func headerContains(header http.Header, name string, token string) bool {
+
+	for _, value := range header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(item), token) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// check whether a request comes from the page served by the same host: browsers send the Origin of
+// the page on cross-origin requests, non-browser clients usually send none
+func sameOrigin(r *http.Request) bool {
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return originURL.Host != "" && strings.EqualFold(originURL.Host, r.Host)
+}
+
+// upgrade an HTTP request into a WebSocket connection (refused when the request comes from another origin)
+func AcceptWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocket, error) {
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if r.Method != http.MethodGet || key == "" || !headerContains(r.Header, "Connection", "upgrade") ||
+		!headerContains(r.Header, "Upgrade", "websocket") {
+		http.Error(w, "WebSocket upgrade expected", http.StatusBadRequest)
+		return nil, fmt.Errorf("invalid WebSocket handshake")
+	}
+	if !sameOrigin(r) {
+		http.Error(w, "cross-origin WebSocket refused", http.StatusForbidden)
+		return nil, fmt.Errorf("cross-origin WebSocket refused: %s", r.Header.Get("Origin"))
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		http.Error(w, "unsupported WebSocket version", http.StatusUpgradeRequired)
+		return nil, fmt.Errorf("unsupported WebSocket version: %s", r.Header.Get("Sec-WebSocket-Version"))
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return nil, fmt.Errorf("connection can't be hijacked")
+	}
+
+	conn, buffer, err := hijacker.Hijack()
+	if err != nil {
+		return nil, err
+	}
+
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + acceptKey(key) + "\r\n\r\n"
+
+	_, err = conn.Write([]byte(response))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &WebSocket{conn: conn, reader: buffer.Reader}, nil
+}
+
+// read a frame: final flag, opcode and unmasked payload
+func (ws *WebSocket) readFrame() (bool, uint8, []byte, error) {
+	var header [2]uint8
+
+	_, err := io.ReadFull(ws.reader, header[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	final := header[0]&WEBSOCKET_FINAL_FRAGMENT != 0
+	opcode := header[0] & 0x0f
+	length := uint64(header[1] & 0x7f)
+
+	switch length {
+	case 126:
+		var extended [2]uint8
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]uint8
+		_, err = io.ReadFull(ws.reader, extended[:])
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	//	clients must mask every frame
+	if header[1]&WEBSOCKET_MASKED == 0 {
+		return false, 0, nil, fmt.Errorf("unmasked WebSocket frame")
+	}
+	if length > WEBSOCKET_MAX_MESSAGE {
+		ws.writeClose(WEBSOCKET_CLOSE_TOO_LARGE)
+		return false, 0, nil, fmt.Errorf("WebSocket frame too large: %d bytes", length)
+	}
+
+	var mask [4]uint8
+	_, err = io.ReadFull(ws.reader, mask[:])
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(ws.reader, payload)
+	if err != nil {
+		return false, 0, nil, err
+	}
+
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return final, opcode, payload, nil
+}
+
+// read the next text or binary message, answering pings; io.EOF is returned once the peer closes
+func (ws *WebSocket) ReadMessage() (uint8, []byte, error) {
+	var opcode uint8
+	var message []byte
+
+	for {
+		final, frameOpcode, payload, err := ws.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch frameOpcode {
+		case WEBSOCKET_PING:
+			err = ws.WriteMessage(WEBSOCKET_PONG, payload)
+			if err != nil {
+				return 0, nil, err
+			}
+			continue
+
+		case WEBSOCKET_PONG:
+			continue
+
+		case WEBSOCKET_CLOSE:
+			ws.writeClose(WEBSOCKET_CLOSE_NORMAL)
+			return 0, nil, io.EOF
+
+		case WEBSOCKET_CONTINUATION:
+			if message == nil {
+				return 0, nil, fmt.Errorf("unexpected WebSocket continuation frame")
+			}
+
+		case WEBSOCKET_TEXT, WEBSOCKET_BINARY:
+			if message != nil {
+				return 0, nil, fmt.Errorf("unfinished fragmented WebSocket message")
+			}
+			opcode = frameOpcode
+			message = []byte{}
+
+		default:
+			return 0, nil, fmt.Errorf("invalid WebSocket opcode: 0x%02x", frameOpcode)
+		}
+
+		message = append(message, payload...)
+		if len(message) > WEBSOCKET_MAX_MESSAGE {
+			ws.writeClose(WEBSOCKET_CLOSE_TOO_LARGE)
+			return 0, nil, fmt.Errorf("WebSocket message too large: %d bytes", len(message))
+		}
+
+		if final {
+			return opcode, message, nil
+		}
+	}
+}
+
+// write an unfragmented message (server frames are not masked)
+func (ws *WebSocket) WriteMessage(opcode uint8, payload []byte) error {
+	var header []uint8
+
+	ws.writeMutex.Lock()
+	defer ws.writeMutex.Unlock()
+
+	if ws.closed {
+		return net.ErrClosed
+	}
+
+	header = append(header, WEBSOCKET_FINAL_FRAGMENT|opcode)
+
+	switch {
+	case len(payload) < 126:
+		header = append(header, uint8(len(payload)))
+	case len(payload) <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(len(payload)))
+	}
+
+	buffers := net.Buffers{header, payload}
+	_, err := buffers.WriteTo(ws.conn)
+
+	return err
+}
+
+// send a close frame with a status code
+func (ws *WebSocket) writeClose(status uint16) {
+	ws.WriteMessage(WEBSOCKET_CLOSE, binary.BigEndian.AppendUint16(nil, status))
+
+	ws.writeMutex.Lock()
+	ws.closed = true
+	ws.writeMutex.Unlock()
+}
+
+// send a close frame and close the connection
+func (ws *WebSocket) Close() error {
+	ws.writeClose(WEBSOCKET_CLOSE_NORMAL)
+
+	return ws.conn.Close()
+}
@@ -0,0 +1,147 @@
+////////////////////////////////////////////////////////////////////////////////
+//	acid2_test.go - Oct-18-2026 by aldebap
+//
+//	Opt-in screen state regression tests using dmg-acid2 and cgb-acid2 (the ROMs are not in the repository)
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// acid2 test settings
+const (
+	ACID2_DIR_ENV        = "GBC_ACID2_DIR"
+	ACID2_OUTPUT_DIR_ENV = "GBC_ACID2_OUTPUT_DIR"
+	ACID2_TESTDATA_DIR   = "testdata/acid2"
+	ACID2_MAX_FRAMES     = 600
+)
+
+// boot a ROM in a system and run it until the CPU is about to execute LD B,B (the debug breakpoint
+// acid2 executes once the screen is drawn), returning the framebuffer shown at that moment
+func runAcid2ROM(rom []uint8, cgbMode bool, maxFrames int) (*ppu.Framebuffer, error) {
+	var model = MODEL_DMG
+
+	if cgbMode {
+		model = MODEL_CGB
+	}
+
+	system, err := NewSystem(rom, model, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for system.Cycles() < uint64(maxFrames)*ppu.FRAME_CYCLES {
+		//	only opcodes are checked: an operand 0x40 (e.g. LD A,0x40) is not a breakpoint
+		opcode, ok := system.CPU().Instruction()
+		if ok && opcode == cpu.LD_B_B {
+			return system.PPU().Framebuffer(), nil
+		}
+
+		err = system.Step()
+		if err != nil {
+			return nil, fmt.Errorf("CPU stopped at PC 0x%04x in frame %d: %w",
+				system.CPU().PC(), system.Cycles()/ppu.FRAME_CYCLES, err)
+		}
+	}
+
+	return nil, fmt.Errorf("LD B,B not reached after %d frames", maxFrames)
+}
+
+// acid2 test case
+type acid2TestCase struct {
+	name      string
+	rom       string
+	reference string
+	cgbMode   bool
+	palette   string
+}
+
+// locate the directory with the acid2 ROMs and reference images, and whether it was set explicitly
+func acid2Directory() (string, bool) {
+	if directory := os.Getenv(ACID2_DIR_ENV); directory != "" {
+		return directory, true
+	}
+
+	return ACID2_TESTDATA_DIR, false
+}
+
+// locate an acid2 file: a missing file fails the test when the directory was set explicitly, and skips it otherwise
+func acid2File(t *testing.T, fileName string) string {
+
+	directory, required := acid2Directory()
+	fileName = filepath.Join(directory, fileName)
+
+	_, err := os.Stat(fileName)
+	if err == nil {
+		return fileName
+	}
+	if required {
+		t.Fatalf("fail locating acid2 file: %s", err.Error())
+	}
+
+	t.Skipf("%s not available (set %s to run the acid2 tests)", fileName, ACID2_DIR_ENV)
+	return ""
+}
+
+// acid2 screen state regression tests: these only run when the ROMs are supplied (see testdata/acid2/README.md)
+func Test_Acid2(t *testing.T) {
+
+	testCases := []acid2TestCase{
+		{name: "dmg-acid2", rom: "dmg-acid2.gb", reference: "dmg-acid2.png", cgbMode: false, palette: "grayscale"},
+		{name: "cgb-acid2", rom: "cgb-acid2.gbc", reference: "cgb-acid2.png", cgbMode: true, palette: "grayscale"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(">>> acid2: "+testCase.name, func(t *testing.T) {
+
+			rom, err := os.ReadFile(acid2File(t, testCase.rom))
+			if err != nil {
+				t.Fatalf("fail reading ROM: %s", err.Error())
+			}
+
+			want, err := ppu.LoadPNG(acid2File(t, testCase.reference))
+			if err != nil {
+				t.Fatalf("fail reading reference image: %s", err.Error())
+			}
+
+			framebuffer, err := runAcid2ROM(rom, testCase.cgbMode, ACID2_MAX_FRAMES)
+			if err != nil {
+				t.Fatalf("fail running %s: %s", testCase.rom, err.Error())
+			}
+
+			//	the reference images use the raw 5 bit expansion for CGB and gray shades for DMG
+			palette, _ := ppu.ParseDMG_palette(testCase.palette)
+			p, _ := ppu.NewPostProcessor(ppu.COLOR_CORRECTION_NONE, palette)
+
+			mismatches, diff, err := ppu.CompareImages(p.Process(framebuffer), want)
+			if err != nil {
+				t.Fatalf("fail comparing screen: %s", err.Error())
+			}
+			if mismatches == 0 {
+				return
+			}
+
+			outputDir := os.Getenv(ACID2_OUTPUT_DIR_ENV)
+			if outputDir == "" {
+				outputDir = os.TempDir()
+			}
+			diffFileName := filepath.Join(outputDir, testCase.name+"-diff.png")
+
+			err = ppu.SavePNG(diffFileName, diff)
+			if err != nil {
+				t.Errorf("fail saving diff image: %s", err.Error())
+			}
+
+			t.Errorf("%s screen differs from reference: %d mismatching pixels (diff image: %s)",
+				testCase.name, mismatches, diffFileName)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+////////////////////////////////////////////////////////////////////////////////
+//	cheat.go - Oct-19-2026 by aldebap
+//
+//	cheat codes: Game Genie ROM patches and GameShark RAM writes
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"math/bits"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aldebap/go_gbc/cartridge"
+)
+
+// cheat code formats:
+//
+//	Game Genie  ABC-DEF-GHI  replaces the ROM byte at (FCDE xor f000) with AB when it reads the compare
+//	                         byte (GI rotated right by 2, xor ba); ABC-DEF patches it unconditionally
+//	GameShark   BBVVLLHH     writes VV into the RAM at HHLL every frame: a bank byte 8x or 9x selects the
+//	                         cartridge RAM bank (a000 - bfff) or the CGB WRAM bank (d000 - dfff) x,
+//	                         any other one writes into the mapped bank
+//
+// a cheat holds one or more codes separated by "+"
+const (
+	CHEAT_GAME_GENIE = uint8(1)
+	CHEAT_GAMESHARK  = uint8(2)
+
+	CHEAT_SEPARATOR       = "+"
+	CHEAT_FILE_COMMENT    = "#"
+	CHEAT_FILE_DISABLED   = "!"
+	GAME_GENIE_ADDRESS    = 0xf000
+	GAME_GENIE_COMPARE    = 0xba
+	GAMESHARK_BANK_SELECT = 0x80
+	GAMESHARK_WRAM_BANKED = 0xd000
+)
+
+// a single code: a ROM patch or a RAM write
+type cheatCode struct {
+	format     uint8
+	address    uint16
+	value      uint8
+	compare    uint8
+	hasCompare bool
+	bank       int
+	hasBank    bool
+}
+
+// a cheat: the codes written in a single line of a cheat file (once added to a system, it is enabled
+// and disabled with System.EnableCheat)
+type Cheat struct {
+	Code        string
+	Description string
+	Enabled     bool
+
+	codes []cheatCode
+}
+
+// parse a hexadecimal code of a number of digits
+func parseHexCode(code string, digits int) (uint64, error) {
+
+	value, err := strconv.ParseUint(code, 16, 64)
+	if err != nil || len(code) != digits {
+		return 0, fmt.Errorf("expected %d hex digits", digits)
+	}
+
+	return value, nil
+}
+
+// parse a Game Genie code: ABC-DEF or ABC-DEF-GHI
+func parseGameGenie(code string) (cheatCode, error) {
+	var digits = strings.ReplaceAll(code, "-", "")
+	var result = cheatCode{format: CHEAT_GAME_GENIE}
+
+	value, err := parseHexCode(digits[:min(len(digits), 6)], 6)
+	if err != nil {
+		return result, err
+	}
+
+	result.value = uint8(value >> 16)
+	result.address = bits.RotateLeft16(uint16(value), -4) ^ GAME_GENIE_ADDRESS
+	if result.address >= cartridge.CARTRIDGE_ROM_SIZE {
+		return result, fmt.Errorf("address 0x%04x outside the ROM", result.address)
+	}
+
+	if len(digits) > 6 {
+		compare, err := parseHexCode(digits[6:], 3)
+		if err != nil {
+			return result, err
+		}
+
+		result.compare = bits.RotateLeft8(uint8(compare>>4&0xf0|compare&0x0f), -2) ^ GAME_GENIE_COMPARE
+		result.hasCompare = true
+	}
+
+	return result, nil
+}
+
+// parse a GameShark code: BBVVLLHH
+func parseGameShark(code string) (cheatCode, error) {
+	var result = cheatCode{format: CHEAT_GAMESHARK}
+
+	value, err := parseHexCode(code, 8)
+	if err != nil {
+		return result, err
+	}
+
+	bank := uint8(value >> 24)
+	result.value = uint8(value >> 16)
+	result.address = bits.ReverseBytes16(uint16(value))
+	if result.address < cartridge.CARTRIDGE_ROM_SIZE {
+		return result, fmt.Errorf("address 0x%04x inside the ROM", result.address)
+	}
+
+	if bank&GAMESHARK_BANK_SELECT != 0 {
+		result.bank = int(bank & 0x0f)
+		result.hasBank = true
+	}
+
+	return result, nil
+}
+
+// parse a cheat made of Game Genie or GameShark codes separated by "+"
+func ParseCheat(code string, description string) (*Cheat, error) {
+	var cheat = Cheat{Code: strings.ToUpper(code), Description: description, Enabled: true}
+
+	for _, value := range strings.Split(cheat.Code, CHEAT_SEPARATOR) {
+		var parsed cheatCode
+		var err error
+
+		if strings.Contains(value, "-") {
+			parsed, err = parseGameGenie(value)
+		} else {
+			parsed, err = parseGameShark(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cheat code %s: %w", value, err)
+		}
+
+		cheat.codes = append(cheat.codes, parsed)
+	}
+
+	return &cheat, nil
+}
+
+// load the cheats of a text file: one cheat per line followed by its description, lines starting with "#"
+// are comments and cheats starting with "!" are loaded disabled
+func LoadCheats(fileName string) ([]*Cheat, error) {
+	var cheats []*Cheat
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, CHEAT_FILE_COMMENT) {
+			continue
+		}
+
+		code, description, _ := strings.Cut(text, " ")
+		disabled := strings.HasPrefix(code, CHEAT_FILE_DISABLED)
+
+		cheat, err := ParseCheat(strings.TrimPrefix(code, CHEAT_FILE_DISABLED), strings.TrimSpace(description))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", fileName, line, err)
+		}
+		cheat.Enabled = !disabled
+
+		cheats = append(cheats, cheat)
+	}
+
+	return cheats, scanner.Err()
+}
+
+// the cheats of a system: the enabled ROM patches are looked up by address on every ROM read, and the
+// RAM writes are applied in order at the start of every frame (the ROM image is never modified)
+type cheatEngine struct {
+	cheats     []*Cheat
+	romPatches map[uint16][]cheatCode
+	ramWrites  []cheatCode
+}
+
+// rebuild the lookup of the enabled codes
+func (c *cheatEngine) update() {
+
+	c.romPatches = nil
+	c.ramWrites = nil
+
+	for _, cheat := range c.cheats {
+		if !cheat.Enabled {
+			continue
+		}
+
+		for _, code := range cheat.codes {
+			if code.format == CHEAT_GAMESHARK {
+				c.ramWrites = append(c.ramWrites, code)
+				continue
+			}
+
+			if c.romPatches == nil {
+				c.romPatches = make(map[uint16][]cheatCode)
+			}
+			c.romPatches[code.address] = append(c.romPatches[code.address], code)
+		}
+	}
+}
+
+// apply the ROM patches to a byte read from the ROM
+func (c *cheatEngine) patchROM(address uint16, value uint8) uint8 {
+
+	for _, code := range c.romPatches[address] {
+		if !code.hasCompare || code.compare == value {
+			return code.value
+		}
+	}
+
+	return value
+}
+
+// add a cheat
+func (s *System) AddCheat(cheat *Cheat) {
+	s.cheats.cheats = append(s.cheats.cheats, cheat)
+	s.cheats.update()
+}
+
+// return the cheats in the order they were added
+func (s *System) Cheats() []*Cheat {
+	return s.cheats.cheats
+}
+
+// enable or disable a cheat (like the other system methods, not while a frame is running)
+func (s *System) EnableCheat(index int, enabled bool) error {
+
+	if index < 0 || index >= len(s.cheats.cheats) {
+		return fmt.Errorf("invalid cheat: %d (%d cheats)", index, len(s.cheats.cheats))
+	}
+
+	s.cheats.cheats[index].Enabled = enabled
+	s.cheats.update()
+
+	return nil
+}
+
+// remove all cheats
+func (s *System) RemoveCheats() {
+	s.cheats = cheatEngine{}
+}
+
+// apply the GameShark codes: banked writes go straight into their bank, the others through the bus
+func (s *System) applyCheats() {
+
+	for _, code := range s.cheats.ramWrites {
+		switch {
+		case code.hasBank && code.address >= cartridge.CARTRIDGE_RAM_ADDRESS &&
+			code.address < cartridge.CARTRIDGE_RAM_ADDRESS+cartridge.CARTRIDGE_RAM_SIZE:
+			s.cartridge.WriteRAMBank(code.bank, code.address-cartridge.CARTRIDGE_RAM_ADDRESS, code.value)
+
+		case code.hasBank && s.cgbMode && code.address >= GAMESHARK_WRAM_BANKED && code.address < ECHO_RAM_ADDRESS:
+			s.wram[max(code.bank&0x07, 1)][code.address-GAMESHARK_WRAM_BANKED] = code.value
+
+		default:
+			s.bus.WriteByte(code.address, code.value)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+////////////////////////////////////////////////////////////////////////////////
+//	cheat_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the cheat codes
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cartridge"
+)
+
+// cheat codes unit tests
+func Test_Cheat(t *testing.T) {
+
+	t.Run(">>> cheat: scenario 1 - parse codes", func(t *testing.T) {
+
+		for _, test := range []struct {
+			code     string
+			expected cheatCode
+		}{
+			{code: "3CA-2BB-6FE", expected: cheatCode{format: CHEAT_GAME_GENIE, address: 0x4a2b, value: 0x3c, compare: 0x21, hasCompare: true}},
+			{code: "3ca-2bb", expected: cheatCode{format: CHEAT_GAME_GENIE, address: 0x4a2b, value: 0x3c}},
+			{code: "01FF23C5", expected: cheatCode{format: CHEAT_GAMESHARK, address: 0xc523, value: 0xff}},
+			{code: "93BB10D0", expected: cheatCode{format: CHEAT_GAMESHARK, address: 0xd010, value: 0xbb, bank: 3, hasBank: true}},
+		} {
+			cheat, err := ParseCheat(test.code, "")
+			if err != nil || len(cheat.codes) != 1 || cheat.codes[0] != test.expected {
+				t.Errorf("failed parsing %s: expected: %+v\n\tresult: %+v (%v)", test.code, test.expected, cheat, err)
+			}
+		}
+
+		for _, code := range []string{"3CA-2BB-6F", "3CA-2BG", "01FF0040", "01FF23C", "01FF23C5+"} {
+			_, err := ParseCheat(code, "")
+			if err == nil {
+				t.Errorf("failed rejecting %s", code)
+			}
+		}
+	})
+
+	t.Run(">>> cheat: scenario 2 - Game Genie patches the ROM reads", func(t *testing.T) {
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+		rom := bytes.Clone(system.Cartridge().ROMImage())
+
+		//	INC A at 0x0100 becomes a NOP, so the counter stays at the initial value of A (1)
+		nop, _ := ParseCheat("001-00F-1EA", "freeze the counter")
+		mismatch, _ := ParseCheat("001-01F-1EA", "compare byte mismatch")
+		system.AddCheat(nop)
+		system.AddCheat(mismatch)
+
+		runFrameHashes(t, system, 2)
+		counter, _ := system.Bus().ReadByte(0xc000)
+		value, _ := system.Bus().ReadByte(0x0101)
+		if counter != 0x01 || value != rom[0x0101] || !bytes.Equal(system.Cartridge().ROMImage(), rom) {
+			t.Errorf("failed patching the ROM: expected: counter 1, ROM unchanged\n\tresult: counter %d, 0x0101 = 0x%02x", counter, value)
+		}
+
+		err := system.EnableCheat(0, false)
+		runFrameHashes(t, system, 1)
+		counter, _ = system.Bus().ReadByte(0xc000)
+		if err != nil || counter == 0x01 {
+			t.Errorf("failed disabling the cheat: expected: counter running\n\tresult: counter %d (%v)", counter, err)
+		}
+
+		if system.EnableCheat(2, true) == nil {
+			t.Errorf("failed rejecting an invalid cheat")
+		}
+	})
+
+	t.Run(">>> cheat: scenario 3 - GameShark writes the RAM every frame", func(t *testing.T) {
+
+		rom := buildCounterROM()
+		rom[cartridge.CARTRIDGE_CGB_FLAG_ADDRESS] = 0x80
+		rom[cartridge.CARTRIDGE_TYPE_ADDRESS] = 0x03
+		rom[cartridge.CARTRIDGE_RAM_SIZE_ADDRESS] = 0x03
+
+		system, _ := NewSystem(rom, MODEL_CGB, nil, false)
+		cheat, _ := ParseCheat("01AA00C1+93BB10D0+82CC00A0", "three writes")
+		system.AddCheat(cheat)
+
+		system.Bus().WriteByte(0xc100, 0x00)
+		runFrameHashes(t, system, 1)
+
+		wram, _ := system.Bus().ReadByte(0xc100)
+		mapped, _ := system.Bus().ReadByte(0xd010)
+
+		//	enable the cartridge RAM and map its bank 2 (MBC1 RAM banking mode)
+		system.Bus().WriteByte(0x0000, 0x0a)
+		system.Bus().WriteByte(0x6000, 0x01)
+		system.Bus().WriteByte(0x4000, 0x02)
+		cartridgeRAM, _ := system.Bus().ReadByte(0xa000)
+
+		if wram != 0xaa || mapped == 0xbb || system.wram[3][0x010] != 0xbb || cartridgeRAM != 0xcc {
+			t.Errorf("failed writing the RAM: expected: aa, bank 3 bb, cartridge bank 2 cc\n\tresult: %02x, mapped %02x, bank 3 %02x, cartridge %02x",
+				wram, mapped, system.wram[3][0x010], cartridgeRAM)
+		}
+
+		system.RemoveCheats()
+		system.Bus().WriteByte(0xc100, 0x00)
+		runFrameHashes(t, system, 1)
+		wram, _ = system.Bus().ReadByte(0xc100)
+		if wram != 0x00 || len(system.Cheats()) != 0 {
+			t.Errorf("failed removing the cheats: expected: 00\n\tresult: %02x", wram)
+		}
+	})
+
+	t.Run(">>> cheat: scenario 4 - cheat files", func(t *testing.T) {
+
+		fileName := filepath.Join(t.TempDir(), "game.cht")
+		os.WriteFile(fileName, []byte("# infinite lives\n001-00F-1EA freeze the counter\n\n!01AA00C1+01BB01C1 two writes\n"), 0644)
+
+		cheats, err := LoadCheats(fileName)
+		if err != nil || len(cheats) != 2 {
+			t.Fatalf("failed loading cheats: expected: 2 cheats\n\tresult: %d (%v)", len(cheats), err)
+		}
+		if cheats[0].Description != "freeze the counter" || !cheats[0].Enabled ||
+			cheats[1].Code != "01AA00C1+01BB01C1" || cheats[1].Enabled || len(cheats[1].codes) != 2 {
+			t.Errorf("failed loading cheats: expected: an enabled cheat and a disabled one with two codes\n\tresult: %+v, %+v", cheats[0], cheats[1])
+		}
+
+		os.WriteFile(fileName, []byte("001-00F-1EA\nZZZ-00F-1EA bad\n"), 0644)
+		_, err = LoadCheats(fileName)
+		if err == nil || !strings.Contains(err.Error(), "game.cht:2") {
+			t.Errorf("failed reporting the bad line: expected: game.cht:2\n\tresult: %v", err)
+		}
+	})
+}
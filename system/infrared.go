@@ -0,0 +1,151 @@
+////////////////////////////////////////////////////////////////////////////////
+//	infrared.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the CGB infrared port (RP register)
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"sync/atomic"
+
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// infrared memory map
+const (
+	INFRARED_REGISTER      = 0xff56
+	INFRARED_REGISTER_SIZE = 0x01
+)
+
+// RP flags
+const (
+	RP_LED_ON       = uint8(0x01)
+	RP_NOT_RECEIVED = uint8(0x02)
+	RP_UNUSED_BITS  = uint8(0x3c)
+	RP_READ_ENABLE  = uint8(0xc0)
+)
+
+// device in front of the infrared port: the light it emits is seen by the receiver
+type InfraredPeer interface {
+	LEDOn() bool
+}
+
+// infrared port LED and receiver
+type Infrared struct {
+	ledOn      atomic.Bool
+	readEnable uint8
+
+	peer InfraredPeer
+}
+
+// create a new infrared port
+func NewInfrared() *Infrared {
+	return &Infrared{}
+}
+
+// connect the device in front of the infrared port: the receiver only sees the peer LED, so without a peer
+// nothing is received (connecting the port to itself makes a loopback)
+func (i *Infrared) ConnectPeer(peer InfraredPeer) {
+	i.peer = peer
+}
+
+// connect two infrared ports facing each other
+func ConnectInfrared(a *Infrared, b *Infrared) {
+	a.ConnectPeer(b)
+	b.ConnectPeer(a)
+}
+
+// return true when the LED is on (InfraredPeer interface)
+func (i *Infrared) LEDOn() bool {
+	return i.ledOn.Load()
+}
+
+// return true when the receiver is enabled and sees light
+func (i *Infrared) receiving() bool {
+	return i.readEnable == RP_READ_ENABLE && i.peer != nil && i.peer.LEDOn()
+}
+
+// read the RP register
+func (i *Infrared) readRegister() uint8 {
+	var value = RP_UNUSED_BITS | i.readEnable
+
+	if i.LEDOn() {
+		value |= RP_LED_ON
+	}
+	if !i.receiving() {
+		value |= RP_NOT_RECEIVED
+	}
+
+	return value
+}
+
+// write the RP register (the received bit is read only)
+func (i *Infrared) writeRegister(value uint8) {
+	i.ledOn.Store(value&RP_LED_ON != 0)
+	i.readEnable = value & RP_READ_ENABLE
+}
+
+// return the RP register as a memory bank (0xff56)
+func (i *Infrared) Registers() memory.Memory {
+	return &infraredRegisters{infrared: i}
+}
+
+// write the infrared port state
+func (i *Infrared) SaveState(w *savestate.Writer) {
+	w.Section("IR")
+
+	w.Bool(i.ledOn.Load())
+	w.Uint8(i.readEnable)
+}
+
+// read the infrared port state
+func (i *Infrared) LoadState(r *savestate.Reader) error {
+	r.Section("IR")
+
+	i.ledOn.Store(r.Bool())
+	i.readEnable = r.Uint8()
+
+	return r.Err()
+}
+
+// infrared register view
+type infraredRegisters struct {
+	infrared *Infrared
+}
+
+// return memory bank size
+func (m *infraredRegisters) Len() uint16 {
+	return INFRARED_REGISTER_SIZE
+}
+
+// write the RP register
+func (m *infraredRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= INFRARED_REGISTER_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.infrared.writeRegister(value)
+
+	return nil
+}
+
+// read the RP register
+func (m *infraredRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= INFRARED_REGISTER_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.infrared.readRegister(), nil
+}
+
+// write a word into the infrared register
+func (m *infraredRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from the infrared register
+func (m *infraredRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
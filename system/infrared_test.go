@@ -0,0 +1,62 @@
+////////////////////////////////////////////////////////////////////////////////
+//	infrared_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the CGB infrared port
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"testing"
+)
+
+// infrared port unit tests
+func Test_Infrared(t *testing.T) {
+
+	t.Run(">>> infrared: scenario 1 - two ports facing each other", func(t *testing.T) {
+
+		sender := NewInfrared()
+		receiver := NewInfrared()
+		ConnectInfrared(sender, receiver)
+
+		testScenarios := []struct {
+			senderRP   uint8
+			receiverRP uint8
+			expected   uint8
+		}{
+			{senderRP: 0x00, receiverRP: 0xc0, expected: 0xfe},
+			{senderRP: 0x01, receiverRP: 0xc0, expected: 0xfc},
+			{senderRP: 0x01, receiverRP: 0x00, expected: 0x3e},
+			{senderRP: 0x01, receiverRP: 0xc1, expected: 0xfd},
+		}
+
+		for _, test := range testScenarios {
+			sender.Registers().WriteByte(0, test.senderRP)
+			receiver.Registers().WriteByte(0, test.receiverRP)
+
+			got, _ := receiver.Registers().ReadByte(0)
+			if got != test.expected {
+				t.Errorf("failed reading RP with sender 0x%02x and receiver 0x%02x: expected: 0x%02x\n\tresult: 0x%02x",
+					test.senderRP, test.receiverRP, test.expected, got)
+			}
+		}
+	})
+
+	t.Run(">>> infrared: scenario 2 - loopback and no peer", func(t *testing.T) {
+
+		infrared := NewInfrared()
+		infrared.Registers().WriteByte(0, RP_READ_ENABLE|RP_LED_ON)
+
+		got, _ := infrared.Registers().ReadByte(0)
+		if got != 0xff {
+			t.Errorf("failed reading RP without peer: expected: 0xff\n\tresult: 0x%02x", got)
+		}
+
+		infrared.ConnectPeer(infrared)
+
+		got, _ = infrared.Registers().ReadByte(0)
+		if got != 0xfd {
+			t.Errorf("failed reading RP in loopback: expected: 0xfd\n\tresult: 0x%02x", got)
+		}
+	})
+}
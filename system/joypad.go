@@ -0,0 +1,257 @@
+////////////////////////////////////////////////////////////////////////////////
+//	joypad.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the Game Boy joypad (P1 register)
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// joypad memory map
+const (
+	JOYPAD_REGISTER      = 0xff00
+	JOYPAD_REGISTER_SIZE = 0x01
+)
+
+// buttons bits (set when pressed): the low nibble is the directions row and the high nibble the buttons row
+const (
+	BUTTON_RIGHT  = uint8(0x01)
+	BUTTON_LEFT   = uint8(0x02)
+	BUTTON_UP     = uint8(0x04)
+	BUTTON_DOWN   = uint8(0x08)
+	BUTTON_A      = uint8(0x10)
+	BUTTON_B      = uint8(0x20)
+	BUTTON_SELECT = uint8(0x40)
+	BUTTON_START  = uint8(0x80)
+)
+
+// P1 flags (the rows are selected when the bit is 0)
+const (
+	P1_SELECT_DIRECTIONS = uint8(0x10)
+	P1_SELECT_BUTTONS    = uint8(0x20)
+	P1_SELECT_MASK       = uint8(0x30)
+	P1_LINES_MASK        = uint8(0x0f)
+	P1_UNUSED_BITS       = uint8(0xc0)
+)
+
+// source of the pressed buttons, polled once per emulated frame
+type InputSource interface {
+	Buttons(frame uint64) uint8
+}
+
+// function used as an input source
+type InputFunc func(frame uint64) uint8
+
+// return the pressed buttons (InputSource interface)
+func (f InputFunc) Buttons(frame uint64) uint8 {
+	return f(frame)
+}
+
+// buttons names in bit order
+var buttonNames = [8]string{"RIGHT", "LEFT", "UP", "DOWN", "A", "B", "SELECT", "START"}
+
+// format the pressed buttons as a list of names (e.g. "A+START"), or "-" when none is pressed
+func FormatButtons(buttons uint8) string {
+	var names []string
+
+	for i, name := range buttonNames {
+		if buttons&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return "-"
+	}
+
+	return strings.Join(names, "+")
+}
+
+// parse a list of buttons names (e.g. "a+start", "-" for none)
+func ParseButtons(value string) (uint8, error) {
+	var buttons uint8
+
+	value = strings.TrimSpace(value)
+	if value == "-" || value == "" {
+		return 0, nil
+	}
+
+	for _, name := range strings.Split(value, "+") {
+		found := false
+
+		for i, buttonName := range buttonNames {
+			if strings.EqualFold(strings.TrimSpace(name), buttonName) {
+				buttons |= 1 << i
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return 0, fmt.Errorf("invalid button name: %s", name)
+		}
+	}
+
+	return buttons, nil
+}
+
+// joypad state and P1 register
+type Joypad struct {
+	selection uint8
+	buttons   uint8
+	frame     uint64
+
+	source           InputSource
+	requestInterrupt cpu.InterruptRequester
+}
+
+// create a new joypad with no row selected
+func NewJoypad() *Joypad {
+
+	return &Joypad{
+		selection: P1_SELECT_MASK,
+	}
+}
+
+// connect the source of the pressed buttons
+func (j *Joypad) ConnectInput(source InputSource) {
+	j.source = source
+}
+
+// connect the interrupt requester
+func (j *Joypad) ConnectInterrupt(requestInterrupt cpu.InterruptRequester) {
+	j.requestInterrupt = requestInterrupt
+}
+
+// poll the input source at the start of a frame
+func (j *Joypad) PollInput() {
+	if j.source != nil {
+		j.SetButtons(j.source.Buttons(j.frame))
+	}
+
+	j.frame++
+}
+
+// return the number of polled frames
+func (j *Joypad) Frame() uint64 {
+	return j.frame
+}
+
+// return the pressed buttons
+func (j *Joypad) Buttons() uint8 {
+	return j.buttons
+}
+
+// set the pressed buttons
+func (j *Joypad) SetButtons(buttons uint8) {
+	j.update(j.selection, buttons)
+}
+
+// return the P1 input lines (0 when a button of a selected row is pressed)
+func (j *Joypad) lines() uint8 {
+	var lines = P1_LINES_MASK
+
+	if j.selection&P1_SELECT_DIRECTIONS == 0 {
+		lines &^= j.buttons & 0x0f
+	}
+	if j.selection&P1_SELECT_BUTTONS == 0 {
+		lines &^= j.buttons >> 4
+	}
+
+	return lines
+}
+
+// change the selection or the buttons requesting the joypad interrupt when a line goes from high to low
+func (j *Joypad) update(selection uint8, buttons uint8) {
+	before := j.lines()
+
+	j.selection = selection & P1_SELECT_MASK
+	j.buttons = buttons
+
+	if before&^j.lines() != 0 && j.requestInterrupt != nil {
+		j.requestInterrupt(cpu.INTERRUPT_JOYPAD)
+	}
+}
+
+// read the P1 register
+func (j *Joypad) readRegister() uint8 {
+	return P1_UNUSED_BITS | j.selection | j.lines()
+}
+
+// write the P1 register (only the selection bits are writable)
+func (j *Joypad) writeRegister(value uint8) {
+	j.update(value, j.buttons)
+}
+
+// return the P1 register as a memory bank (0xff00)
+func (j *Joypad) Registers() memory.Memory {
+	return &joypadRegisters{joypad: j}
+}
+
+// write the joypad state
+func (j *Joypad) SaveState(w *savestate.Writer) {
+	w.Section("JOYP")
+
+	w.Uint8(j.selection)
+	w.Uint8(j.buttons)
+	w.Uint64(j.frame)
+}
+
+// read the joypad state
+func (j *Joypad) LoadState(r *savestate.Reader) error {
+	r.Section("JOYP")
+
+	j.selection = r.Uint8()
+	j.buttons = r.Uint8()
+	j.frame = r.Uint64()
+
+	return r.Err()
+}
+
+// joypad register view
+type joypadRegisters struct {
+	joypad *Joypad
+}
+
+// return memory bank size
+func (m *joypadRegisters) Len() uint16 {
+	return JOYPAD_REGISTER_SIZE
+}
+
+// write the P1 register
+func (m *joypadRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= JOYPAD_REGISTER_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.joypad.writeRegister(value)
+
+	return nil
+}
+
+// read the P1 register
+func (m *joypadRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= JOYPAD_REGISTER_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.joypad.readRegister(), nil
+}
+
+// write a word into the joypad register
+func (m *joypadRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from the joypad register
+func (m *joypadRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
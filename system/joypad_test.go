@@ -0,0 +1,117 @@
+////////////////////////////////////////////////////////////////////////////////
+//	joypad_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy joypad
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// joypad unit tests
+func Test_Joypad(t *testing.T) {
+
+	t.Run(">>> joypad: scenario 1 - P1 row selection", func(t *testing.T) {
+
+		joypad := NewJoypad()
+		p1 := joypad.Registers()
+
+		joypad.SetButtons(BUTTON_LEFT | BUTTON_START)
+
+		testScenarios := []struct {
+			selection uint8
+			expected  uint8
+		}{
+			{selection: 0x30, expected: 0xff},
+			{selection: 0x20, expected: 0xed},
+			{selection: 0x10, expected: 0xd7},
+			{selection: 0x00, expected: 0xc5},
+		}
+
+		for _, test := range testScenarios {
+			p1.WriteByte(0, test.selection|0x0f)
+
+			got, _ := p1.ReadByte(0)
+			if got != test.expected {
+				t.Errorf("failed reading P1 with selection 0x%02x: expected: 0x%02x\n\tresult: 0x%02x", test.selection, test.expected, got)
+			}
+		}
+	})
+
+	t.Run(">>> joypad: scenario 2 - interrupt on high to low transitions", func(t *testing.T) {
+
+		var requests uint8
+		var count int
+
+		joypad := NewJoypad()
+		joypad.ConnectInterrupt(func(interrupt uint8) {
+			requests |= interrupt
+			count++
+		})
+
+		//	pressing a button of a not selected row does not change the lines
+		joypad.Registers().WriteByte(0, P1_SELECT_BUTTONS)
+		joypad.SetButtons(BUTTON_A)
+		if count != 0 {
+			t.Errorf("failed joypad interrupt: unexpected request for a not selected row")
+		}
+
+		joypad.SetButtons(BUTTON_A | BUTTON_DOWN)
+		if count != 1 || requests != cpu.INTERRUPT_JOYPAD {
+			t.Errorf("failed joypad interrupt: expected one request\n\tresult: %d (IF 0x%02x)", count, requests)
+		}
+
+		//	releasing a button is a low to high transition
+		joypad.SetButtons(BUTTON_A)
+		if count != 1 {
+			t.Errorf("failed joypad interrupt: unexpected request on release")
+		}
+
+		//	selecting the row of a pressed button also pulls a line low
+		joypad.Registers().WriteByte(0, P1_SELECT_DIRECTIONS)
+		if count != 2 {
+			t.Errorf("failed joypad interrupt: expected request on row selection")
+		}
+	})
+
+	t.Run(">>> joypad: scenario 3 - input source polled per frame", func(t *testing.T) {
+
+		joypad := NewJoypad()
+		joypad.ConnectInput(InputFunc(func(frame uint64) uint8 {
+			if frame == 2 {
+				return BUTTON_START
+			}
+			return 0
+		}))
+
+		var pressed []uint8
+		for range 4 {
+			joypad.PollInput()
+			pressed = append(pressed, joypad.Buttons())
+		}
+
+		if pressed[1] != 0 || pressed[2] != BUTTON_START || pressed[3] != 0 {
+			t.Errorf("failed polling input source: expected START on frame 2\n\tresult: %v", pressed)
+		}
+	})
+
+	t.Run(">>> joypad: scenario 4 - buttons names", func(t *testing.T) {
+
+		buttons, err := ParseButtons("a+Start+up")
+		if err != nil || buttons != BUTTON_A|BUTTON_START|BUTTON_UP {
+			t.Errorf("failed parsing buttons: expected: 0x94\n\tresult: 0x%02x", buttons)
+		}
+		if FormatButtons(buttons) != "UP+A+START" || FormatButtons(0) != "-" {
+			t.Errorf("failed formatting buttons: %s", FormatButtons(buttons))
+		}
+
+		_, err = ParseButtons("a+turbo")
+		if err == nil {
+			t.Errorf("expected error for invalid button name")
+		}
+	})
+}
@@ -0,0 +1,227 @@
+////////////////////////////////////////////////////////////////////////////////
+//	link.go - Oct-18-2026 by aldebap
+//
+//	link cable between two emulators over TCP or an in-process pipe
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"io"
+	"net"
+)
+
+// link protocol: every message has two bytes, the message type and a value
+const (
+	LINK_TRANSFER          = uint8(0x01)
+	LINK_REPLY             = uint8(0x02)
+	LINK_REPLY_NO_TRANSFER = uint8(0x03)
+	LINK_SYNC              = uint8(0x04)
+	LINK_MESSAGE_SIZE      = 2
+	LINK_INCOMING_BUFFER   = 64
+)
+
+// link cable connecting the serial port to another emulator:
+//
+//   - when the local Game Boy drives the clock, the byte is sent in a transfer message and
+//     the emulator waits for the reply with the byte shifted out by the other side
+//   - transfer messages from the other side are answered while this side waits (in Exchange or Sync),
+//     with the byte in SB when an externally clocked transfer was started, or with no transfer
+//   - Sync is a barrier both emulators must call at the same points (e.g. at the end of every frame),
+//     keeping them in lockstep: the more often it is called, the lower the latency of externally clocked transfers
+type LinkCable struct {
+	serial *Serial
+	conn   io.ReadWriteCloser
+
+	incoming  chan [LINK_MESSAGE_SIZE]uint8
+	readError error
+
+	peerSyncs int
+	err       error
+}
+
+// create a link cable over a connection, connecting it to the serial port
+func NewLinkCable(serial *Serial, conn io.ReadWriteCloser) *LinkCable {
+	var link = &LinkCable{
+		serial:   serial,
+		conn:     conn,
+		incoming: make(chan [LINK_MESSAGE_SIZE]uint8, LINK_INCOMING_BUFFER),
+	}
+
+	serial.ConnectPeer(link)
+	go link.receive()
+
+	return link
+}
+
+// connect to an emulator listening for a link cable
+func DialLink(address string, serial *Serial) (*LinkCable, error) {
+
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLinkCable(serial, conn), nil
+}
+
+// wait for an emulator connecting a link cable
+func AcceptLink(listener net.Listener, serial *Serial) (*LinkCable, error) {
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLinkCable(serial, conn), nil
+}
+
+// listen on an address until an emulator connects a link cable
+func ListenLink(address string, serial *Serial) (*LinkCable, error) {
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	return AcceptLink(listener, serial)
+}
+
+// link two serial ports in the same process
+func NewLinkPipe(serialA *Serial, serialB *Serial) (*LinkCable, *LinkCable) {
+
+	connA, connB := net.Pipe()
+
+	return NewLinkCable(serialA, connA), NewLinkCable(serialB, connB)
+}
+
+// read the messages sent by the other side, so writes never block on a busy receiver
+func (l *LinkCable) receive() {
+	var message [LINK_MESSAGE_SIZE]uint8
+
+	for {
+		_, err := io.ReadFull(l.conn, message[:])
+		if err != nil {
+			l.readError = err
+			close(l.incoming)
+			return
+		}
+
+		l.incoming <- message
+	}
+}
+
+// send a message to the other side
+func (l *LinkCable) send(messageType uint8, value uint8) error {
+
+	_, err := l.conn.Write([]uint8{messageType, value})
+	if err != nil {
+		return fmt.Errorf("link cable disconnected: %s", err.Error())
+	}
+
+	return nil
+}
+
+// wait for the next message, answering the transfers requested by the other side
+func (l *LinkCable) next() ([LINK_MESSAGE_SIZE]uint8, error) {
+
+	for {
+		message, ok := <-l.incoming
+		if !ok {
+			return message, fmt.Errorf("link cable disconnected: %s", l.readError.Error())
+		}
+
+		if message[0] != LINK_TRANSFER {
+			return message, nil
+		}
+
+		sent, ok := l.serial.ExternalTransfer(message[1])
+		reply := LINK_REPLY
+		if !ok {
+			reply = LINK_REPLY_NO_TRANSFER
+		}
+
+		err := l.send(reply, sent)
+		if err != nil {
+			return message, err
+		}
+	}
+}
+
+// transfer a byte clocked by the local serial port (SerialPeer interface)
+func (l *LinkCable) Exchange(value uint8) uint8 {
+	if l.err != nil {
+		return SERIAL_DISCONNECTED
+	}
+
+	l.err = l.send(LINK_TRANSFER, value)
+
+	for l.err == nil {
+		var message [LINK_MESSAGE_SIZE]uint8
+
+		message, l.err = l.next()
+		if l.err != nil {
+			break
+		}
+
+		switch message[0] {
+		case LINK_REPLY:
+			return message[1]
+
+		case LINK_REPLY_NO_TRANSFER:
+			return SERIAL_DISCONNECTED
+
+		case LINK_SYNC:
+			l.peerSyncs++
+
+		default:
+			l.err = fmt.Errorf("invalid link message: 0x%02x", message[0])
+		}
+	}
+
+	return SERIAL_DISCONNECTED
+}
+
+// wait until the other side reaches the same synchronization point
+func (l *LinkCable) Sync() error {
+	if l.err != nil {
+		return l.err
+	}
+
+	l.err = l.send(LINK_SYNC, 0)
+
+	for l.err == nil && l.peerSyncs == 0 {
+		var message [LINK_MESSAGE_SIZE]uint8
+
+		message, l.err = l.next()
+		if l.err != nil {
+			break
+		}
+
+		if message[0] != LINK_SYNC {
+			l.err = fmt.Errorf("unexpected link message: 0x%02x", message[0])
+			break
+		}
+		l.peerSyncs++
+	}
+
+	if l.err != nil {
+		return l.err
+	}
+
+	l.peerSyncs--
+
+	return nil
+}
+
+// return the error that broke the link, if any
+func (l *LinkCable) Err() error {
+	return l.err
+}
+
+// disconnect the link cable
+func (l *LinkCable) Close() error {
+	return l.conn.Close()
+}
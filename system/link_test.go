@@ -0,0 +1,153 @@
+////////////////////////////////////////////////////////////////////////////////
+//	link_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the link cable
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"net"
+	"testing"
+)
+
+// number of frames exchanged by the link tests
+const (
+	testLinkFrames = 16
+)
+
+// master side: send one byte per frame with the internal clock
+func runLinkMaster(serial *Serial, link *LinkCable) error {
+
+	for i := range testLinkFrames {
+		sendSerialByte(serial, uint8(i), SC_TRANSFER_START|SC_INTERNAL_CLOCK)
+		serial.Step(8 * SERIAL_BIT_CYCLES)
+
+		err := link.Sync()
+		if err != nil {
+			return err
+		}
+
+		sb, _ := serial.Registers().ReadByte(REG_SB)
+		if sb != 0x80+uint8(i) {
+			return fmt.Errorf("master received on frame %d: expected: 0x%02x\n\tresult: 0x%02x", i, 0x80+i, sb)
+		}
+	}
+
+	return nil
+}
+
+// slave side: wait for one byte per frame with the external clock
+func runLinkSlave(serial *Serial, link *LinkCable) error {
+
+	for i := range testLinkFrames {
+		sendSerialByte(serial, 0x80+uint8(i), SC_TRANSFER_START)
+		serial.Step(8 * SERIAL_BIT_CYCLES)
+
+		err := link.Sync()
+		if err != nil {
+			return err
+		}
+
+		sb, _ := serial.Registers().ReadByte(REG_SB)
+		if sb != uint8(i) || serial.WaitingExternalClock() {
+			return fmt.Errorf("slave received on frame %d: expected: 0x%02x\n\tresult: 0x%02x", i, i, sb)
+		}
+	}
+
+	return nil
+}
+
+// run both sides of a link in lockstep
+func runLinkedSerials(t *testing.T, master *Serial, masterLink *LinkCable, slave *Serial, slaveLink *LinkCable) {
+	done := make(chan error)
+
+	go func() {
+		done <- runLinkSlave(slave, slaveLink)
+	}()
+
+	err := runLinkMaster(master, masterLink)
+	if err != nil {
+		t.Errorf("failed link transfer: %s", err.Error())
+	}
+
+	err = <-done
+	if err != nil {
+		t.Errorf("failed link transfer: %s", err.Error())
+	}
+}
+
+// link cable unit tests
+func Test_LinkCable(t *testing.T) {
+
+	t.Run(">>> link cable: scenario 1 - in-process pipe", func(t *testing.T) {
+
+		master := NewSerial(false)
+		slave := NewSerial(false)
+
+		masterLink, slaveLink := NewLinkPipe(master, slave)
+		defer masterLink.Close()
+		defer slaveLink.Close()
+
+		runLinkedSerials(t, master, masterLink, slave, slaveLink)
+	})
+
+	t.Run(">>> link cable: scenario 2 - TCP on localhost", func(t *testing.T) {
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skipf("TCP not available: %s", err.Error())
+		}
+		defer listener.Close()
+
+		master := NewSerial(true)
+		slave := NewSerial(true)
+
+		accepted := make(chan *LinkCable)
+		go func() {
+			link, _ := AcceptLink(listener, slave)
+			accepted <- link
+		}()
+
+		masterLink, err := DialLink(listener.Addr().String(), master)
+		if err != nil {
+			t.Fatalf("fail connecting link: %s", err.Error())
+		}
+		defer masterLink.Close()
+
+		slaveLink := <-accepted
+		if slaveLink == nil {
+			t.Fatalf("fail accepting link")
+		}
+		defer slaveLink.Close()
+
+		runLinkedSerials(t, master, masterLink, slave, slaveLink)
+	})
+
+	t.Run(">>> link cable: scenario 3 - no transfer when the other side is not waiting", func(t *testing.T) {
+
+		master := NewSerial(false)
+		idle := NewSerial(false)
+
+		masterLink, idleLink := NewLinkPipe(master, idle)
+		defer masterLink.Close()
+
+		go idleLink.Sync()
+
+		sendSerialByte(master, 0x55, SC_TRANSFER_START|SC_INTERNAL_CLOCK)
+		master.Step(8 * SERIAL_BIT_CYCLES)
+		masterLink.Sync()
+
+		sb, _ := master.Registers().ReadByte(REG_SB)
+		if sb != SERIAL_DISCONNECTED {
+			t.Errorf("failed idle transfer: expected: 0xff\n\tresult: 0x%02x", sb)
+		}
+
+		//	a closed link breaks the following transfers
+		idleLink.Close()
+		if masterLink.Sync() == nil {
+			t.Errorf("expected error for a disconnected link")
+		}
+	})
+}
@@ -0,0 +1,449 @@
+////////////////////////////////////////////////////////////////////////////////
+//	movie.go - Oct-18-2026 by aldebap
+//
+//	input movies: record the joypad state of every frame and replay it
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// movie file format:
+//
+//	GBC-MOVIE 2
+//	rom 1a2b3c4d
+//	boot-rom 5e6f7a8b
+//	cheat 01FF16D0
+//	setting cgb true
+//	frames
+//	A+START 0123456789abcdef
+//	- 0123456789abcdef
+//
+// the boot ROM line is written when the movie starts with the boot sequence, and there is one cheat line for
+// each cheat enabled at power on (version 1 movies have neither); every line after "frames" holds the pressed
+// buttons and the framebuffer hash at the end of the frame ("-" when unknown)
+const (
+	MOVIE_SIGNATURE = "GBC-MOVIE"
+	MOVIE_VERSION   = 2
+	MOVIE_NO_HASH   = "-"
+)
+
+// movie session modes
+const (
+	MOVIE_RECORD   = uint8(1)
+	MOVIE_PLAYBACK = uint8(2)
+	MOVIE_VERIFY   = uint8(3)
+)
+
+// joypad state and framebuffer hash of one frame
+type movieFrame struct {
+	buttons         uint8
+	framebufferHash uint64
+	hasHash         bool
+}
+
+// input movie
+type Movie struct {
+	romChecksum     uint32
+	bootROMChecksum uint32
+	hasBootROM      bool
+	cheats          []string
+	settings        map[string]string
+	frames          []movieFrame
+}
+
+// return the checksum identifying a ROM in a movie (CRC-32 of the whole ROM)
+func ROMChecksum(rom []uint8) uint32 {
+	return crc32.ChecksumIEEE(rom)
+}
+
+// return the codes of the enabled cheats
+func enabledCheatCodes(cheats []*Cheat) []string {
+	var codes []string
+
+	for _, cheat := range cheats {
+		if cheat.Enabled {
+			codes = append(codes, cheat.Code)
+		}
+	}
+
+	return codes
+}
+
+// create a new empty movie for a ROM, the boot ROM (nil when the boot sequence is skipped),
+// the cheats and the emulator settings
+func NewMovie(rom []uint8, bootROM []uint8, cheats []*Cheat, settings map[string]string) *Movie {
+	var movie = &Movie{
+		romChecksum: ROMChecksum(rom),
+		hasBootROM:  bootROM != nil,
+		cheats:      enabledCheatCodes(cheats),
+		settings:    make(map[string]string),
+	}
+
+	if bootROM != nil {
+		movie.bootROMChecksum = ROMChecksum(bootROM)
+	}
+
+	for key, value := range settings {
+		movie.settings[key] = value
+	}
+
+	return movie
+}
+
+// return the ROM checksum
+func (m *Movie) ROMChecksum() uint32 {
+	return m.romChecksum
+}
+
+// return the boot ROM checksum, and false when the movie skips the boot sequence
+func (m *Movie) BootROMChecksum() (uint32, bool) {
+	return m.bootROMChecksum, m.hasBootROM
+}
+
+// return the codes of the cheats enabled at power on
+func (m *Movie) Cheats() []string {
+	return m.cheats
+}
+
+// return an emulator setting
+func (m *Movie) Setting(key string) (string, bool) {
+	value, ok := m.settings[key]
+
+	return value, ok
+}
+
+// return the number of frames
+func (m *Movie) Len() int {
+	return len(m.frames)
+}
+
+// return the buttons pressed in a frame
+func (m *Movie) Buttons(frame int) uint8 {
+	if frame < 0 || frame >= len(m.frames) {
+		return 0
+	}
+
+	return m.frames[frame].buttons
+}
+
+// write the movie in text format
+func (m *Movie) Write(w io.Writer) error {
+	var writer = bufio.NewWriter(w)
+	var keys []string
+
+	fmt.Fprintf(writer, "%s %d\n", MOVIE_SIGNATURE, MOVIE_VERSION)
+	fmt.Fprintf(writer, "rom %08x\n", m.romChecksum)
+	if m.hasBootROM {
+		fmt.Fprintf(writer, "boot-rom %08x\n", m.bootROMChecksum)
+	}
+	for _, code := range m.cheats {
+		fmt.Fprintf(writer, "cheat %s\n", code)
+	}
+
+	for key := range m.settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fmt.Fprintf(writer, "setting %s %s\n", key, m.settings[key])
+	}
+
+	fmt.Fprintln(writer, "frames")
+
+	for _, frame := range m.frames {
+		hash := MOVIE_NO_HASH
+		if frame.hasHash {
+			hash = fmt.Sprintf("%016x", frame.framebufferHash)
+		}
+
+		fmt.Fprintf(writer, "%s %s\n", FormatButtons(frame.buttons), hash)
+	}
+
+	return writer.Flush()
+}
+
+// save the movie into a file
+func (m *Movie) Save(fileName string) error {
+
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = m.Write(file)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// read a movie in text format
+func ReadMovie(r io.Reader) (*Movie, error) {
+	var scanner = bufio.NewScanner(r)
+	var movie = &Movie{
+		settings: make(map[string]string),
+	}
+	var line int
+	var signed, inFrames bool
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+
+		if !signed {
+			if len(fields) != 2 || fields[0] != MOVIE_SIGNATURE {
+				return nil, fmt.Errorf("invalid movie signature: %s", text)
+			}
+			version, err := strconv.Atoi(fields[1])
+			if err != nil || version < 1 || version > MOVIE_VERSION {
+				return nil, fmt.Errorf("invalid movie signature: %s", text)
+			}
+			signed = true
+			continue
+		}
+
+		if inFrames {
+			frame, err := parseMovieFrame(fields)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err.Error())
+			}
+
+			movie.frames = append(movie.frames, frame)
+			continue
+		}
+
+		switch {
+		case fields[0] == "rom" && len(fields) == 2:
+			checksum, err := strconv.ParseUint(fields[1], 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid ROM checksum: %s", line, fields[1])
+			}
+			movie.romChecksum = uint32(checksum)
+
+		case fields[0] == "boot-rom" && len(fields) == 2:
+			checksum, err := strconv.ParseUint(fields[1], 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid boot ROM checksum: %s", line, fields[1])
+			}
+			movie.bootROMChecksum = uint32(checksum)
+			movie.hasBootROM = true
+
+		case fields[0] == "cheat" && len(fields) == 2:
+			movie.cheats = append(movie.cheats, fields[1])
+
+		case fields[0] == "setting" && len(fields) >= 2:
+			movie.settings[fields[1]] = strings.Join(fields[2:], " ")
+
+		case fields[0] == "frames" && len(fields) == 1:
+			inFrames = true
+
+		default:
+			return nil, fmt.Errorf("line %d: invalid movie header: %s", line, text)
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if !signed {
+		return nil, fmt.Errorf("empty movie")
+	}
+	if !inFrames {
+		return nil, fmt.Errorf("missing movie frames")
+	}
+
+	return movie, nil
+}
+
+// parse a frame line: buttons and framebuffer hash
+func parseMovieFrame(fields []string) (movieFrame, error) {
+	var frame movieFrame
+
+	if len(fields) != 2 {
+		return frame, fmt.Errorf("invalid movie frame: %s", strings.Join(fields, " "))
+	}
+
+	buttons, err := ParseButtons(fields[0])
+	if err != nil {
+		return frame, err
+	}
+	frame.buttons = buttons
+
+	if fields[1] != MOVIE_NO_HASH {
+		hash, err := strconv.ParseUint(fields[1], 16, 64)
+		if err != nil {
+			return frame, fmt.Errorf("invalid framebuffer hash: %s", fields[1])
+		}
+		frame.framebufferHash = hash
+		frame.hasHash = true
+	}
+
+	return frame, nil
+}
+
+// load a movie from a file
+func LoadMovie(fileName string) (*Movie, error) {
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadMovie(file)
+}
+
+// record or replay a movie on the joypad input path: it is the joypad input source
+// and must be told about the end of every frame
+type MovieSession struct {
+	mode   uint8
+	movie  *Movie
+	source InputSource
+	frame  int
+}
+
+// create a session recording the buttons given by an input source
+func NewMovieRecorder(rom []uint8, bootROM []uint8, cheats []*Cheat, settings map[string]string, source InputSource) *MovieSession {
+
+	return &MovieSession{
+		mode:   MOVIE_RECORD,
+		movie:  NewMovie(rom, bootROM, cheats, settings),
+		source: source,
+	}
+}
+
+// describe a boot ROM checksum for the setup errors
+func describeBootROM(checksum uint32, hasBootROM bool) string {
+	if !hasBootROM {
+		return "no boot ROM"
+	}
+
+	return fmt.Sprintf("checksum %08x", checksum)
+}
+
+// describe cheat codes for the setup errors
+func describeCheats(codes []string) string {
+	if len(codes) == 0 {
+		return "no cheats"
+	}
+
+	return strings.Join(codes, " ")
+}
+
+// create a session replaying a movie, optionally verifying the framebuffer hashes: the ROM, the boot ROM
+// and the enabled cheats must be the ones the movie was recorded with
+func NewMoviePlayer(movie *Movie, rom []uint8, bootROM []uint8, cheats []*Cheat, verify bool) (*MovieSession, error) {
+
+	if ROMChecksum(rom) != movie.romChecksum {
+		return nil, fmt.Errorf("movie recorded with another ROM: expected checksum %08x, found %08x", movie.romChecksum, ROMChecksum(rom))
+	}
+
+	other := NewMovie(rom, bootROM, cheats, nil)
+	if other.hasBootROM != movie.hasBootROM || other.bootROMChecksum != movie.bootROMChecksum {
+		return nil, fmt.Errorf("movie recorded with another boot ROM: expected %s, found %s",
+			describeBootROM(movie.bootROMChecksum, movie.hasBootROM), describeBootROM(other.bootROMChecksum, other.hasBootROM))
+	}
+	if describeCheats(other.cheats) != describeCheats(movie.cheats) {
+		return nil, fmt.Errorf("movie recorded with other cheats: expected %s, found %s",
+			describeCheats(movie.cheats), describeCheats(other.cheats))
+	}
+
+	session := &MovieSession{
+		mode:  MOVIE_PLAYBACK,
+		movie: movie,
+	}
+	if verify {
+		session.mode = MOVIE_VERIFY
+	}
+
+	return session, nil
+}
+
+// return the session movie
+func (s *MovieSession) Movie() *Movie {
+	return s.movie
+}
+
+// return the session mode
+func (s *MovieSession) Mode() uint8 {
+	return s.mode
+}
+
+// return true when the replay reached the end of the movie
+func (s *MovieSession) Finished() bool {
+	return s.mode != MOVIE_RECORD && s.frame >= len(s.movie.frames)
+}
+
+// return the buttons of the current frame (InputSource interface)
+func (s *MovieSession) Buttons(frame uint64) uint8 {
+
+	if s.mode != MOVIE_RECORD {
+		return s.movie.Buttons(s.frame)
+	}
+
+	var buttons uint8
+	if s.source != nil {
+		buttons = s.source.Buttons(frame)
+	}
+
+	//	the buttons are polled once per frame, a second poll replaces them
+	if s.frame < len(s.movie.frames) {
+		s.movie.frames[s.frame].buttons = buttons
+	} else {
+		s.movie.frames = append(s.movie.frames, movieFrame{buttons: buttons})
+	}
+
+	return buttons
+}
+
+// signal the end of a frame: the framebuffer hash is recorded, or verified failing when the replay diverges
+func (s *MovieSession) EndFrame(framebuffer *ppu.Framebuffer) error {
+	var hash = framebuffer.Hash()
+
+	defer func() {
+		s.frame++
+	}()
+
+	switch s.mode {
+	case MOVIE_RECORD:
+		if s.frame == len(s.movie.frames) {
+			s.movie.frames = append(s.movie.frames, movieFrame{})
+		}
+		s.movie.frames[s.frame].framebufferHash = hash
+		s.movie.frames[s.frame].hasHash = true
+
+	case MOVIE_VERIFY:
+		if s.frame >= len(s.movie.frames) || !s.movie.frames[s.frame].hasHash {
+			return nil
+		}
+		if s.movie.frames[s.frame].framebufferHash != hash {
+			return fmt.Errorf("replay diverged on frame %d: expected framebuffer hash %016x, found %016x",
+				s.frame, s.movie.frames[s.frame].framebufferHash, hash)
+		}
+	}
+
+	return nil
+}
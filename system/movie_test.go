@@ -0,0 +1,273 @@
+////////////////////////////////////////////////////////////////////////////////
+//	movie_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for input movies
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// run a number of frames: the framebuffer depends on the pressed buttons and the previous frame
+func runMovieFrames(session *MovieSession, frames int) error {
+	joypad := NewJoypad()
+	joypad.ConnectInput(session)
+	framebuffer := ppu.NewFramebuffer(false)
+
+	for range frames {
+		joypad.PollInput()
+
+		shade, _ := framebuffer.DMGPixel(0, 0)
+		framebuffer.SetDMGPixel(int(joypad.Frame()), 0, shade^joypad.Buttons(), ppu.DMG_PALETTE_BG)
+
+		err := session.EndFrame(framebuffer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// build a ROM adding the joypad button lines into the work RAM in a loop
+func buildJoypadROM() []uint8 {
+	rom := buildLoopROM(0x00)
+
+	copy(rom[CARTRIDGE_ENTRY:], []uint8{
+		cpu.LD_A_n, P1_SELECT_DIRECTIONS,
+		cpu.LDH_ADDR_n_A, 0x00,
+		cpu.LDH_A_ADDR_n, 0x00,
+		cpu.LD_B_A,
+		cpu.LD_A_ADDR_nn, 0x00, 0xc0,
+		cpu.ADD_B,
+		cpu.LD_ADDR_nn_A, 0x00, 0xc0,
+		cpu.JR_e, 0xf4,
+	})
+
+	return rom
+}
+
+// run the joypad ROM with a movie session until the frames are run or the replay ends,
+// returning the state hash after each frame
+func runMovieSystem(t *testing.T, session *MovieSession, frames int) []uint64 {
+	var hashes []uint64
+
+	system, _ := NewSystem(buildJoypadROM(), MODEL_DMG, nil, false)
+	system.Joypad().ConnectInput(session)
+
+	for range frames {
+		if session.Finished() {
+			break
+		}
+
+		err := system.RunFrame()
+		if err == nil {
+			err = session.EndFrame(system.PPU().Framebuffer())
+		}
+		if err != nil {
+			t.Fatalf("failed running frame %d: %v", system.Frame(), err)
+		}
+		hashes = append(hashes, system.StateHash())
+	}
+
+	return hashes
+}
+
+// input movie unit tests
+func Test_Movie(t *testing.T) {
+	var rom = []uint8{0x00, 0xc3, 0x50, 0x01}
+
+	t.Run(">>> input movie: scenario 1 - record, save and verify the replay", func(t *testing.T) {
+
+		script := InputFunc(func(frame uint64) uint8 {
+			return []uint8{0, BUTTON_A, BUTTON_A | BUTTON_START, 0, BUTTON_DOWN}[frame%5]
+		})
+
+		recorder := NewMovieRecorder(rom, nil, nil, map[string]string{"cgb": "false"}, script)
+		err := runMovieFrames(recorder, 10)
+		if err != nil {
+			t.Fatalf("fail recording movie: %s", err.Error())
+		}
+
+		fileName := filepath.Join(t.TempDir(), "session.gbm")
+		err = recorder.Movie().Save(fileName)
+		if err != nil {
+			t.Fatalf("fail saving movie: %s", err.Error())
+		}
+
+		movie, err := LoadMovie(fileName)
+		if err != nil {
+			t.Fatalf("fail loading movie: %s", err.Error())
+		}
+		if movie.Len() != 10 || movie.Buttons(2) != BUTTON_A|BUTTON_START {
+			t.Errorf("failed loading movie frames: expected 10 frames\n\tresult: %d", movie.Len())
+		}
+		if value, _ := movie.Setting("cgb"); value != "false" {
+			t.Errorf("failed loading movie settings: expected cgb false\n\tresult: %s", value)
+		}
+
+		player, err := NewMoviePlayer(movie, rom, nil, nil, true)
+		if err != nil {
+			t.Fatalf("fail creating player: %s", err.Error())
+		}
+
+		err = runMovieFrames(player, 10)
+		if err != nil {
+			t.Errorf("failed verifying replay: %s", err.Error())
+		}
+		if !player.Finished() {
+			t.Errorf("failed replaying movie: expected the end of the movie")
+		}
+	})
+
+	t.Run(">>> input movie: scenario 2 - verification detects a diverging replay", func(t *testing.T) {
+
+		movie, err := ReadMovie(strings.NewReader("GBC-MOVIE 1\nrom 00000000\nframes\nA 0000000000000001\n"))
+		if err != nil {
+			t.Fatalf("fail reading movie: %s", err.Error())
+		}
+		movie.romChecksum = ROMChecksum(rom)
+
+		player, _ := NewMoviePlayer(movie, rom, nil, nil, true)
+		err = runMovieFrames(player, 1)
+		if err == nil {
+			t.Errorf("expected error for a diverging replay")
+		}
+
+		//	playback without verification ignores the hashes
+		player, _ = NewMoviePlayer(movie, rom, nil, nil, false)
+		err = runMovieFrames(player, 1)
+		if err != nil {
+			t.Errorf("failed replaying movie: %s", err.Error())
+		}
+	})
+
+	t.Run(">>> input movie: scenario 3 - write format and invalid movies", func(t *testing.T) {
+
+		recorder := NewMovieRecorder(rom, nil, nil, nil, nil)
+		recorder.Buttons(0)
+
+		var buffer bytes.Buffer
+		recorder.Movie().Write(&buffer)
+
+		expected := fmt.Sprintf("GBC-MOVIE 2\nrom %08x\nframes\n- -\n", ROMChecksum(rom))
+		if buffer.String() != expected {
+			t.Errorf("failed writing movie: expected: %q\n\tresult: %q", expected, buffer.String())
+		}
+
+		_, err := NewMoviePlayer(recorder.Movie(), []uint8{0x01}, nil, nil, false)
+		if err == nil {
+			t.Errorf("expected error for another ROM")
+		}
+
+		for _, text := range []string{"", "GBC-MOVIE 3\nframes\n", "GBC-MOVIE 1\nrom 0\n", "GBC-MOVIE 1\nframes\nTURBO -\n"} {
+			_, err = ReadMovie(strings.NewReader(text))
+			if err == nil {
+				t.Errorf("expected error for invalid movie: %q", text)
+			}
+		}
+	})
+
+	t.Run(">>> input movie: scenario 4 - boot ROM and cheats in the header", func(t *testing.T) {
+
+		var bootROM = make([]uint8, 0x100)
+
+		infiniteLives, _ := ParseCheat("01FF16D0", "infinite lives")
+		disabled, _ := ParseCheat("00A-17B", "disabled")
+		disabled.Enabled = false
+
+		recorder := NewMovieRecorder(rom, bootROM, []*Cheat{infiniteLives, disabled}, nil, nil)
+
+		var buffer bytes.Buffer
+		recorder.Movie().Write(&buffer)
+
+		movie, err := ReadMovie(&buffer)
+		if err != nil {
+			t.Fatalf("fail reading movie: %s", err.Error())
+		}
+
+		checksum, ok := movie.BootROMChecksum()
+		if !ok || checksum != ROMChecksum(bootROM) || strings.Join(movie.Cheats(), " ") != "01FF16D0" {
+			t.Errorf("failed reading movie header: expected boot ROM %08x and cheat 01FF16D0\n\tresult: %08x (%v) and %v",
+				ROMChecksum(bootROM), checksum, ok, movie.Cheats())
+		}
+
+		_, err = NewMoviePlayer(movie, rom, bootROM, []*Cheat{disabled, infiniteLives}, false)
+		if err != nil {
+			t.Errorf("failed replaying movie with the same setup: %s", err.Error())
+		}
+
+		for _, test := range []struct {
+			bootROM  []uint8
+			cheats   []*Cheat
+			expected string
+		}{
+			{bootROM: nil, cheats: []*Cheat{infiniteLives}, expected: "another boot ROM"},
+			{bootROM: make([]uint8, 0x900), cheats: []*Cheat{infiniteLives}, expected: "another boot ROM"},
+			{bootROM: bootROM, cheats: nil, expected: "expected 01FF16D0, found no cheats"},
+		} {
+			_, err = NewMoviePlayer(movie, rom, test.bootROM, test.cheats, false)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("failed checking movie setup: expected: %s\n\tresult: %v", test.expected, err)
+			}
+		}
+
+		//	version 1 movies skip the boot sequence and have no cheats
+		movie, err = ReadMovie(strings.NewReader(fmt.Sprintf("GBC-MOVIE 1\nrom %08x\nframes\n", ROMChecksum(rom))))
+		if err != nil {
+			t.Fatalf("fail reading version 1 movie: %s", err.Error())
+		}
+		_, err = NewMoviePlayer(movie, rom, nil, nil, false)
+		if err != nil {
+			t.Errorf("failed replaying version 1 movie: %s", err.Error())
+		}
+	})
+
+	t.Run(">>> input movie: scenario 5 - replay the pressed buttons to the same state hashes", func(t *testing.T) {
+
+		rom := buildJoypadROM()
+
+		recorder := NewMovieRecorder(rom, nil, nil, map[string]string{"model": "dmg"}, InputFunc(testInput))
+		expected := runMovieSystem(t, recorder, 60)
+
+		var buffer bytes.Buffer
+		recorder.Movie().Write(&buffer)
+
+		movie, err := ReadMovie(&buffer)
+		if err != nil {
+			t.Fatalf("fail reading movie: %s", err.Error())
+		}
+
+		pressed := 0
+		for frame := range movie.Len() {
+			if movie.Buttons(frame) != testInput(uint64(frame)) {
+				t.Fatalf("failed recording the buttons of frame %d: expected: %02x\n\tresult: %02x", frame, testInput(uint64(frame)), movie.Buttons(frame))
+			}
+			if movie.Buttons(frame) != 0 {
+				pressed++
+			}
+		}
+		if pressed == 0 {
+			t.Fatalf("failed recording the buttons: expected pressed buttons")
+		}
+
+		player, err := NewMoviePlayer(movie, rom, nil, nil, true)
+		if err != nil {
+			t.Fatalf("fail creating player: %s", err.Error())
+		}
+
+		result := runMovieSystem(t, player, 1000)
+		if frame := firstDivergence(expected, result); frame != -1 {
+			t.Errorf("failed replaying the movie: expected: identical state hashes\n\tresult: divergence at frame %d", frame)
+		}
+	})
+}
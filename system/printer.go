@@ -0,0 +1,349 @@
+////////////////////////////////////////////////////////////////////////////////
+//	printer.go - Oct-18-2026 by aldebap
+//
+//	Game Boy Printer emulation: a serial peer writing the print jobs as PNG files
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"path/filepath"
+
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// printer packet: 0x88 0x33, command, compression, length (LSB, MSB), data, checksum (LSB, MSB),
+// then two bytes answered with the printer id and status
+const (
+	PRINTER_MAGIC_1     = uint8(0x88)
+	PRINTER_MAGIC_2     = uint8(0x33)
+	PRINTER_ID          = uint8(0x81)
+	PRINTER_MAX_DATA    = 0x280
+	PRINTER_BUFFER_SIZE = 0x2000
+	PRINTER_WIDTH       = 160
+	PRINTER_TILES_ROW   = PRINTER_WIDTH / 8
+	PRINTER_BUSY_POLLS  = 4
+	PRINTER_MARGIN_ROWS = 8
+)
+
+// printer commands
+const (
+	PRINTER_INIT   = uint8(0x01)
+	PRINTER_PRINT  = uint8(0x02)
+	PRINTER_DATA   = uint8(0x04)
+	PRINTER_STATUS = uint8(0x0f)
+)
+
+// printer status flags
+const (
+	PRINTER_CHECKSUM_ERROR = uint8(0x01)
+	PRINTER_PRINTING       = uint8(0x02)
+	PRINTER_DATA_FULL      = uint8(0x04)
+	PRINTER_UNPROCESSED    = uint8(0x08)
+	PRINTER_PACKET_ERROR   = uint8(0x10)
+)
+
+// printer packet reception states
+const (
+	printerMagic1 = iota
+	printerMagic2
+	printerCommand
+	printerCompression
+	printerLengthLSB
+	printerLengthMSB
+	printerData
+	printerChecksumLSB
+	printerChecksumMSB
+	printerAlive
+	printerStatus
+)
+
+// printer gray shades (white to black)
+var printerShades = [4]color.Gray{{Y: 0xff}, {Y: 0xaa}, {Y: 0x55}, {Y: 0x00}}
+
+// Game Boy Printer: the data packets fill a buffer of 2bpp tiles (20 tiles per row), the print command
+// draws it using the palette and margins; a print job ends with a print command with an after margin,
+// so a long image sent in several prints is written into a single PNG file
+type GameBoyPrinter struct {
+	directory string
+	files     []string
+
+	state       int
+	command     uint8
+	compressed  bool
+	length      uint16
+	remaining   uint16
+	packet      []uint8
+	checksum    uint16
+	received    uint16
+	status      uint8
+	busyPolls   int
+	imageBuffer []uint8
+	job         []uint8
+	jobRows     int
+}
+
+// create a new printer writing the print jobs into a directory
+func NewGameBoyPrinter(directory string) *GameBoyPrinter {
+
+	return &GameBoyPrinter{
+		directory: directory,
+	}
+}
+
+// return the files written so far
+func (p *GameBoyPrinter) Files() []string {
+	return p.files
+}
+
+// receive a byte clocked by the Game Boy and return the printer byte (SerialPeer interface)
+func (p *GameBoyPrinter) Exchange(value uint8) uint8 {
+
+	switch p.state {
+	case printerMagic1:
+		if value == PRINTER_MAGIC_1 {
+			p.state = printerMagic2
+		}
+
+	case printerMagic2:
+		p.state = printerMagic1
+		if value == PRINTER_MAGIC_2 {
+			p.state = printerCommand
+		}
+
+	case printerCommand:
+		p.command = value
+		p.checksum = uint16(value)
+		p.state = printerCompression
+
+	case printerCompression:
+		p.compressed = value&0x01 != 0
+		p.checksum += uint16(value)
+		p.state = printerLengthLSB
+
+	case printerLengthLSB:
+		p.length = uint16(value)
+		p.checksum += uint16(value)
+		p.state = printerLengthMSB
+
+	case printerLengthMSB:
+		p.length |= uint16(value) << 8
+		p.checksum += uint16(value)
+		p.packet = p.packet[:0]
+		p.remaining = p.length
+		p.state = printerData
+		if p.length == 0 {
+			p.state = printerChecksumLSB
+		}
+
+	case printerData:
+		//	the bytes beyond PRINTER_MAX_DATA are only added to the checksum (the packet is rejected)
+		if len(p.packet) < PRINTER_MAX_DATA {
+			p.packet = append(p.packet, value)
+		}
+		p.checksum += uint16(value)
+		p.remaining--
+		if p.remaining == 0 {
+			p.state = printerChecksumLSB
+		}
+
+	case printerChecksumLSB:
+		p.received = uint16(value)
+		p.state = printerChecksumMSB
+
+	case printerChecksumMSB:
+		p.received |= uint16(value) << 8
+		p.processPacket()
+		p.state = printerAlive
+
+	case printerAlive:
+		p.state = printerStatus
+		return PRINTER_ID
+
+	case printerStatus:
+		p.state = printerMagic1
+		return p.currentStatus()
+	}
+
+	return 0x00
+}
+
+// status answered at the end of a packet; the printing flag is cleared after a few status polls
+func (p *GameBoyPrinter) currentStatus() uint8 {
+	var status = p.status
+
+	if p.busyPolls > 0 {
+		status |= PRINTER_PRINTING
+		if p.command == PRINTER_STATUS {
+			p.busyPolls--
+		}
+	}
+	if len(p.imageBuffer) > 0 {
+		status |= PRINTER_UNPROCESSED
+	}
+
+	return status
+}
+
+// execute a packet once its checksum was received
+func (p *GameBoyPrinter) processPacket() {
+
+	if p.received != p.checksum {
+		p.status |= PRINTER_CHECKSUM_ERROR
+		return
+	}
+	p.status &^= PRINTER_CHECKSUM_ERROR | PRINTER_PACKET_ERROR
+
+	if p.length > PRINTER_MAX_DATA {
+		p.status |= PRINTER_PACKET_ERROR
+		return
+	}
+
+	switch p.command {
+	case PRINTER_INIT:
+		p.status = 0
+		p.busyPolls = 0
+		p.imageBuffer = p.imageBuffer[:0]
+
+	case PRINTER_DATA:
+		data := p.packet
+		if p.compressed {
+			data = decompressPrinterData(data)
+		}
+
+		//	the printer memory holds PRINTER_BUFFER_SIZE bytes: the data beyond it is dropped
+		free := PRINTER_BUFFER_SIZE - len(p.imageBuffer)
+		if len(data) > free {
+			data = data[:free]
+		}
+		p.imageBuffer = append(p.imageBuffer, data...)
+
+		//	an empty data packet ends the image data
+		if p.length == 0 || len(p.imageBuffer) == PRINTER_BUFFER_SIZE {
+			p.status |= PRINTER_DATA_FULL
+		}
+
+	case PRINTER_PRINT:
+		if len(p.packet) != 4 {
+			p.status |= PRINTER_PACKET_ERROR
+			return
+		}
+
+		err := p.print(p.packet[1], p.packet[2])
+		if err != nil {
+			p.status |= PRINTER_PACKET_ERROR
+			return
+		}
+		p.status &^= PRINTER_DATA_FULL
+		p.busyPolls = PRINTER_BUSY_POLLS
+
+	case PRINTER_STATUS:
+
+	default:
+		p.status |= PRINTER_PACKET_ERROR
+	}
+}
+
+// decompress run length encoded data: a control byte with bit 7 set repeats the next byte
+// (control & 0x7f) + 2 times, otherwise the next control + 1 bytes are copied
+func decompressPrinterData(data []uint8) []uint8 {
+	var result []uint8
+
+	for i := 0; i < len(data); {
+		control := data[i]
+		i++
+
+		if control&0x80 != 0 {
+			if i >= len(data) {
+				break
+			}
+			for range int(control&0x7f) + 2 {
+				result = append(result, data[i])
+			}
+			i++
+			continue
+		}
+
+		count := min(int(control)+1, len(data)-i)
+		result = append(result, data[i:i+count]...)
+		i += count
+	}
+
+	return result
+}
+
+// draw the image buffer into the current job using the palette and the margins
+// (high nibble before, low nibble after, in units of PRINTER_MARGIN_ROWS rows)
+func (p *GameBoyPrinter) print(margins uint8, palette uint8) error {
+
+	//	a zero palette is handled as the default one
+	if palette == 0 {
+		palette = 0xe4
+	}
+
+	p.appendBlankRows(int(margins>>4) * PRINTER_MARGIN_ROWS)
+
+	tileRows := len(p.imageBuffer) / (PRINTER_TILES_ROW * ppu.TILE_SIZE)
+	for tileRow := range tileRows {
+		for row := range 8 {
+			for tile := range PRINTER_TILES_ROW {
+				offset := (tileRow*PRINTER_TILES_ROW+tile)*ppu.TILE_SIZE + row*2
+				low := p.imageBuffer[offset]
+				high := p.imageBuffer[offset+1]
+
+				for bit := 7; bit >= 0; bit-- {
+					index := (high>>bit&0x01)<<1 | low>>bit&0x01
+					p.job = append(p.job, palette>>(index*2)&0x03)
+				}
+			}
+			p.jobRows++
+		}
+	}
+
+	p.imageBuffer = p.imageBuffer[:0]
+
+	after := int(margins&0x0f) * PRINTER_MARGIN_ROWS
+	p.appendBlankRows(after)
+
+	if after > 0 {
+		return p.Flush()
+	}
+
+	return nil
+}
+
+// add blank rows to the current job
+func (p *GameBoyPrinter) appendBlankRows(rows int) {
+	for range rows * PRINTER_WIDTH {
+		p.job = append(p.job, 0)
+	}
+	p.jobRows += rows
+}
+
+// write the current job into a PNG file
+func (p *GameBoyPrinter) Flush() error {
+	if p.jobRows == 0 {
+		return nil
+	}
+
+	img := image.NewGray(image.Rect(0, 0, PRINTER_WIDTH, p.jobRows))
+	for i, shade := range p.job {
+		img.SetGray(i%PRINTER_WIDTH, i/PRINTER_WIDTH, printerShades[shade])
+	}
+
+	p.job = p.job[:0]
+	p.jobRows = 0
+
+	fileName := filepath.Join(p.directory, fmt.Sprintf("print_%03d.png", len(p.files)+1))
+
+	err := ppu.SavePNG(fileName, img)
+	if err != nil {
+		return err
+	}
+	p.files = append(p.files, fileName)
+
+	return nil
+}
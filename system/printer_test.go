@@ -0,0 +1,164 @@
+////////////////////////////////////////////////////////////////////////////////
+//	printer_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy Printer emulation
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// send a printer packet returning the printer id and status
+func sendPrinterPacket(printer *GameBoyPrinter, command uint8, compression uint8, data []uint8) (uint8, uint8) {
+	var checksum = uint16(command) + uint16(compression) + uint16(len(data)&0xff) + uint16(len(data)>>8)
+
+	packet := []uint8{PRINTER_MAGIC_1, PRINTER_MAGIC_2, command, compression, uint8(len(data)), uint8(len(data) >> 8)}
+	for _, value := range data {
+		packet = append(packet, value)
+		checksum += uint16(value)
+	}
+	packet = append(packet, uint8(checksum), uint8(checksum>>8))
+
+	for _, value := range packet {
+		printer.Exchange(value)
+	}
+
+	return printer.Exchange(0x00), printer.Exchange(0x00)
+}
+
+// printer unit tests
+func Test_GameBoyPrinter(t *testing.T) {
+
+	t.Run(">>> printer: scenario 1 - print a two tile rows image", func(t *testing.T) {
+
+		printer := NewGameBoyPrinter(t.TempDir())
+
+		id, status := sendPrinterPacket(printer, PRINTER_INIT, 0, nil)
+		if id != PRINTER_ID || status != 0 {
+			t.Errorf("failed initializing printer: expected: 0x81 and 0x00\n\tresult: 0x%02x and 0x%02x", id, status)
+		}
+
+		//	first tile row with color 3, second tile row with color 1
+		data := append(bytes.Repeat([]uint8{0xff}, PRINTER_TILES_ROW*ppu.TILE_SIZE),
+			bytes.Repeat([]uint8{0xff, 0x00}, PRINTER_TILES_ROW*ppu.TILE_SIZE/2)...)
+
+		_, status = sendPrinterPacket(printer, PRINTER_DATA, 0, data)
+		if status != PRINTER_UNPROCESSED {
+			t.Errorf("failed sending data: expected status: 0x08\n\tresult: 0x%02x", status)
+		}
+
+		_, status = sendPrinterPacket(printer, PRINTER_DATA, 0, nil)
+		if status != PRINTER_UNPROCESSED|PRINTER_DATA_FULL {
+			t.Errorf("failed ending data: expected status: 0x0c\n\tresult: 0x%02x", status)
+		}
+
+		//	one sheet, margins 0 before and 1 after, default palette
+		_, status = sendPrinterPacket(printer, PRINTER_PRINT, 0, []uint8{0x01, 0x01, 0xe4, 0x40})
+		if status != PRINTER_PRINTING {
+			t.Errorf("failed printing: expected status: 0x02\n\tresult: 0x%02x", status)
+		}
+
+		for range PRINTER_BUSY_POLLS {
+			sendPrinterPacket(printer, PRINTER_STATUS, 0, nil)
+		}
+		_, status = sendPrinterPacket(printer, PRINTER_STATUS, 0, nil)
+		if status != 0 {
+			t.Errorf("failed ending print: expected status: 0x00\n\tresult: 0x%02x", status)
+		}
+
+		if len(printer.Files()) != 1 {
+			t.Fatalf("failed writing print job: expected one file\n\tresult: %v", printer.Files())
+		}
+
+		img, err := ppu.LoadPNG(printer.Files()[0])
+		if err != nil {
+			t.Fatalf("fail loading print job: %s", err.Error())
+		}
+		if img.Bounds().Dx() != PRINTER_WIDTH || img.Bounds().Dy() != 16+PRINTER_MARGIN_ROWS {
+			t.Errorf("failed print size: expected: 160x24\n\tresult: %v", img.Bounds())
+		}
+
+		testScenarios := []struct {
+			y        int
+			expected uint8
+		}{
+			{y: 0, expected: 0x00},
+			{y: 8, expected: 0xaa},
+			{y: 16, expected: 0xff},
+		}
+
+		for _, test := range testScenarios {
+			got := color.GrayModel.Convert(img.At(5, test.y)).(color.Gray).Y
+			if got != test.expected {
+				t.Errorf("failed print row %d: expected: 0x%02x\n\tresult: 0x%02x", test.y, test.expected, got)
+			}
+		}
+	})
+
+	t.Run(">>> printer: scenario 2 - compressed data", func(t *testing.T) {
+
+		got := decompressPrinterData([]uint8{0x83, 0xaa, 0x01, 0x12, 0x34})
+		expected := []uint8{0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0x12, 0x34}
+		if !bytes.Equal(got, expected) {
+			t.Errorf("failed decompressing data: expected: %v\n\tresult: %v", expected, got)
+		}
+
+		printer := NewGameBoyPrinter(t.TempDir())
+		_, status := sendPrinterPacket(printer, PRINTER_DATA, 1, []uint8{0xff, 0x00})
+		if status != PRINTER_UNPROCESSED || len(printer.imageBuffer) != 129 {
+			t.Errorf("failed receiving compressed data: expected 129 bytes\n\tresult: %d", len(printer.imageBuffer))
+		}
+	})
+
+	t.Run(">>> printer: scenario 3 - checksum error", func(t *testing.T) {
+
+		printer := NewGameBoyPrinter(t.TempDir())
+
+		for _, value := range []uint8{PRINTER_MAGIC_1, PRINTER_MAGIC_2, PRINTER_INIT, 0, 0, 0, 0x02, 0x00} {
+			printer.Exchange(value)
+		}
+		printer.Exchange(0x00)
+
+		status := printer.Exchange(0x00)
+		if status != PRINTER_CHECKSUM_ERROR {
+			t.Errorf("failed checking checksum: expected status: 0x01\n\tresult: 0x%02x", status)
+		}
+	})
+
+	t.Run(">>> printer: scenario 4 - oversized data packet", func(t *testing.T) {
+
+		printer := NewGameBoyPrinter(t.TempDir())
+
+		_, status := sendPrinterPacket(printer, PRINTER_DATA, 0, make([]uint8, PRINTER_MAX_DATA+1))
+		if status != PRINTER_PACKET_ERROR || len(printer.imageBuffer) != 0 || len(printer.packet) != PRINTER_MAX_DATA {
+			t.Errorf("failed rejecting oversized packet: expected status: 0x10\n\tresult: 0x%02x (%d bytes buffered)", status, len(printer.imageBuffer))
+		}
+
+		//	the next packet is received normally
+		_, status = sendPrinterPacket(printer, PRINTER_DATA, 0, make([]uint8, PRINTER_MAX_DATA))
+		if status != PRINTER_UNPROCESSED || len(printer.imageBuffer) != PRINTER_MAX_DATA {
+			t.Errorf("failed receiving data: expected status: 0x08\n\tresult: 0x%02x (%d bytes buffered)", status, len(printer.imageBuffer))
+		}
+	})
+
+	t.Run(">>> printer: scenario 5 - full printer memory", func(t *testing.T) {
+
+		printer := NewGameBoyPrinter(t.TempDir())
+
+		//	each packet expands to 0x2000 bytes: 64 runs of 128 bytes
+		for range 2 {
+			sendPrinterPacket(printer, PRINTER_DATA, 1, bytes.Repeat([]uint8{0xfe, 0xff}, 64))
+		}
+
+		_, status := sendPrinterPacket(printer, PRINTER_STATUS, 0, nil)
+		if status != PRINTER_UNPROCESSED|PRINTER_DATA_FULL || len(printer.imageBuffer) != PRINTER_BUFFER_SIZE {
+			t.Errorf("failed filling printer memory: expected status: 0x0c\n\tresult: 0x%02x (%d bytes buffered)", status, len(printer.imageBuffer))
+		}
+	})
+}
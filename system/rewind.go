@@ -0,0 +1,284 @@
+////////////////////////////////////////////////////////////////////////////////
+//	rewind.go - Oct-19-2026 by aldebap
+//
+//	rewind: ring buffer of compressed delta snapshots to step back frame by frame
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io"
+)
+
+// default rewind settings: a snapshot every 10 frames, up to 10 seconds back within 16MB
+const (
+	REWIND_INTERVAL = 10
+	REWIND_DEPTH    = 600
+	REWIND_BUDGET   = 16 * 1024 * 1024
+)
+
+// an older snapshot: the XOR between it and the next newer snapshot, compressed
+type rewindDelta struct {
+	frame uint64
+	delta []uint8
+}
+
+// rewind buffer: the newest snapshot is kept whole and every older one as a compressed XOR delta against
+// the next newer snapshot, so the oldest can be dropped without touching the others; the joypad buttons
+// of every frame since the oldest snapshot are recorded to replay the frames between two snapshots
+type rewindBuffer struct {
+	interval int
+	depth    int
+	budget   int
+
+	newest      []uint8
+	newestFrame uint64
+	older       []rewindDelta
+	olderSize   int
+	buttons     []uint8
+	replaying   bool
+
+	compressed bytes.Buffer
+	compressor *flate.Writer
+}
+
+// create a new rewind buffer
+func newRewindBuffer(interval int, depth int, budget int) (*rewindBuffer, error) {
+
+	if interval < 1 {
+		return nil, fmt.Errorf("invalid rewind interval: %d frames", interval)
+	}
+	if depth < 1 {
+		return nil, fmt.Errorf("invalid rewind depth: %d frames", depth)
+	}
+	if budget < 1 {
+		return nil, fmt.Errorf("invalid rewind memory budget: %d bytes", budget)
+	}
+
+	compressor, _ := flate.NewWriter(nil, flate.BestSpeed)
+
+	return &rewindBuffer{
+		interval:   interval,
+		depth:      depth,
+		budget:     budget,
+		compressor: compressor,
+	}, nil
+}
+
+// discard all snapshots
+func (r *rewindBuffer) clear() {
+	r.newest = nil
+	r.older = nil
+	r.olderSize = 0
+	r.buttons = nil
+}
+
+// return the oldest frame that can be restored
+func (r *rewindBuffer) oldestFrame() uint64 {
+
+	if len(r.older) > 0 {
+		return r.older[0].frame
+	}
+
+	return r.newestFrame
+}
+
+// return the memory used by the snapshots and the recorded buttons
+func (r *rewindBuffer) size() int {
+	return len(r.newest) + r.olderSize + len(r.buttons)
+}
+
+// XOR two snapshots into a new slice (snapshots of a system have the same length)
+func xorStates(a []uint8, b []uint8) []uint8 {
+	var result = make([]uint8, max(len(a), len(b)))
+
+	copy(result, a)
+	for i, value := range b {
+		result[i] ^= value
+	}
+
+	return result
+}
+
+// compress a delta (mostly zeros, as only a few bytes change between snapshots)
+func (r *rewindBuffer) compress(delta []uint8) []uint8 {
+
+	r.compressed.Reset()
+	r.compressor.Reset(&r.compressed)
+	r.compressor.Write(delta)
+	r.compressor.Close()
+
+	return bytes.Clone(r.compressed.Bytes())
+}
+
+// expand a compressed delta
+func expandDelta(delta []uint8) ([]uint8, error) {
+	return io.ReadAll(flate.NewReader(bytes.NewReader(delta)))
+}
+
+// record the buttons of a frame run by the system, taking a snapshot every interval frames
+// (frames run out of sequence, e.g. after loading a state, restart the buffer)
+func (r *rewindBuffer) capture(s *System) {
+
+	if r.replaying {
+		return
+	}
+	if r.newest != nil && s.frame != r.oldestFrame()+uint64(len(r.buttons))+1 {
+		r.clear()
+	}
+
+	if r.newest != nil {
+		r.buttons = append(r.buttons, s.joypad.Buttons())
+	}
+	if r.newest != nil && s.frame-r.newestFrame < uint64(r.interval) {
+		return
+	}
+
+	state := s.SaveState()
+	if r.newest != nil {
+		delta := r.compress(xorStates(r.newest, state))
+		r.older = append(r.older, rewindDelta{frame: r.newestFrame, delta: delta})
+		r.olderSize += len(delta)
+	}
+	r.newest = state
+	r.newestFrame = s.frame
+
+	r.trim()
+}
+
+// drop the oldest snapshots beyond the depth or the memory budget (the newest snapshot is always kept)
+func (r *rewindBuffer) trim() {
+
+	for len(r.older) > 0 {
+		next := r.newestFrame
+		if len(r.older) > 1 {
+			next = r.older[1].frame
+		}
+
+		//	the oldest snapshot is needed while the next one can't reach the depth
+		current := r.older[0].frame + uint64(len(r.buttons))
+		if current < next+uint64(r.depth) && r.size() <= r.budget {
+			return
+		}
+
+		r.buttons = r.buttons[next-r.older[0].frame:]
+		r.olderSize -= len(r.older[0].delta)
+		r.older[0] = rewindDelta{}
+		r.older = r.older[1:]
+	}
+}
+
+// drop the snapshots newer than a frame, rebuilding the newest one from its delta
+func (r *rewindBuffer) dropAfter(frame uint64) error {
+
+	for r.newestFrame > frame && len(r.older) > 0 {
+		last := r.older[len(r.older)-1]
+
+		delta, err := expandDelta(last.delta)
+		if err != nil {
+			return fmt.Errorf("invalid rewind snapshot of frame %d: %w", last.frame, err)
+		}
+
+		r.newest = xorStates(r.newest, delta)[:len(r.newest)]
+		r.newestFrame = last.frame
+		r.olderSize -= len(last.delta)
+		r.older = r.older[:len(r.older)-1]
+	}
+
+	return nil
+}
+
+// return the number of frames the system can be rewound
+func (s *System) RewindFrames() int {
+
+	if s.rewind == nil || s.rewind.newest == nil {
+		return 0
+	}
+
+	return int(s.frame - s.rewind.oldestFrame())
+}
+
+// return the memory used by the rewind buffer in bytes
+func (s *System) RewindSize() int {
+
+	if s.rewind == nil {
+		return 0
+	}
+
+	return s.rewind.size()
+}
+
+// keep snapshots every interval frames to rewind up to depth frames, using at most budget bytes
+// (the joypad is replayed when rewinding, but serial peers and audio sinks see the replayed frames again)
+func (s *System) EnableRewind(interval int, depth int, budget int) error {
+
+	rewind, err := newRewindBuffer(interval, depth, budget)
+	if err != nil {
+		return err
+	}
+
+	s.rewind = rewind
+
+	return nil
+}
+
+// stop keeping rewind snapshots, releasing their memory
+func (s *System) DisableRewind() {
+	s.rewind = nil
+}
+
+// go back a number of frames: the newest snapshot up to the target frame is restored and the frames
+// after it are run again with the recorded buttons
+func (s *System) Rewind(frames int) error {
+
+	if s.rewind == nil {
+		return fmt.Errorf("rewind is not enabled")
+	}
+	if frames < 0 || frames > s.RewindFrames() {
+		return fmt.Errorf("can't rewind %d frames: only %d frames recorded", frames, s.RewindFrames())
+	}
+
+	r := s.rewind
+	target := s.frame - uint64(frames)
+
+	err := r.dropAfter(target)
+	if err == nil {
+		err = s.restoreState(r.newest)
+	}
+	if err != nil {
+		r.clear()
+		return err
+	}
+
+	start := r.newestFrame - r.oldestFrame()
+	replay := r.buttons[start : start+target-r.newestFrame]
+	source := s.joypad.source
+
+	r.replaying = true
+	s.joypad.ConnectInput(InputFunc(func(uint64) uint8 {
+		buttons := replay[0]
+		replay = replay[1:]
+
+		return buttons
+	}))
+
+	for range target - r.newestFrame {
+		err = s.RunFrame()
+		if err != nil {
+			break
+		}
+	}
+
+	s.joypad.ConnectInput(source)
+	r.replaying = false
+	r.buttons = r.buttons[:target-r.oldestFrame()]
+
+	if err != nil {
+		r.clear()
+	}
+
+	return err
+}
@@ -0,0 +1,114 @@
+////////////////////////////////////////////////////////////////////////////////
+//	rewind_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the rewind buffer
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"testing"
+)
+
+// create a system running the counter ROM with the buttons changing every frame,
+// returning the state after every frame
+func newRewindSystem(t *testing.T, frames int, interval int, depth int, budget int) (*System, map[uint64][]uint8) {
+	var states = make(map[uint64][]uint8)
+
+	system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+	system.Joypad().ConnectInput(InputFunc(func(frame uint64) uint8 { return uint8(frame*37) ^ uint8(frame>>2) }))
+
+	err := system.EnableRewind(interval, depth, budget)
+	if err != nil {
+		t.Fatalf("failed enabling rewind: %v", err)
+	}
+
+	for range frames {
+		err = system.RunFrame()
+		if err != nil {
+			t.Fatalf("failed running frame: %v", err)
+		}
+		states[system.Frame()] = system.SaveState()
+	}
+
+	return system, states
+}
+
+// rewind unit tests
+func Test_Rewind(t *testing.T) {
+
+	t.Run(">>> rewind: scenario 1 - step back frame by frame", func(t *testing.T) {
+
+		system, states := newRewindSystem(t, 60, 8, 40, REWIND_BUDGET)
+
+		if system.RewindFrames() < 40 || system.RewindFrames() >= 48 {
+			t.Errorf("failed keeping the depth: expected: 40 to 47 frames\n\tresult: %d", system.RewindFrames())
+		}
+
+		for frame := uint64(59); frame >= 30; frame-- {
+			err := system.Rewind(1)
+			if err != nil {
+				t.Fatalf("failed rewinding to frame %d: %v", frame, err)
+			}
+			if system.Frame() != frame || !bytes.Equal(system.SaveState(), states[frame]) {
+				t.Fatalf("failed rewinding: expected: the state of frame %d\n\tresult: frame %d", frame, system.Frame())
+			}
+		}
+
+		//	running again after rewinding records the new frames
+		err := system.RunFrame()
+		if err == nil {
+			err = system.Rewind(6)
+		}
+		if err != nil || system.Frame() != 25 || !bytes.Equal(system.SaveState(), states[25]) {
+			t.Errorf("failed rewinding after running: expected: the state of frame 25\n\tresult: frame %d (%v)", system.Frame(), err)
+		}
+	})
+
+	t.Run(">>> rewind: scenario 2 - memory budget", func(t *testing.T) {
+
+		system, _ := newRewindSystem(t, 10, 2, 600, REWIND_BUDGET)
+		stateSize := len(system.SaveState())
+
+		system, states := newRewindSystem(t, 100, 2, 600, stateSize+2048)
+		if system.RewindSize() > stateSize+2048 || system.RewindFrames() == 0 || system.RewindFrames() >= 98 {
+			t.Errorf("failed keeping the budget: expected: at most %d bytes\n\tresult: %d bytes, %d frames",
+				stateSize+2048, system.RewindSize(), system.RewindFrames())
+		}
+
+		frames := system.RewindFrames()
+		err := system.Rewind(frames)
+		if err != nil || !bytes.Equal(system.SaveState(), states[uint64(100-frames)]) {
+			t.Errorf("failed rewinding to the oldest frame: expected: frame %d\n\tresult: frame %d (%v)", 100-frames, system.Frame(), err)
+		}
+	})
+
+	t.Run(">>> rewind: scenario 3 - limits", func(t *testing.T) {
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+		if system.Rewind(1) == nil {
+			t.Errorf("failed reporting rewind not enabled")
+		}
+		if system.EnableRewind(0, REWIND_DEPTH, REWIND_BUDGET) == nil {
+			t.Errorf("failed rejecting the interval")
+		}
+
+		system, _ = newRewindSystem(t, 20, 5, 10, REWIND_BUDGET)
+		if system.Rewind(system.RewindFrames()+1) == nil || system.Frame() != 20 {
+			t.Errorf("failed rejecting rewinding too far: expected: frame 20\n\tresult: frame %d", system.Frame())
+		}
+
+		//	a loaded state starts another timeline
+		system.LoadState(system.SaveState())
+		if system.RewindFrames() != 0 {
+			t.Errorf("failed discarding the snapshots: expected: 0 frames\n\tresult: %d", system.RewindFrames())
+		}
+
+		system.DisableRewind()
+		system.RunFrame()
+		if system.RewindFrames() != 0 || system.RewindSize() != 0 {
+			t.Errorf("failed disabling rewind")
+		}
+	})
+}
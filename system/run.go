@@ -0,0 +1,223 @@
+////////////////////////////////////////////////////////////////////////////////
+//	run.go - Oct-19-2026 by aldebap
+//
+//	frame paced run loop with speed control, pause and frame advance
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// run loop settings: the host clock only paces the frames, it never changes what a frame computes
+const (
+	FRAME_DURATION    = time.Duration(ppu.FRAME_CYCLES) * time.Second / cpu.CPU_CLOCK_RATE
+	SPEED_UNTHROTTLED = 0.0
+	SPEED_NORMAL      = 1.0
+	SPEED_MAX         = 64.0
+	MAX_FRAME_LAG     = 4
+)
+
+// function called by the run loop after every frame
+type FrameHandler func(frame uint64) error
+
+// run loop controls, changed from any goroutine while the loop runs
+type runControl struct {
+	mutex   sync.Mutex
+	speed   float64
+	paused  bool
+	steps   int
+	handler FrameHandler
+	wake    chan struct{}
+}
+
+// create the run loop controls: normal speed, running
+func newRunControl() *runControl {
+	return &runControl{
+		speed: SPEED_NORMAL,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// wake up the run loop to apply a change
+func (c *runControl) notify() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// return whether the next frame can run (false while paused without frames to advance)
+func (c *runControl) nextFrame() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.paused {
+		return true
+	}
+	if c.steps > 0 {
+		c.steps--
+		return true
+	}
+
+	return false
+}
+
+// connect the function called after every frame run by Run (e.g. to draw it)
+func (s *System) ConnectFrameHandler(handler FrameHandler) {
+	s.run.mutex.Lock()
+	defer s.run.mutex.Unlock()
+
+	s.run.handler = handler
+}
+
+// set the run speed as a multiple of 59.73 Hz (e.g. 4 to fast-forward), SPEED_UNTHROTTLED runs as fast as possible
+func (s *System) SetSpeed(speed float64) error {
+
+	if speed < 0 || speed > SPEED_MAX {
+		return fmt.Errorf("invalid speed: %g", speed)
+	}
+
+	s.run.mutex.Lock()
+	s.run.speed = speed
+	s.run.mutex.Unlock()
+
+	s.run.notify()
+
+	return nil
+}
+
+// return the run speed
+func (s *System) Speed() float64 {
+	s.run.mutex.Lock()
+	defer s.run.mutex.Unlock()
+
+	return s.run.speed
+}
+
+// pause the run loop after the current frame
+func (s *System) Pause() {
+	s.run.mutex.Lock()
+	s.run.paused = true
+	s.run.steps = 0
+	s.run.mutex.Unlock()
+
+	s.run.notify()
+}
+
+// resume the run loop
+func (s *System) Resume() {
+	s.run.mutex.Lock()
+	s.run.paused = false
+	s.run.mutex.Unlock()
+
+	s.run.notify()
+}
+
+// return true while the run loop is paused
+func (s *System) Paused() bool {
+	s.run.mutex.Lock()
+	defer s.run.mutex.Unlock()
+
+	return s.run.paused
+}
+
+// run one more frame while paused
+func (s *System) AdvanceFrame() {
+	s.run.mutex.Lock()
+	if s.run.paused {
+		s.run.steps++
+	}
+	s.run.mutex.Unlock()
+
+	s.run.notify()
+}
+
+// wait until the turn of the frame following the one scheduled at a time, applying the speed changes
+// made meanwhile; returns early when the context is cancelled or the run loop is paused
+func (s *System) waitFrame(ctx context.Context, timer *time.Timer, scheduled time.Time) {
+
+	for {
+		speed := s.Speed()
+		if speed == SPEED_UNTHROTTLED || s.Paused() {
+			return
+		}
+
+		wait := time.Until(scheduled.Add(time.Duration(float64(FRAME_DURATION) / speed)))
+		if wait <= 0 {
+			return
+		}
+
+		timer.Reset(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			return
+		case <-s.run.wake:
+			timer.Stop()
+		}
+	}
+}
+
+// run frames (see RunFrame: VBlank to VBlank, 70224 dots) paced to 59.73 Hz times the speed against the host clock,
+// calling the frame handler after each one, until the context is cancelled (returning nil) or a frame fails;
+// when the host falls more than MAX_FRAME_LAG frames behind the pacing restarts from the current time
+func (s *System) Run(ctx context.Context) error {
+	var scheduled = time.Now()
+
+	timer := time.NewTimer(FRAME_DURATION)
+	timer.Stop()
+	defer timer.Stop()
+
+	for ctx.Err() == nil {
+		if !s.run.nextFrame() {
+			select {
+			case <-ctx.Done():
+			case <-s.run.wake:
+			}
+			scheduled = time.Now()
+			continue
+		}
+
+		err := s.RunFrame()
+		if err != nil {
+			return err
+		}
+
+		s.run.mutex.Lock()
+		handler := s.run.handler
+		s.run.mutex.Unlock()
+
+		if handler != nil {
+			err = handler(s.frame)
+			if err != nil {
+				return err
+			}
+		}
+
+		s.waitFrame(ctx, timer, scheduled)
+
+		now := time.Now()
+		speed := s.Speed()
+		if speed == SPEED_UNTHROTTLED {
+			scheduled = now
+			continue
+		}
+
+		scheduled = scheduled.Add(time.Duration(float64(FRAME_DURATION) / speed))
+		if now.Sub(scheduled) > MAX_FRAME_LAG*FRAME_DURATION {
+			scheduled = now
+		}
+	}
+
+	return nil
+}
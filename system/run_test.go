@@ -0,0 +1,131 @@
+////////////////////////////////////////////////////////////////////////////////
+//	run_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the frame paced run loop
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// run loop unit tests
+func Test_Run(t *testing.T) {
+
+	t.Run(">>> run: scenario 1 - unthrottled until cancelled", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+		system.SetSpeed(SPEED_UNTHROTTLED)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		system.ConnectFrameHandler(func(frame uint64) error {
+			if frame == 120 {
+				cancel()
+			}
+			return nil
+		})
+
+		err := system.Run(ctx)
+		if err != nil || system.Frame() != 120 {
+			t.Errorf("failed running until cancelled: expected: 120 frames\n\tresult: %d frames (%v)", system.Frame(), err)
+		}
+	})
+
+	t.Run(">>> run: scenario 2 - pacing and fast-forward", func(t *testing.T) {
+
+		for _, speed := range []float64{SPEED_NORMAL, 4} {
+			system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+			system.SetSpeed(speed)
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			system.ConnectFrameHandler(func(frame uint64) error {
+				if frame == 9 {
+					cancel()
+				}
+				return nil
+			})
+
+			start := time.Now()
+			system.Run(ctx)
+			elapsed := time.Since(start)
+			cancel()
+
+			//	the first frame runs right away, the next 8 wait for their turn
+			expected := time.Duration(float64(8*FRAME_DURATION) / speed)
+			if elapsed < expected-FRAME_DURATION/4 {
+				t.Errorf("failed pacing at speed %g: expected: at least %s\n\tresult: %s", speed, expected, elapsed)
+			}
+		}
+	})
+
+	t.Run(">>> run: scenario 3 - frame advance while paused", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+		system.Pause()
+
+		frames := make(chan uint64, 10)
+		system.ConnectFrameHandler(func(frame uint64) error {
+			frames <- frame
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		result := make(chan error)
+		go func() { result <- system.Run(ctx) }()
+
+		for expected := uint64(1); expected <= 3; expected++ {
+			system.AdvanceFrame()
+
+			select {
+			case frame := <-frames:
+				if frame != expected {
+					t.Errorf("failed advancing frame: expected: %d\n\tresult: %d", expected, frame)
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("failed advancing frame %d", expected)
+			}
+		}
+
+		select {
+		case frame := <-frames:
+			t.Errorf("failed pausing: expected no frames\n\tresult: frame %d", frame)
+		case <-time.After(10 * FRAME_DURATION):
+		}
+
+		cancel()
+		err := <-result
+		if err != nil || !system.Paused() {
+			t.Errorf("failed cancelling while paused: %v", err)
+		}
+	})
+
+	t.Run(">>> run: scenario 4 - frame handler errors and invalid speeds", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+		system.SetSpeed(SPEED_UNTHROTTLED)
+		system.ConnectFrameHandler(func(frame uint64) error {
+			return fmt.Errorf("stop at frame %d", frame)
+		})
+
+		err := system.Run(context.Background())
+		if err == nil || err.Error() != "stop at frame 1" {
+			t.Errorf("failed stopping on handler error: expected: stop at frame 1\n\tresult: %v", err)
+		}
+
+		for _, speed := range []float64{-1, SPEED_MAX + 1} {
+			if system.SetSpeed(speed) == nil {
+				t.Errorf("failed rejecting speed %g", speed)
+			}
+		}
+		if system.Speed() != SPEED_UNTHROTTLED {
+			t.Errorf("failed keeping speed: expected: %g\n\tresult: %g", SPEED_UNTHROTTLED, system.Speed())
+		}
+	})
+}
@@ -0,0 +1,248 @@
+////////////////////////////////////////////////////////////////////////////////
+//	savestate.go - Oct-19-2026 by aldebap
+//
+//	save states: snapshot and restore the whole machine
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"os"
+
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// save state format:
+//
+//	signature  "GBCSTATE"
+//	version    uint16
+//	ROM        uint32 (CRC-32 of the ROM image)
+//	model      uint8
+//	CGB mode   uint8
+//
+// followed by the sections of the system, CPU, cartridge, PPU, APU, timer, joypad, serial and infrared port;
+// states from STATE_MIN_VERSION up to STATE_VERSION can be loaded
+const (
+	STATE_SIGNATURE   = "GBCSTATE"
+	STATE_VERSION     = 1
+	STATE_MIN_VERSION = 1
+	STATE_HEADER_SIZE = len(STATE_SIGNATURE) + 2 + 4 + 1 + 1
+	STATE_SLOTS       = 10
+)
+
+// return the name of a model
+func modelName(model uint8) string {
+
+	switch model {
+	case MODEL_DMG:
+		return "dmg"
+	case MODEL_CGB:
+		return "cgb"
+	}
+
+	return "auto"
+}
+
+// write the system state: work RAM, high RAM, interrupts, CGB registers and counters
+func (s *System) saveSystemState(w *savestate.Writer) {
+	w.Section("SYS")
+
+	for _, bank := range s.wram {
+		w.Bytes(bank)
+	}
+	w.Uint8(s.wramBank)
+
+	hram := make([]uint8, HRAM_SIZE)
+	for i := range hram {
+		hram[i], _ = s.hram.ReadByte(uint16(i))
+	}
+	w.Bytes(hram)
+
+	w.Uint8(s.interruptFlag)
+	w.Uint8(s.interruptEnable)
+	w.Bool(s.bootROMEnabled)
+	w.Uint8(s.key1)
+	w.Uint16(s.hdmaSource)
+	w.Uint16(s.hdmaDestination)
+
+	w.Uint64(s.frame)
+	w.Uint64(s.cycles)
+}
+
+// read the system state
+func (s *System) loadSystemState(r *savestate.Reader) error {
+	r.Section("SYS")
+
+	for _, bank := range s.wram {
+		r.Bytes(bank)
+	}
+	s.wramBank = max(r.Uint8()&0x07, 1)
+
+	hram := make([]uint8, HRAM_SIZE)
+	r.Bytes(hram)
+	for i, value := range hram {
+		s.hram.WriteByte(uint16(i), value)
+	}
+
+	s.interruptFlag = r.Uint8()
+	s.interruptEnable = r.Uint8()
+	s.bootROMEnabled = r.Bool()
+	s.key1 = r.Uint8()
+	s.hdmaSource = r.Uint16()
+	s.hdmaDestination = r.Uint16()
+
+	s.frame = r.Uint64()
+	s.cycles = r.Uint64()
+
+	if r.Err() == nil && s.bootROMEnabled && s.bootROM == nil {
+		r.Fail(fmt.Errorf("save state taken while running the boot ROM: the boot ROM is needed to load it"))
+	}
+
+	return r.Err()
+}
+
+// return a snapshot of the whole machine (the connected peers, e.g. a link cable, are not included)
+func (s *System) SaveState() []uint8 {
+	var header = []uint8(STATE_SIGNATURE)
+
+	header = binary.LittleEndian.AppendUint16(header, STATE_VERSION)
+	header = binary.LittleEndian.AppendUint32(header, s.romChecksum)
+	header = append(header, s.model)
+	if s.cgbMode {
+		header = append(header, 1)
+	} else {
+		header = append(header, 0)
+	}
+
+	w := savestate.NewWriter()
+	s.saveSystemState(w)
+	s.cpu.SaveState(w)
+	s.cartridge.SaveState(w)
+	s.ppu.SaveState(w)
+	s.apu.SaveState(w)
+	s.timer.SaveState(w)
+	s.joypad.SaveState(w)
+	s.serial.SaveState(w)
+	s.infrared.SaveState(w)
+
+	return append(header, w.Data()...)
+}
+
+// return the FNV-1a hash of the whole machine state (CPU, memory, cartridge and peripherals): the emulation
+// depends only on the ROM, the model, the boot ROM and the joypad buttons of every frame (never on the host
+// clock or the map iteration order), so the same inputs give the same sequence of hashes after every frame
+func (s *System) StateHash() uint64 {
+	var hash = fnv.New64a()
+
+	hash.Write(s.SaveState())
+
+	return hash.Sum64()
+}
+
+// check a save state header, returning the format version
+func (s *System) checkStateHeader(data []uint8) (uint16, error) {
+
+	if len(data) < STATE_HEADER_SIZE || !bytes.HasPrefix(data, []uint8(STATE_SIGNATURE)) {
+		return 0, fmt.Errorf("invalid save state: missing %s signature", STATE_SIGNATURE)
+	}
+	header := data[len(STATE_SIGNATURE):]
+
+	version := binary.LittleEndian.Uint16(header)
+	if version > STATE_VERSION {
+		return 0, fmt.Errorf("save state version %d is newer than the supported version %d", version, STATE_VERSION)
+	}
+	if version < STATE_MIN_VERSION {
+		return 0, fmt.Errorf("save state version %d is no longer supported (oldest supported version: %d)", version, STATE_MIN_VERSION)
+	}
+
+	if checksum := binary.LittleEndian.Uint32(header[2:]); checksum != s.romChecksum {
+		return 0, fmt.Errorf("save state taken with another ROM: checksum %08x, expected %08x", checksum, s.romChecksum)
+	}
+
+	model, cgbMode := header[6], header[7] != 0
+	if model != s.model || cgbMode != s.cgbMode {
+		return 0, fmt.Errorf("save state taken with the %s model (CGB mode %t), running the %s model (CGB mode %t)",
+			modelName(model), cgbMode, modelName(s.model), s.cgbMode)
+	}
+
+	return version, nil
+}
+
+// restore the machine from a snapshot
+func (s *System) loadState(data []uint8) error {
+
+	version, err := s.checkStateHeader(data)
+	if err != nil {
+		return err
+	}
+
+	r := savestate.NewReader(data[STATE_HEADER_SIZE:], version)
+	for _, load := range []func(*savestate.Reader) error{s.loadSystemState, s.cpu.LoadState, s.cartridge.LoadState,
+		s.ppu.LoadState, s.apu.LoadState, s.timer.LoadState, s.joypad.LoadState, s.serial.LoadState, s.infrared.LoadState} {
+
+		err = load(r)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Remaining() != 0 {
+		return fmt.Errorf("invalid save state: %d unexpected bytes at the end", r.Remaining())
+	}
+
+	return nil
+}
+
+// restore the machine from a snapshot, or leave it unchanged on error
+func (s *System) restoreState(data []uint8) error {
+	var backup = s.SaveState()
+
+	err := s.loadState(data)
+	if err != nil {
+		s.loadState(backup)
+		return err
+	}
+
+	return nil
+}
+
+// restore the machine from a snapshot taken by SaveState: on error the machine is left unchanged
+// (the rewind snapshots are discarded, as they belong to another timeline)
+func (s *System) LoadState(data []uint8) error {
+
+	err := s.restoreState(data)
+	if err != nil {
+		return err
+	}
+
+	if s.rewind != nil {
+		s.rewind.clear()
+	}
+
+	return nil
+}
+
+// write a snapshot of the machine into a file
+func (s *System) SaveStateFile(fileName string) error {
+	return os.WriteFile(fileName, s.SaveState(), 0644)
+}
+
+// restore the machine from a file written by SaveStateFile
+func (s *System) LoadStateFile(fileName string) error {
+
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = s.LoadState(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fileName, err)
+	}
+
+	return nil
+}
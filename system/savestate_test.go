@@ -0,0 +1,154 @@
+////////////////////////////////////////////////////////////////////////////////
+//	savestate_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the save states
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// build a ROM counting in A and writing the counter into the work RAM and the tile data
+func buildCounterROM() []uint8 {
+	rom := buildLoopROM(0x00)
+
+	copy(rom[CARTRIDGE_ENTRY:], []uint8{
+		cpu.INC_A,
+		cpu.LD_ADDR_nn_A, 0x00, 0xc0,
+		cpu.LD_ADDR_nn_A, 0x00, 0x80,
+		cpu.JR_e, 0xf7,
+	})
+
+	return rom
+}
+
+// run frames returning the framebuffer hash after each one
+func runFrameHashes(t *testing.T, system *System, frames int) []uint64 {
+	var hashes []uint64
+
+	for range frames {
+		err := system.RunFrame()
+		if err != nil {
+			t.Fatalf("failed running frame: %v", err)
+		}
+		hashes = append(hashes, system.PPU().Framebuffer().Hash())
+	}
+
+	return hashes
+}
+
+// save state unit tests
+func Test_SaveState(t *testing.T) {
+
+	t.Run(">>> save state: scenario 1 - restore and run again", func(t *testing.T) {
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+		runFrameHashes(t, system, 10)
+
+		//	stop in the middle of an instruction
+		for range 3 {
+			system.Step()
+		}
+
+		state := system.SaveState()
+		cycles := system.Cycles()
+		expected := runFrameHashes(t, system, 5)
+		counter, _ := system.Bus().ReadByte(0xc000)
+
+		err := system.LoadState(state)
+		if err != nil {
+			t.Fatalf("failed loading state: %v", err)
+		}
+		if !bytes.Equal(system.SaveState(), state) || system.Cycles() != cycles {
+			t.Errorf("failed restoring state: expected the saved state back")
+		}
+
+		result := runFrameHashes(t, system, 5)
+		value, _ := system.Bus().ReadByte(0xc000)
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("failed running after restoring: frame %d expected: %016x\n\tresult: %016x", i, expected[i], result[i])
+			}
+		}
+		if value != counter {
+			t.Errorf("failed running after restoring: expected: counter %d\n\tresult: %d", counter, value)
+		}
+	})
+
+	t.Run(">>> save state: scenario 2 - incompatible states leave the machine unchanged", func(t *testing.T) {
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+		runFrameHashes(t, system, 3)
+		state := system.SaveState()
+
+		otherROM, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+		otherModel, _ := NewSystem(buildCounterROM(), MODEL_CGB, nil, false)
+
+		newer := append([]uint8(nil), state...)
+		binary.LittleEndian.PutUint16(newer[len(STATE_SIGNATURE):], STATE_VERSION+1)
+
+		corrupted := append([]uint8(nil), state...)
+		copy(corrupted[STATE_HEADER_SIZE:], "XXXX")
+
+		for _, test := range []struct {
+			name     string
+			data     []uint8
+			expected string
+		}{
+			{name: "another ROM", data: otherROM.SaveState(), expected: "another ROM"},
+			{name: "another model", data: otherModel.SaveState(), expected: "cgb model"},
+			{name: "newer version", data: newer, expected: "newer than the supported version"},
+			{name: "signature", data: []uint8("GBC-MOVIE 1\n"), expected: "missing GBCSTATE signature"},
+			{name: "truncated", data: state[:len(state)-100], expected: "truncated save state"},
+			{name: "section", data: corrupted, expected: "expected section"},
+			{name: "trailing bytes", data: append(append([]uint8(nil), state...), 0x00), expected: "unexpected bytes"},
+		} {
+			system.RunFrame()
+			before := system.SaveState()
+
+			err := system.LoadState(test.data)
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("failed rejecting %s: expected: %s\n\tresult: %v", test.name, test.expected, err)
+			}
+			if !bytes.Equal(system.SaveState(), before) {
+				t.Errorf("failed keeping the machine after rejecting %s", test.name)
+			}
+		}
+	})
+
+	t.Run(">>> save state: scenario 3 - state files", func(t *testing.T) {
+
+		fileName := filepath.Join(t.TempDir(), "counter.ss1")
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_CGB, nil, false)
+		runFrameHashes(t, system, 4)
+
+		err := system.SaveStateFile(fileName)
+		if err != nil {
+			t.Fatalf("failed saving state file: %v", err)
+		}
+
+		restored, _ := NewSystem(buildCounterROM(), MODEL_CGB, nil, false)
+		err = restored.LoadStateFile(fileName)
+		if err != nil {
+			t.Fatalf("failed loading state file: %v", err)
+		}
+
+		if restored.Frame() != 4 || restored.CPU().PC() != system.CPU().PC() || !bytes.Equal(restored.SaveState(), system.SaveState()) {
+			t.Errorf("failed loading state file: expected: frame 4 and the same machine\n\tresult: frame %d", restored.Frame())
+		}
+
+		err = restored.LoadStateFile(filepath.Join(t.TempDir(), "missing.ss1"))
+		if err == nil {
+			t.Errorf("failed reporting a missing state file")
+		}
+	})
+}
@@ -0,0 +1,273 @@
+////////////////////////////////////////////////////////////////////////////////
+//	serial.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the Game Boy serial port (SB and SC registers)
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"io"
+	"sync"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// serial memory map
+const (
+	SERIAL_REGISTERS      = 0xff01
+	SERIAL_REGISTERS_SIZE = 0x02
+)
+
+// serial registers (offset from 0xff01)
+const (
+	REG_SB = 0x00
+	REG_SC = 0x01
+)
+
+// SC flags
+const (
+	SC_INTERNAL_CLOCK = uint8(0x01)
+	SC_CLOCK_SPEED    = uint8(0x02)
+	SC_TRANSFER_START = uint8(0x80)
+	SC_DMG_READ_MASK  = uint8(0x7e)
+	SC_CGB_READ_MASK  = uint8(0x7c)
+)
+
+// serial timing: T-cycles per bit with the internal clock at 8192 Hz and (CGB only) 262144 Hz
+const (
+	SERIAL_BIT_CYCLES      = cpu.CPU_CLOCK_RATE / 8192
+	SERIAL_FAST_BIT_CYCLES = cpu.CPU_CLOCK_RATE / 262144
+	SERIAL_DISCONNECTED    = uint8(0xff)
+)
+
+// device connected to the other end of the serial port: when the Game Boy drives the clock
+// it receives the byte sent and returns the byte shifted in at the same time
+type SerialPeer interface {
+	Exchange(value uint8) uint8
+}
+
+// serial port registers and transfer state
+type Serial struct {
+	cgbMode bool
+
+	sb uint8
+	sc uint8
+
+	transferCycles int
+
+	peer             SerialPeer
+	requestInterrupt cpu.InterruptRequester
+}
+
+// create a new serial port
+func NewSerial(cgbMode bool) *Serial {
+
+	return &Serial{
+		cgbMode: cgbMode,
+	}
+}
+
+// connect the device at the other end of the serial port
+func (s *Serial) ConnectPeer(peer SerialPeer) {
+	s.peer = peer
+}
+
+// connect the interrupt requester
+func (s *Serial) ConnectInterrupt(requestInterrupt cpu.InterruptRequester) {
+	s.requestInterrupt = requestInterrupt
+}
+
+// run the serial port for a number of T-cycles, completing internally clocked transfers
+func (s *Serial) Step(cycles int) {
+	if s.transferCycles == 0 {
+		return
+	}
+
+	s.transferCycles -= cycles
+	if s.transferCycles > 0 {
+		return
+	}
+	s.transferCycles = 0
+
+	received := SERIAL_DISCONNECTED
+	if s.peer != nil {
+		received = s.peer.Exchange(s.sb)
+	}
+
+	s.completeTransfer(received)
+}
+
+// return true when a transfer waits for an external clock
+func (s *Serial) WaitingExternalClock() bool {
+	return s.sc&SC_TRANSFER_START != 0 && s.sc&SC_INTERNAL_CLOCK == 0
+}
+
+// shift a byte in driven by an external clock, returning the byte shifted out;
+// nothing is exchanged (false) when no externally clocked transfer was started
+func (s *Serial) ExternalTransfer(value uint8) (uint8, bool) {
+	if !s.WaitingExternalClock() {
+		return SERIAL_DISCONNECTED, false
+	}
+
+	sent := s.sb
+	s.completeTransfer(value)
+
+	return sent, true
+}
+
+// store the received byte, end the transfer and request the serial interrupt
+func (s *Serial) completeTransfer(received uint8) {
+
+	s.sb = received
+	s.sc &^= SC_TRANSFER_START
+
+	if s.requestInterrupt != nil {
+		s.requestInterrupt(cpu.INTERRUPT_SERIAL)
+	}
+}
+
+// T-cycles of a whole byte transfer with the internal clock
+func (s *Serial) byteCycles() int {
+	if s.cgbMode && s.sc&SC_CLOCK_SPEED != 0 {
+		return 8 * SERIAL_FAST_BIT_CYCLES
+	}
+
+	return 8 * SERIAL_BIT_CYCLES
+}
+
+// read a serial register
+func (s *Serial) readRegister(register uint16) uint8 {
+
+	if register == REG_SB {
+		return s.sb
+	}
+
+	if s.cgbMode {
+		return s.sc | SC_CGB_READ_MASK
+	}
+
+	return s.sc | SC_DMG_READ_MASK
+}
+
+// write a serial register
+func (s *Serial) writeRegister(register uint16, value uint8) {
+
+	if register == REG_SB {
+		s.sb = value
+		return
+	}
+
+	s.sc = value & (SC_TRANSFER_START | SC_CLOCK_SPEED | SC_INTERNAL_CLOCK)
+	if !s.cgbMode {
+		s.sc &^= SC_CLOCK_SPEED
+	}
+
+	s.transferCycles = 0
+	if s.sc&SC_TRANSFER_START != 0 && s.sc&SC_INTERNAL_CLOCK != 0 {
+		s.transferCycles = s.byteCycles()
+	}
+}
+
+// return the serial registers as a memory bank (0xff01 - 0xff02)
+func (s *Serial) Registers() memory.Memory {
+	return &serialRegisters{serial: s}
+}
+
+// write the serial port state (the peer keeps its own state)
+func (s *Serial) SaveState(w *savestate.Writer) {
+	w.Section("SIO")
+
+	w.Uint8(s.sb)
+	w.Uint8(s.sc)
+	w.Int(s.transferCycles)
+}
+
+// read the serial port state
+func (s *Serial) LoadState(r *savestate.Reader) error {
+	r.Section("SIO")
+
+	s.sb = r.Uint8()
+	s.sc = r.Uint8()
+	s.transferCycles = r.Int()
+
+	return r.Err()
+}
+
+// serial peer capturing the bytes sent (e.g. test ROMs reporting their results)
+type SerialCapture struct {
+	mutex  sync.Mutex
+	output []uint8
+	writer io.Writer
+}
+
+// create a new serial capture, optionally copying the bytes into a writer
+func NewSerialCapture(writer io.Writer) *SerialCapture {
+
+	return &SerialCapture{
+		writer: writer,
+	}
+}
+
+// keep the byte sent and answer as a disconnected cable (SerialPeer interface)
+func (c *SerialCapture) Exchange(value uint8) uint8 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.output = append(c.output, value)
+	if c.writer != nil {
+		c.writer.Write([]uint8{value})
+	}
+
+	return SERIAL_DISCONNECTED
+}
+
+// return the captured bytes as a string
+func (c *SerialCapture) String() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return string(c.output)
+}
+
+// serial registers view
+type serialRegisters struct {
+	serial *Serial
+}
+
+// return memory bank size
+func (m *serialRegisters) Len() uint16 {
+	return SERIAL_REGISTERS_SIZE
+}
+
+// write a serial register
+func (m *serialRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= SERIAL_REGISTERS_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.serial.writeRegister(address, value)
+
+	return nil
+}
+
+// read a serial register
+func (m *serialRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= SERIAL_REGISTERS_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.serial.readRegister(address), nil
+}
+
+// write a word into serial registers
+func (m *serialRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from serial registers
+func (m *serialRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
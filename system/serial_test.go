@@ -0,0 +1,112 @@
+////////////////////////////////////////////////////////////////////////////////
+//	serial_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy serial port
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// send a byte through the serial port with the internal clock
+func sendSerialByte(serial *Serial, value uint8, sc uint8) {
+	registers := serial.Registers()
+
+	registers.WriteByte(REG_SB, value)
+	registers.WriteByte(REG_SC, sc)
+}
+
+// serial port unit tests
+func Test_Serial(t *testing.T) {
+
+	t.Run(">>> serial: scenario 1 - internal clock at 8192 Hz", func(t *testing.T) {
+
+		var requests int
+
+		serial := NewSerial(false)
+		serial.ConnectInterrupt(func(interrupt uint8) {
+			if interrupt == cpu.INTERRUPT_SERIAL {
+				requests++
+			}
+		})
+
+		sendSerialByte(serial, 0x42, SC_TRANSFER_START|SC_INTERNAL_CLOCK)
+
+		serial.Step(8*SERIAL_BIT_CYCLES - 4)
+		sc, _ := serial.Registers().ReadByte(REG_SC)
+		if sc != 0xff || requests != 0 {
+			t.Errorf("failed serial timing: transfer completed too early (SC 0x%02x)", sc)
+		}
+
+		serial.Step(4)
+		sb, _ := serial.Registers().ReadByte(REG_SB)
+		sc, _ = serial.Registers().ReadByte(REG_SC)
+		if sb != 0xff || sc != 0x7f || requests != 1 {
+			t.Errorf("failed completing transfer: expected SB 0xff, SC 0x7f and one interrupt\n\tresult: 0x%02x, 0x%02x and %d", sb, sc, requests)
+		}
+	})
+
+	t.Run(">>> serial: scenario 2 - CGB fast clock", func(t *testing.T) {
+
+		serial := NewSerial(true)
+		sendSerialByte(serial, 0x42, SC_TRANSFER_START|SC_CLOCK_SPEED|SC_INTERNAL_CLOCK)
+
+		serial.Step(8 * SERIAL_FAST_BIT_CYCLES)
+		sc, _ := serial.Registers().ReadByte(REG_SC)
+		if sc != 0x7f {
+			t.Errorf("failed fast transfer: expected SC 0x7f\n\tresult: 0x%02x", sc)
+		}
+
+		//	the clock speed bit does not exist on DMG
+		serial = NewSerial(false)
+		sendSerialByte(serial, 0x42, SC_TRANSFER_START|SC_CLOCK_SPEED|SC_INTERNAL_CLOCK)
+
+		serial.Step(8 * SERIAL_FAST_BIT_CYCLES)
+		sc, _ = serial.Registers().ReadByte(REG_SC)
+		if sc != 0xff {
+			t.Errorf("failed DMG transfer: expected SC 0xff\n\tresult: 0x%02x", sc)
+		}
+	})
+
+	t.Run(">>> serial: scenario 3 - external clock", func(t *testing.T) {
+
+		serial := NewSerial(false)
+
+		_, ok := serial.ExternalTransfer(0x12)
+		if ok {
+			t.Errorf("failed external transfer: expected no transfer before SC is written")
+		}
+
+		sendSerialByte(serial, 0x34, SC_TRANSFER_START)
+		serial.Step(8 * SERIAL_BIT_CYCLES)
+
+		sent, ok := serial.ExternalTransfer(0x12)
+		sb, _ := serial.Registers().ReadByte(REG_SB)
+		if !ok || sent != 0x34 || sb != 0x12 {
+			t.Errorf("failed external transfer: expected 0x34 sent and 0x12 received\n\tresult: 0x%02x and 0x%02x", sent, sb)
+		}
+	})
+
+	t.Run(">>> serial: scenario 4 - capture the output of a test ROM", func(t *testing.T) {
+
+		var copied strings.Builder
+
+		serial := NewSerial(false)
+		capture := NewSerialCapture(&copied)
+		serial.ConnectPeer(capture)
+
+		for _, value := range []uint8("Passed") {
+			sendSerialByte(serial, value, SC_TRANSFER_START|SC_INTERNAL_CLOCK)
+			serial.Step(8 * SERIAL_BIT_CYCLES)
+		}
+
+		if capture.String() != "Passed" || copied.String() != "Passed" {
+			t.Errorf("failed capturing serial output: expected: Passed\n\tresult: %q", capture.String())
+		}
+	})
+}
@@ -0,0 +1,108 @@
+////////////////////////////////////////////////////////////////////////////////
+//	statehash_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the deterministic execution and the state hashes
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"context"
+	"testing"
+)
+
+// joypad buttons changing every few frames
+func testInput(frame uint64) uint8 {
+	return uint8(frame/5*29) ^ uint8(frame>>3)
+}
+
+// run frames from the power on returning the state hash after each one
+func runStateHashes(t *testing.T, model uint8, input InputFunc, frames int) []uint64 {
+	var hashes []uint64
+
+	system, _ := NewSystem(buildCounterROM(), model, nil, false)
+	system.Joypad().ConnectInput(input)
+
+	for range frames {
+		err := system.RunFrame()
+		if err != nil {
+			t.Fatalf("failed running frame: %v", err)
+		}
+		hashes = append(hashes, system.StateHash())
+	}
+
+	return hashes
+}
+
+// compare two hash sequences returning the first frame that diverges (-1 when identical)
+func firstDivergence(a []uint64, b []uint64) int {
+
+	for i := range min(len(a), len(b)) {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	if len(a) != len(b) {
+		return min(len(a), len(b))
+	}
+
+	return -1
+}
+
+// deterministic execution unit tests
+func Test_StateHash(t *testing.T) {
+
+	t.Run(">>> state hash: scenario 1 - same inputs, same hashes", func(t *testing.T) {
+
+		for _, model := range []uint8{MODEL_DMG, MODEL_CGB} {
+			expected := runStateHashes(t, model, testInput, 120)
+			result := runStateHashes(t, model, testInput, 120)
+
+			if frame := firstDivergence(expected, result); frame != -1 {
+				t.Errorf("failed running deterministically (%s): expected: identical hashes\n\tresult: divergence at frame %d",
+					modelName(model), frame)
+			}
+		}
+	})
+
+	t.Run(">>> state hash: scenario 2 - another input diverges", func(t *testing.T) {
+
+		expected := runStateHashes(t, MODEL_DMG, testInput, 60)
+		result := runStateHashes(t, MODEL_DMG, func(frame uint64) uint8 {
+			if frame == 40 {
+				return testInput(frame) ^ BUTTON_START
+			}
+			return testInput(frame)
+		}, 60)
+
+		if frame := firstDivergence(expected, result); frame != 40 {
+			t.Errorf("failed detecting the input: expected: divergence at frame 40\n\tresult: %d", frame)
+		}
+	})
+
+	t.Run(">>> state hash: scenario 3 - the host clock doesn't leak into the emulation", func(t *testing.T) {
+		var result []uint64
+
+		expected := runStateHashes(t, MODEL_DMG, testInput, 30)
+
+		system, _ := NewSystem(buildCounterROM(), MODEL_DMG, nil, false)
+		system.Joypad().ConnectInput(InputFunc(testInput))
+		system.SetSpeed(SPEED_UNTHROTTLED)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		system.ConnectFrameHandler(func(frame uint64) error {
+			result = append(result, system.StateHash())
+			if frame == 30 {
+				cancel()
+			}
+			return nil
+		})
+
+		err := system.Run(ctx)
+		if frame := firstDivergence(expected, result); err != nil || frame != -1 {
+			t.Errorf("failed running deterministically with Run: expected: identical hashes\n\tresult: divergence at frame %d (%v)", frame, err)
+		}
+	})
+}
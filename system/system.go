@@ -0,0 +1,506 @@
+////////////////////////////////////////////////////////////////////////////////
+//	system.go - Oct-18-2026 by aldebap
+//
+//	Game Boy system: the CPU, cartridge and peripherals wired into the bus
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldebap/go_gbc/apu"
+	"github.com/aldebap/go_gbc/cartridge"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// Game Boy models
+const (
+	MODEL_AUTO = uint8(0)
+	MODEL_DMG  = uint8(1)
+	MODEL_CGB  = uint8(2)
+)
+
+// system memory map
+const (
+	WRAM_ADDRESS        = 0xc000
+	WRAM_BANK_SIZE      = 0x1000
+	WRAM_CGB_BANKS      = 8
+	ECHO_RAM_ADDRESS    = 0xe000
+	ECHO_RAM_SIZE       = 0x1e00
+	HRAM_ADDRESS        = 0xff80
+	HRAM_SIZE           = 0x7f
+	IF_REGISTER         = 0xff0f
+	IE_REGISTER         = 0xffff
+	KEY1_REGISTER       = 0xff4d
+	BOOT_ROM_REGISTER   = 0xff50
+	HDMA_REGISTERS      = 0xff51
+	HDMA_REGISTERS_SIZE = 0x05
+	SVBK_REGISTER       = 0xff70
+	OAM_DMA_REGISTER    = ppu.LCD_REGISTERS + ppu.REG_DMA
+)
+
+// boot ROM sizes (the CGB boot ROM leaves 0x0100 - 0x01ff to the cartridge header)
+const (
+	DMG_BOOT_ROM_SIZE = 0x0100
+	CGB_BOOT_ROM_SIZE = 0x0900
+	CARTRIDGE_ENTRY   = 0x0100
+)
+
+// Game Boy system
+type System struct {
+	model   uint8
+	cgbMode bool
+
+	cpu       *cpu.SM83_CPU
+	bus       *memory.Bus
+	cartridge *cartridge.Cartridge
+	rom       memory.Memory
+	ppu       *ppu.PPU
+	apu       *apu.APU
+	timer     *Timer
+	joypad    *Joypad
+	serial    *Serial
+	infrared  *Infrared
+
+	wram     [WRAM_CGB_BANKS][]uint8
+	wramBank uint8
+	hram     *memory.RAM_memory
+
+	interruptFlag   uint8
+	interruptEnable uint8
+
+	romChecksum    uint32
+	bootROM        []uint8
+	bootROMEnabled bool
+
+	key1            uint8
+	hdmaSource      uint16
+	hdmaDestination uint16
+
+	frame  uint64
+	cycles uint64
+
+	run    *runControl
+	rewind *rewindBuffer
+	cheats cheatEngine
+}
+
+// parse a model name: auto, dmg or cgb
+func ParseModel(value string) (uint8, error) {
+
+	switch strings.ToLower(value) {
+	case "auto", "":
+		return MODEL_AUTO, nil
+	case "dmg":
+		return MODEL_DMG, nil
+	case "cgb", "gbc":
+		return MODEL_CGB, nil
+	}
+
+	return 0, fmt.Errorf("invalid model: %s", value)
+}
+
+// create a new system running a ROM: with MODEL_AUTO the CGB features are enabled for CGB cartridges,
+// without a boot ROM the system starts with the registers set by the boot ROM
+func NewSystem(rom []uint8, model uint8, bootROM []uint8, trace bool) (*System, error) {
+
+	gameCartridge, err := cartridge.NewCartridge(rom)
+	if err != nil {
+		return nil, err
+	}
+
+	if model == MODEL_AUTO {
+		model = MODEL_DMG
+		if gameCartridge.CGBSupport() {
+			model = MODEL_CGB
+		}
+	}
+
+	//	a CGB runs DMG cartridges in compatibility mode
+	cgbMode := model == MODEL_CGB && gameCartridge.CGBSupport()
+
+	if bootROM != nil {
+		expected := DMG_BOOT_ROM_SIZE
+		if model == MODEL_CGB {
+			expected = CGB_BOOT_ROM_SIZE
+		}
+		if len(bootROM) != expected {
+			return nil, fmt.Errorf("invalid boot ROM size: expected %d bytes, found %d", expected, len(bootROM))
+		}
+	}
+
+	s := &System{
+		model:     model,
+		cgbMode:   cgbMode,
+		cpu:       cpu.NewSM83_CPU(trace),
+		bus:       memory.NewBus(),
+		cartridge: gameCartridge,
+		rom:       gameCartridge.ROM(),
+		ppu:       ppu.NewPPU(cgbMode),
+		apu:       apu.NewAPU(cgbMode),
+		timer:     NewTimer(),
+		joypad:    NewJoypad(),
+		serial:    NewSerial(cgbMode),
+		infrared:  NewInfrared(),
+		hram:      memory.NewRAM_memory(HRAM_SIZE),
+		wramBank:  1,
+
+		romChecksum:    ROMChecksum(rom),
+		bootROM:        bootROM,
+		bootROMEnabled: bootROM != nil,
+
+		run: newRunControl(),
+	}
+
+	for i := range s.wram {
+		s.wram[i] = make([]uint8, WRAM_BANK_SIZE)
+	}
+
+	s.ppu.ConnectInterrupt(s.RequestInterrupt)
+	s.timer.ConnectInterrupt(s.RequestInterrupt)
+	s.timer.ConnectDIVReset(s.apu.ResetDIV)
+	s.joypad.ConnectInterrupt(s.RequestInterrupt)
+	s.serial.ConnectInterrupt(s.RequestInterrupt)
+
+	s.connectBus()
+
+	s.cpu.ConnectMemory(s.bus, 0x0000)
+	s.cpu.ConnectMemory(memory.NewRegisterBank(1,
+		func(uint16) uint8 { return s.interruptEnable },
+		func(_ uint16, value uint8) { s.interruptEnable = value },
+	), IE_REGISTER)
+	s.cpu.ConnectInterrupts(
+		func() uint8 { return s.interruptFlag & s.interruptEnable },
+		func(interrupt uint8) { s.interruptFlag &^= interrupt },
+	)
+
+	if !s.bootROMEnabled {
+		s.skipBootROM()
+	}
+
+	return s, nil
+}
+
+// connect all memory banks to the bus (registers inside larger banks are connected first)
+func (s *System) connectBus() {
+
+	s.bus.ConnectMemory(memory.NewRegisterBank(cartridge.CARTRIDGE_ROM_SIZE, s.readROM, s.writeROM), cartridge.CARTRIDGE_ROM_ADDRESS)
+	s.bus.ConnectMemory(s.ppu.VRAM(), ppu.VRAM_ADDRESS)
+	s.bus.ConnectMemory(s.cartridge.RAM(), cartridge.CARTRIDGE_RAM_ADDRESS)
+	s.bus.ConnectMemory(memory.NewRegisterBank(2*WRAM_BANK_SIZE, s.readWRAM, s.writeWRAM), WRAM_ADDRESS)
+	s.bus.ConnectMemory(memory.NewRegisterBank(ECHO_RAM_SIZE, s.readWRAM, s.writeWRAM), ECHO_RAM_ADDRESS)
+	s.bus.ConnectMemory(s.ppu.OAM(), ppu.OAM_ADDRESS)
+
+	s.bus.ConnectMemory(s.joypad.Registers(), JOYPAD_REGISTER)
+	s.bus.ConnectMemory(s.serial.Registers(), SERIAL_REGISTERS)
+	s.bus.ConnectMemory(s.timer.Registers(), TIMER_REGISTERS)
+	s.bus.ConnectMemory(memory.NewRegisterBank(1,
+		func(uint16) uint8 { return s.interruptFlag | 0xe0 },
+		func(_ uint16, value uint8) { s.interruptFlag = value & 0x1f },
+	), IF_REGISTER)
+	s.bus.ConnectMemory(s.apu.Registers(), apu.APU_REGISTERS)
+	s.bus.ConnectMemory(memory.NewRegisterBank(1, s.readOAMDMA, s.writeOAMDMA), OAM_DMA_REGISTER)
+	s.bus.ConnectMemory(memory.NewRegisterBank(1, nil, s.writeBootROMRegister), BOOT_ROM_REGISTER)
+
+	if s.cgbMode {
+		s.bus.ConnectMemory(memory.NewRegisterBank(1, s.readKEY1, s.writeKEY1), KEY1_REGISTER)
+		s.bus.ConnectMemory(memory.NewRegisterBank(HDMA_REGISTERS_SIZE, s.readHDMA, s.writeHDMA), HDMA_REGISTERS)
+		s.bus.ConnectMemory(s.infrared.Registers(), INFRARED_REGISTER)
+		s.bus.ConnectMemory(s.ppu.PaletteRegisters(), ppu.CGB_PALETTE_ADDRESS)
+		s.bus.ConnectMemory(memory.NewRegisterBank(1, s.readSVBK, s.writeSVBK), SVBK_REGISTER)
+		s.bus.ConnectMemory(s.apu.PCMRegisters(), apu.APU_PCM_REGISTERS)
+	}
+
+	s.bus.ConnectMemory(s.ppu.Registers(), ppu.LCD_REGISTERS)
+	s.bus.ConnectMemory(s.hram, HRAM_ADDRESS)
+}
+
+// set the registers as the boot ROM leaves them
+func (s *System) skipBootROM() {
+
+	switch {
+	case s.model == MODEL_CGB:
+		s.cpu.SetRegisters(0x1180, 0x0000, 0xff56, 0x000d, 0xfffe, CARTRIDGE_ENTRY)
+
+	default:
+		s.cpu.SetRegisters(0x01b0, 0x0013, 0x00d8, 0x014d, 0xfffe, CARTRIDGE_ENTRY)
+	}
+}
+
+// request an interrupt setting its bit in IF (InterruptRequester)
+func (s *System) RequestInterrupt(interrupt uint8) {
+	s.interruptFlag |= interrupt
+}
+
+// return the pending interrupts (IF)
+func (s *System) InterruptFlag() uint8 {
+	return s.interruptFlag
+}
+
+// read the cartridge ROM, or the boot ROM while it is mapped (with the Game Genie patches applied)
+func (s *System) readROM(address uint16) uint8 {
+
+	if s.bootROMEnabled && int(address) < len(s.bootROM) &&
+		(address < DMG_BOOT_ROM_SIZE || address >= CARTRIDGE_ENTRY+0x0100) {
+		return s.bootROM[address]
+	}
+
+	value, _ := s.rom.ReadByte(address)
+	if s.cheats.romPatches != nil {
+		value = s.cheats.patchROM(address, value)
+	}
+
+	return value
+}
+
+// write the cartridge memory bank controller registers
+func (s *System) writeROM(address uint16, value uint8) {
+	s.rom.WriteByte(address, value)
+}
+
+// writing a non zero value into 0xff50 unmaps the boot ROM
+func (s *System) writeBootROMRegister(_ uint16, value uint8) {
+	if value != 0 {
+		s.bootROMEnabled = false
+	}
+}
+
+// WRAM bank and offset of an address relative to 0xc000 (or to the echo RAM)
+func (s *System) wramCell(address uint16) (uint8, uint16) {
+	address %= 2 * WRAM_BANK_SIZE
+
+	if address < WRAM_BANK_SIZE {
+		return 0, address
+	}
+
+	return s.wramBank, address - WRAM_BANK_SIZE
+}
+
+// read the work RAM
+func (s *System) readWRAM(address uint16) uint8 {
+	bank, offset := s.wramCell(address)
+
+	return s.wram[bank][offset]
+}
+
+// write the work RAM
+func (s *System) writeWRAM(address uint16, value uint8) {
+	bank, offset := s.wramCell(address)
+
+	s.wram[bank][offset] = value
+}
+
+// read SVBK (CGB WRAM bank)
+func (s *System) readSVBK(uint16) uint8 {
+	return s.wramBank | 0xf8
+}
+
+// write SVBK: bank 0 selects bank 1
+func (s *System) writeSVBK(_ uint16, value uint8) {
+	s.wramBank = max(value&0x07, 1)
+}
+
+// read KEY1 (CGB speed switch)
+func (s *System) readKEY1(uint16) uint8 {
+	return s.key1 | 0x7e
+}
+
+// write KEY1: the speed switch is prepared but double speed is not emulated
+func (s *System) writeKEY1(_ uint16, value uint8) {
+	s.key1 = s.key1&0x80 | value&0x01
+}
+
+// read the OAM DMA register
+func (s *System) readOAMDMA(uint16) uint8 {
+	value, _ := s.ppu.Registers().ReadByte(ppu.REG_DMA)
+
+	return value
+}
+
+// writing the OAM DMA register copies 160 bytes from (value << 8) into OAM
+func (s *System) writeOAMDMA(_ uint16, value uint8) {
+	var source = uint16(value) << 8
+
+	var oam = s.ppu.OAM()
+
+	s.ppu.Registers().WriteByte(ppu.REG_DMA, value)
+
+	for i := range uint16(ppu.OAM_SIZE) {
+		data, _ := s.bus.ReadByte(source + i)
+		oam.WriteByte(i, data)
+	}
+}
+
+// read the HDMA registers: only HDMA5 is readable and the transfers complete immediately
+func (s *System) readHDMA(uint16) uint8 {
+	return 0xff
+}
+
+// write the HDMA registers: writing HDMA5 copies (length + 1) * 16 bytes into VRAM
+// (HBlank transfers are executed immediately as general purpose transfers)
+func (s *System) writeHDMA(address uint16, value uint8) {
+
+	switch address {
+	case 0x00:
+		s.hdmaSource = s.hdmaSource&0x00ff | uint16(value)<<8
+	case 0x01:
+		s.hdmaSource = s.hdmaSource&0xff00 | uint16(value&0xf0)
+	case 0x02:
+		s.hdmaDestination = s.hdmaDestination&0x00ff | uint16(value&0x1f)<<8
+	case 0x03:
+		s.hdmaDestination = s.hdmaDestination&0xff00 | uint16(value&0xf0)
+	case 0x04:
+		length := (uint16(value&0x7f) + 1) * 16
+		vram := s.ppu.VRAM()
+
+		for i := range length {
+			data, _ := s.bus.ReadByte(s.hdmaSource + i)
+			vram.WriteByte((s.hdmaDestination+i)&(ppu.VRAM_SIZE-1), data)
+		}
+
+		s.hdmaSource += length
+		s.hdmaDestination += length
+	}
+}
+
+// run one machine cycle (4 T-cycles) of the CPU and the peripherals
+func (s *System) Step() error {
+
+	err := s.cpu.MachineCycle()
+	if err != nil {
+		return err
+	}
+
+	s.timer.Step(4)
+	s.ppu.Step(4)
+	s.apu.Step(4)
+	s.serial.Step(4)
+	s.cycles += 4
+
+	return nil
+}
+
+// run until the end of a frame (the start of VBlank, or a frame worth of cycles while the LCD is off),
+// polling the joypad input source at the start of the frame
+func (s *System) RunFrame() error {
+	return s.runFrame(func() bool { return false })
+}
+
+// run until the end of a frame, stopping early when the CPU reaches an address
+func (s *System) RunFrameUntilPC(address uint16) error {
+	return s.runFrame(func() bool { return s.cpu.PC() == address })
+}
+
+// run one frame: every machine cycle the frame ends if stop returns true
+func (s *System) runFrame(stop func() bool) error {
+	var start = s.cycles
+
+	s.joypad.PollInput()
+	s.applyCheats()
+
+	for {
+		err := s.Step()
+		if err != nil {
+			return err
+		}
+
+		if s.ppu.FrameReady() || s.cycles-start >= ppu.FRAME_CYCLES || stop() {
+			break
+		}
+	}
+
+	s.frame++
+
+	if s.rewind != nil {
+		s.rewind.capture(s)
+	}
+
+	return nil
+}
+
+// create a post processor for the screen: DMG cartridges on a CGB use the boot ROM compatibility palette
+func (s *System) NewPostProcessor(colorCorrection uint8, dmgPalette *ppu.DMG_palette) (*ppu.PostProcessor, error) {
+
+	postProcessor, err := ppu.NewPostProcessor(colorCorrection, dmgPalette)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.model == MODEL_CGB && !s.cgbMode {
+		err = postProcessor.UseCompatibilityPalette(s.cartridge.ROMImage())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return postProcessor, nil
+}
+
+// return the number of frames run
+func (s *System) Frame() uint64 {
+	return s.frame
+}
+
+// return the number of T-cycles run
+func (s *System) Cycles() uint64 {
+	return s.cycles
+}
+
+// return the running model
+func (s *System) Model() uint8 {
+	return s.model
+}
+
+// return true if the CGB features are enabled
+func (s *System) CGBMode() bool {
+	return s.cgbMode
+}
+
+// return the CPU
+func (s *System) CPU() *cpu.SM83_CPU {
+	return s.cpu
+}
+
+// return the bus
+func (s *System) Bus() *memory.Bus {
+	return s.bus
+}
+
+// return the cartridge
+func (s *System) Cartridge() *cartridge.Cartridge {
+	return s.cartridge
+}
+
+// return the PPU
+func (s *System) PPU() *ppu.PPU {
+	return s.ppu
+}
+
+// return the APU
+func (s *System) APU() *apu.APU {
+	return s.apu
+}
+
+// return the timer
+func (s *System) Timer() *Timer {
+	return s.timer
+}
+
+// return the joypad
+func (s *System) Joypad() *Joypad {
+	return s.joypad
+}
+
+// return the serial port
+func (s *System) Serial() *Serial {
+	return s.serial
+}
+
+// return the infrared port
+func (s *System) Infrared() *Infrared {
+	return s.infrared
+}
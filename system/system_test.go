@@ -0,0 +1,201 @@
+////////////////////////////////////////////////////////////////////////////////
+//	system_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy system
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"testing"
+
+	"github.com/aldebap/go_gbc/cartridge"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/ppu"
+)
+
+// build a ROM looping forever at the cartridge entry point (JR -2)
+func buildLoopROM(cgbFlag uint8) []uint8 {
+	rom := make([]uint8, 2*cartridge.ROM_BANK_SIZE)
+
+	copy(rom[cartridge.CARTRIDGE_TITLE_ADDRESS:], "TEST")
+	rom[CARTRIDGE_ENTRY] = cpu.JR_e
+	rom[CARTRIDGE_ENTRY+1] = 0xfe
+	rom[cartridge.CARTRIDGE_CGB_FLAG_ADDRESS] = cgbFlag
+
+	return rom
+}
+
+// system unit tests
+func Test_System(t *testing.T) {
+
+	t.Run(">>> system: scenario 1 - model selection", func(t *testing.T) {
+
+		for _, test := range []struct {
+			model    uint8
+			cgbFlag  uint8
+			expected uint8
+			cgbMode  bool
+		}{
+			{model: MODEL_AUTO, cgbFlag: 0x00, expected: MODEL_DMG, cgbMode: false},
+			{model: MODEL_AUTO, cgbFlag: cartridge.CARTRIDGE_CGB_SUPPORT, expected: MODEL_CGB, cgbMode: true},
+			{model: MODEL_CGB, cgbFlag: 0x00, expected: MODEL_CGB, cgbMode: false},
+			{model: MODEL_DMG, cgbFlag: cartridge.CARTRIDGE_CGB_SUPPORT, expected: MODEL_DMG, cgbMode: false},
+		} {
+			system, err := NewSystem(buildLoopROM(test.cgbFlag), test.model, nil, false)
+			if err != nil {
+				t.Fatalf("failed creating system: %v", err)
+			}
+
+			if system.Model() != test.expected || system.CGBMode() != test.cgbMode {
+				t.Errorf("failed selecting model %d: expected: %d (CGB mode %v)\n\tresult: %d (CGB mode %v)",
+					test.model, test.expected, test.cgbMode, system.Model(), system.CGBMode())
+			}
+		}
+
+		_, err := NewSystem(buildLoopROM(0x00), MODEL_DMG, make([]uint8, CGB_BOOT_ROM_SIZE), false)
+		if err == nil {
+			t.Errorf("failed rejecting a boot ROM of the wrong size")
+		}
+	})
+
+	t.Run(">>> system: scenario 2 - memory map", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(cartridge.CARTRIDGE_CGB_SUPPORT), MODEL_CGB, nil, false)
+		bus := system.Bus()
+
+		bus.WriteByte(0xc123, 0x11)
+		echo, _ := bus.ReadByte(0xe123)
+		if echo != 0x11 {
+			t.Errorf("failed reading echo RAM: expected: 0x11\n\tresult: 0x%02x", echo)
+		}
+
+		bus.WriteByte(SVBK_REGISTER, 0x02)
+		bus.WriteByte(0xd000, 0x22)
+		bus.WriteByte(SVBK_REGISTER, 0x00)
+		value, _ := bus.ReadByte(0xd000)
+		if value != 0x00 {
+			t.Errorf("failed switching WRAM bank: expected: 0x00\n\tresult: 0x%02x", value)
+		}
+
+		bus.WriteByte(SVBK_REGISTER, 0x02)
+		value, _ = bus.ReadByte(0xd000)
+		if value != 0x22 {
+			t.Errorf("failed reading WRAM bank 2: expected: 0x22\n\tresult: 0x%02x", value)
+		}
+
+		bus.WriteByte(HRAM_ADDRESS, 0x33)
+		value, _ = bus.ReadByte(HRAM_ADDRESS)
+		if value != 0x33 {
+			t.Errorf("failed reading HRAM: expected: 0x33\n\tresult: 0x%02x", value)
+		}
+
+		value, _ = bus.ReadByte(0xfea0)
+		if value != memory.BUS_UNMAPPED_READ {
+			t.Errorf("failed reading unmapped address: expected: 0x%02x\n\tresult: 0x%02x", memory.BUS_UNMAPPED_READ, value)
+		}
+
+		system.RequestInterrupt(cpu.INTERRUPT_TIMER)
+		value, _ = bus.ReadByte(IF_REGISTER)
+		if value != 0xe0|cpu.INTERRUPT_TIMER {
+			t.Errorf("failed reading IF: expected: 0x%02x\n\tresult: 0x%02x", 0xe0|cpu.INTERRUPT_TIMER, value)
+		}
+	})
+
+	t.Run(">>> system: scenario 3 - OAM DMA", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+		bus := system.Bus()
+
+		for i := range uint16(ppu.OAM_SIZE) {
+			bus.WriteByte(0xc100+i, uint8(i))
+		}
+		bus.WriteByte(OAM_DMA_REGISTER, 0xc1)
+
+		first, _ := bus.ReadByte(ppu.OAM_ADDRESS)
+		last, _ := bus.ReadByte(ppu.OAM_ADDRESS + ppu.OAM_SIZE - 1)
+		if first != 0x00 || last != ppu.OAM_SIZE-1 {
+			t.Errorf("failed OAM DMA: expected: 0x00 and 0x%02x\n\tresult: 0x%02x and 0x%02x", ppu.OAM_SIZE-1, first, last)
+		}
+	})
+
+	t.Run(">>> system: scenario 4 - boot ROM overlay", func(t *testing.T) {
+
+		bootROM := make([]uint8, DMG_BOOT_ROM_SIZE)
+		bootROM[0x00] = 0x31
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, bootROM, false)
+		bus := system.Bus()
+
+		value, _ := bus.ReadByte(0x0000)
+		if value != 0x31 || system.CPU().PC() != 0x0000 {
+			t.Errorf("failed mapping boot ROM: expected: 0x31 at PC 0x0000\n\tresult: 0x%02x at PC 0x%04x", value, system.CPU().PC())
+		}
+
+		bus.WriteByte(BOOT_ROM_REGISTER, 0x01)
+		value, _ = bus.ReadByte(0x0000)
+		if value != 0x00 {
+			t.Errorf("failed unmapping boot ROM: expected: 0x00\n\tresult: 0x%02x", value)
+		}
+	})
+
+	t.Run(">>> system: scenario 5 - run frames", func(t *testing.T) {
+
+		system, _ := NewSystem(buildLoopROM(0x00), MODEL_DMG, nil, false)
+
+		for range 3 {
+			err := system.RunFrame()
+			if err != nil {
+				t.Fatalf("failed running frame: %v", err)
+			}
+		}
+
+		if system.Frame() != 3 || system.InterruptFlag()&cpu.INTERRUPT_VBLANK == 0 {
+			t.Errorf("failed running frames: expected: 3 frames and VBlank requested\n\tresult: %d frames and IF 0x%02x",
+				system.Frame(), system.InterruptFlag())
+		}
+
+		pc := system.CPU().PC()
+		if pc < CARTRIDGE_ENTRY || pc > CARTRIDGE_ENTRY+2 {
+			t.Errorf("failed running the loop: expected: PC near 0x%04x\n\tresult: 0x%04x", CARTRIDGE_ENTRY, pc)
+		}
+	})
+
+	t.Run(">>> system: scenario 6 - VBlank interrupt serviced while halted", func(t *testing.T) {
+
+		rom := buildLoopROM(0x00)
+		copy(rom[CARTRIDGE_ENTRY:], []uint8{
+			cpu.LD_A_n, cpu.INTERRUPT_VBLANK,
+			cpu.LDH_ADDR_n_A, 0xff,
+			cpu.EI,
+			cpu.HALT,
+			cpu.JR_e, 0xfd,
+		})
+
+		//	the handler counts the interrupts in the work RAM
+		copy(rom[cpu.INTERRUPT_VECTOR:], []uint8{
+			cpu.LD_A_ADDR_nn, 0x00, 0xc0,
+			cpu.INC_A,
+			cpu.LD_ADDR_nn_A, 0x00, 0xc0,
+			cpu.RETI,
+		})
+
+		system, _ := NewSystem(rom, MODEL_DMG, nil, false)
+		system.Bus().WriteByte(0xc000, 0x00)
+
+		for range 4 {
+			err := system.RunFrame()
+			if err != nil {
+				t.Fatalf("failed running frame: %v", err)
+			}
+		}
+
+		//	every frame ends when VBlank is requested, so the last request is still pending
+		counter, _ := system.Bus().ReadByte(0xc000)
+		if counter != 3 || system.InterruptFlag()&cpu.INTERRUPT_VBLANK == 0 {
+			t.Errorf("failed servicing VBlank: expected: 3 interrupts and the last one pending\n\tresult: %d interrupts, IF 0x%02x",
+				counter, system.InterruptFlag())
+		}
+	})
+}
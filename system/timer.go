@@ -0,0 +1,205 @@
+////////////////////////////////////////////////////////////////////////////////
+//	timer.go - Oct-18-2026 by aldebap
+//
+//	Emulator for the Game Boy timer (DIV, TIMA, TMA and TAC registers)
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
+	"github.com/aldebap/go_gbc/savestate"
+)
+
+// timer memory map
+const (
+	TIMER_REGISTERS      = 0xff04
+	TIMER_REGISTERS_SIZE = 0x04
+)
+
+// timer registers (offset from 0xff04)
+const (
+	REG_DIV  = 0x00
+	REG_TIMA = 0x01
+	REG_TMA  = 0x02
+	REG_TAC  = 0x03
+)
+
+// TAC flags
+const (
+	TAC_ENABLE      = uint8(0x04)
+	TAC_CLOCK       = uint8(0x03)
+	TAC_UNUSED_BITS = uint8(0xf8)
+)
+
+// bit of the internal counter clocking TIMA for every TAC clock select (4096, 262144, 65536 and 16384 Hz)
+var timerClockBit = [4]uint16{0x0200, 0x0008, 0x0020, 0x0080}
+
+// timer registers and the internal 16 bit counter (DIV is its high byte)
+type Timer struct {
+	counter uint16
+	tima    uint8
+	tma     uint8
+	tac     uint8
+
+	requestInterrupt cpu.InterruptRequester
+	divReset         func()
+}
+
+// create a new timer
+func NewTimer() *Timer {
+	return &Timer{}
+}
+
+// connect the interrupt requester
+func (t *Timer) ConnectInterrupt(requestInterrupt cpu.InterruptRequester) {
+	t.requestInterrupt = requestInterrupt
+}
+
+// connect a function called when DIV is reset (e.g. the APU frame sequencer)
+func (t *Timer) ConnectDIVReset(divReset func()) {
+	t.divReset = divReset
+}
+
+// return the signal clocking TIMA: the selected counter bit and the enable flag
+func (t *Timer) timerSignal() bool {
+	return t.tac&TAC_ENABLE != 0 && t.counter&timerClockBit[t.tac&TAC_CLOCK] != 0
+}
+
+// increment TIMA reloading it from TMA and requesting the timer interrupt on overflow
+func (t *Timer) incrementTIMA() {
+	t.tima++
+
+	if t.tima == 0 {
+		t.tima = t.tma
+		if t.requestInterrupt != nil {
+			t.requestInterrupt(cpu.INTERRUPT_TIMER)
+		}
+	}
+}
+
+// run the timer for a number of T-cycles: TIMA is incremented on the falling edge of the timer signal
+func (t *Timer) Step(cycles int) {
+
+	for range cycles {
+		before := t.timerSignal()
+		t.counter++
+
+		if before && !t.timerSignal() {
+			t.incrementTIMA()
+		}
+	}
+}
+
+// update the timer signal after a register write, a falling edge also increments TIMA
+func (t *Timer) update(counter uint16, tac uint8) {
+	before := t.timerSignal()
+
+	t.counter = counter
+	t.tac = tac & (TAC_ENABLE | TAC_CLOCK)
+
+	if before && !t.timerSignal() {
+		t.incrementTIMA()
+	}
+}
+
+// read a timer register
+func (t *Timer) readRegister(register uint16) uint8 {
+
+	switch register {
+	case REG_DIV:
+		return uint8(t.counter >> 8)
+	case REG_TIMA:
+		return t.tima
+	case REG_TMA:
+		return t.tma
+	}
+
+	return t.tac | TAC_UNUSED_BITS
+}
+
+// write a timer register (any write into DIV resets the counter)
+func (t *Timer) writeRegister(register uint16, value uint8) {
+
+	switch register {
+	case REG_DIV:
+		t.update(0, t.tac)
+		if t.divReset != nil {
+			t.divReset()
+		}
+	case REG_TIMA:
+		t.tima = value
+	case REG_TMA:
+		t.tma = value
+	case REG_TAC:
+		t.update(t.counter, value)
+	}
+}
+
+// return the timer registers as a memory bank (0xff04 - 0xff07)
+func (t *Timer) Registers() memory.Memory {
+	return &timerRegisters{timer: t}
+}
+
+// write the timer state
+func (t *Timer) SaveState(w *savestate.Writer) {
+	w.Section("TIMR")
+
+	w.Uint16(t.counter)
+	w.Uint8(t.tima)
+	w.Uint8(t.tma)
+	w.Uint8(t.tac)
+}
+
+// read the timer state
+func (t *Timer) LoadState(r *savestate.Reader) error {
+	r.Section("TIMR")
+
+	t.counter = r.Uint16()
+	t.tima = r.Uint8()
+	t.tma = r.Uint8()
+	t.tac = r.Uint8()
+
+	return r.Err()
+}
+
+// timer registers view
+type timerRegisters struct {
+	timer *Timer
+}
+
+// return memory bank size
+func (m *timerRegisters) Len() uint16 {
+	return TIMER_REGISTERS_SIZE
+}
+
+// write a timer register
+func (m *timerRegisters) WriteByte(address uint16, value uint8) error {
+	if address >= TIMER_REGISTERS_SIZE {
+		return memory.ErrAddressOutOfBounds
+	}
+
+	m.timer.writeRegister(address, value)
+
+	return nil
+}
+
+// read a timer register
+func (m *timerRegisters) ReadByte(address uint16) (uint8, error) {
+	if address >= TIMER_REGISTERS_SIZE {
+		return 0, memory.ErrAddressOutOfBounds
+	}
+
+	return m.timer.readRegister(address), nil
+}
+
+// write a word into timer registers
+func (m *timerRegisters) WriteWord(address uint16, value uint16) error {
+	return memory.WriteWordAsBytes(m, address, value)
+}
+
+// read a word from timer registers
+func (m *timerRegisters) ReadWord(address uint16) (uint16, error) {
+	return memory.ReadWordAsBytes(m, address)
+}
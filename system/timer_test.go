@@ -0,0 +1,96 @@
+////////////////////////////////////////////////////////////////////////////////
+//	timer_test.go - Oct-18-2026 by aldebap
+//
+//	Test cases for the Game Boy timer
+////////////////////////////////////////////////////////////////////////////////
+
+package system
+
+import (
+	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+)
+
+// timer unit tests
+func Test_Timer(t *testing.T) {
+
+	t.Run(">>> timer: scenario 1 - DIV increments every 256 cycles", func(t *testing.T) {
+
+		timer := NewTimer()
+
+		timer.Step(255)
+		div, _ := timer.Registers().ReadByte(REG_DIV)
+		if div != 0x00 {
+			t.Errorf("failed DIV timing: expected: 0x00\n\tresult: 0x%02x", div)
+		}
+
+		timer.Step(1)
+		div, _ = timer.Registers().ReadByte(REG_DIV)
+		if div != 0x01 {
+			t.Errorf("failed DIV timing: expected: 0x01\n\tresult: 0x%02x", div)
+		}
+	})
+
+	t.Run(">>> timer: scenario 2 - TIMA clock selects", func(t *testing.T) {
+
+		for clock, cycles := range []int{1024, 16, 64, 256} {
+			timer := NewTimer()
+			timer.Registers().WriteByte(REG_TAC, TAC_ENABLE|uint8(clock))
+
+			timer.Step(cycles * 3)
+			tima, _ := timer.Registers().ReadByte(REG_TIMA)
+			if tima != 3 {
+				t.Errorf("failed TIMA clock %d: expected: 3\n\tresult: %d", clock, tima)
+			}
+		}
+	})
+
+	t.Run(">>> timer: scenario 3 - TIMA overflow reloads TMA and requests the interrupt", func(t *testing.T) {
+
+		var requests int
+
+		timer := NewTimer()
+		timer.ConnectInterrupt(func(interrupt uint8) {
+			if interrupt == cpu.INTERRUPT_TIMER {
+				requests++
+			}
+		})
+
+		registers := timer.Registers()
+		registers.WriteByte(REG_TMA, 0xf0)
+		registers.WriteByte(REG_TIMA, 0xfe)
+		registers.WriteByte(REG_TAC, TAC_ENABLE|0x01)
+
+		timer.Step(2 * 16)
+		tima, _ := registers.ReadByte(REG_TIMA)
+		if tima != 0xf0 || requests != 1 {
+			t.Errorf("failed TIMA overflow: expected: 0xf0 and one interrupt\n\tresult: 0x%02x and %d", tima, requests)
+		}
+	})
+
+	t.Run(">>> timer: scenario 4 - DIV write resets the counter", func(t *testing.T) {
+
+		var resets int
+
+		timer := NewTimer()
+		timer.ConnectDIVReset(func() { resets++ })
+		registers := timer.Registers()
+		registers.WriteByte(REG_TAC, TAC_ENABLE|0x01)
+
+		//	the selected counter bit is high: the reset is a falling edge incrementing TIMA
+		timer.Step(8)
+		registers.WriteByte(REG_DIV, 0x55)
+
+		div, _ := registers.ReadByte(REG_DIV)
+		tima, _ := registers.ReadByte(REG_TIMA)
+		if div != 0x00 || tima != 0x01 || resets != 1 {
+			t.Errorf("failed DIV reset: expected: DIV 0x00, TIMA 0x01 and one reset\n\tresult: 0x%02x, 0x%02x and %d", div, tima, resets)
+		}
+
+		tac, _ := registers.ReadByte(REG_TAC)
+		if tac != 0xfd {
+			t.Errorf("failed reading TAC: expected: 0xfd\n\tresult: 0x%02x", tac)
+		}
+	})
+}
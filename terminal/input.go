@@ -0,0 +1,160 @@
+////////////////////////////////////////////////////////////////////////////////
+//	input.go - Oct-19-2026 by aldebap
+//
+//	joypad input from the keys typed in a terminal in raw mode
+////////////////////////////////////////////////////////////////////////////////
+
+package terminal
+
+import (
+	"io"
+	"sync"
+
+	"github.com/aldebap/go_gbc/system"
+)
+
+// terminal input settings: terminals report key presses (and auto repeats) but no key releases,
+// so every press holds its button for a number of frames
+const (
+	KEY_HOLD_FRAMES = 10
+	KEY_ESCAPE      = 0x1b
+	KEY_CTRL_C      = 0x03
+	KEY_BACKSPACE   = 0x7f
+)
+
+// keys mapped to the joypad buttons
+var keyButtons = map[byte]uint8{
+	'x':           system.BUTTON_A,
+	'X':           system.BUTTON_A,
+	'z':           system.BUTTON_B,
+	'Z':           system.BUTTON_B,
+	'\r':          system.BUTTON_START,
+	'\n':          system.BUTTON_START,
+	' ':           system.BUTTON_SELECT,
+	KEY_BACKSPACE: system.BUTTON_SELECT,
+}
+
+// final byte of the arrow keys escape sequences (ESC [ x or ESC O x)
+var arrowButtons = map[byte]uint8{
+	'A': system.BUTTON_UP,
+	'B': system.BUTTON_DOWN,
+	'C': system.BUTTON_RIGHT,
+	'D': system.BUTTON_LEFT,
+}
+
+// joypad input source fed with the bytes read from a terminal
+type Input struct {
+	mutex   sync.Mutex
+	pending []byte
+	held    [8]int
+	rewind  int
+	quit    bool
+}
+
+// create a new terminal input
+func NewInput() *Input {
+	return &Input{}
+}
+
+// press the buttons for KEY_HOLD_FRAMES frames
+func (i *Input) press(buttons uint8) {
+	for bit := range i.held {
+		if buttons&(1<<bit) != 0 {
+			i.held[bit] = KEY_HOLD_FRAMES
+		}
+	}
+}
+
+// decode the bytes typed in the terminal (escape sequences may be split between calls)
+func (i *Input) Feed(data []byte) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.pending = append(i.pending, data...)
+
+	for len(i.pending) > 0 {
+		key := i.pending[0]
+
+		if key != KEY_ESCAPE {
+			switch key {
+			case 'q', 'Q', KEY_CTRL_C:
+				i.quit = true
+			case 'r', 'R':
+				i.rewind = KEY_HOLD_FRAMES
+			default:
+				i.press(keyButtons[key])
+			}
+			i.pending = i.pending[1:]
+			continue
+		}
+
+		//	a lone escape is dropped
+		if len(i.pending) >= 2 && i.pending[1] != '[' && i.pending[1] != 'O' {
+			i.pending = i.pending[1:]
+			continue
+		}
+
+		//	wait for the rest of the escape sequence
+		if len(i.pending) < 3 {
+			return
+		}
+
+		i.press(arrowButtons[i.pending[2]])
+		i.pending = i.pending[3:]
+	}
+}
+
+// read the terminal until it is closed or the quit key is typed
+func (i *Input) Listen(reader io.Reader) error {
+	var buffer = make([]byte, 64)
+
+	for !i.Quit() {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			i.Feed(buffer[:n])
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// return true after the quit key (q or Ctrl-C) was typed
+func (i *Input) Quit() bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return i.quit
+}
+
+// return true while the rewind key (r) is held, releasing it as its hold time runs out (called once per frame)
+func (i *Input) Rewinding() bool {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.rewind == 0 {
+		return false
+	}
+	i.rewind--
+
+	return true
+}
+
+// return the held buttons, releasing them as their hold time runs out (joypad InputSource interface)
+func (i *Input) Buttons(frame uint64) uint8 {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	var buttons uint8
+
+	for bit := range i.held {
+		if i.held[bit] > 0 {
+			buttons |= 1 << bit
+			i.held[bit]--
+		}
+	}
+
+	return buttons
+}
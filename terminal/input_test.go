@@ -0,0 +1,90 @@
+////////////////////////////////////////////////////////////////////////////////
+//	input_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the terminal joypad input
+////////////////////////////////////////////////////////////////////////////////
+
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aldebap/go_gbc/system"
+)
+
+// terminal input unit tests
+func Test_Input(t *testing.T) {
+
+	t.Run(">>> input: scenario 1 - keys and arrows", func(t *testing.T) {
+
+		input := NewInput()
+		input.Feed([]byte("x\x1b[A\r\x1bOC"))
+
+		buttons := input.Buttons(0)
+		expected := system.BUTTON_A | system.BUTTON_UP | system.BUTTON_START | system.BUTTON_RIGHT
+		if buttons != expected {
+			t.Errorf("failed decoding keys: expected: %s\n\tresult: %s", system.FormatButtons(expected), system.FormatButtons(buttons))
+		}
+	})
+
+	t.Run(">>> input: scenario 2 - escape sequence split between reads", func(t *testing.T) {
+
+		input := NewInput()
+		input.Feed([]byte("\x1b"))
+		input.Feed([]byte("["))
+		if input.Buttons(0) != 0 {
+			t.Errorf("failed waiting for the escape sequence: expected no buttons")
+		}
+
+		input.Feed([]byte("Dz"))
+		buttons := input.Buttons(1)
+		if buttons != system.BUTTON_LEFT|system.BUTTON_B {
+			t.Errorf("failed decoding split sequence: expected: LEFT+B\n\tresult: %s", system.FormatButtons(buttons))
+		}
+
+		//	a lone escape is dropped
+		input.Feed([]byte("\x1b "))
+		if input.Buttons(2)&system.BUTTON_SELECT == 0 {
+			t.Errorf("failed dropping a lone escape: expected SELECT")
+		}
+	})
+
+	t.Run(">>> input: scenario 3 - buttons are held for a number of frames", func(t *testing.T) {
+
+		input := NewInput()
+		input.Feed([]byte("x"))
+
+		for frame := range uint64(KEY_HOLD_FRAMES) {
+			if input.Buttons(frame) != system.BUTTON_A {
+				t.Fatalf("failed holding button: released at frame %d", frame)
+			}
+		}
+
+		if input.Buttons(KEY_HOLD_FRAMES) != 0 {
+			t.Errorf("failed releasing button after %d frames", KEY_HOLD_FRAMES)
+		}
+	})
+
+	t.Run(">>> input: scenario 4 - quit key", func(t *testing.T) {
+
+		input := NewInput()
+		err := input.Listen(strings.NewReader("xxq"))
+		if err != nil || !input.Quit() {
+			t.Errorf("failed quitting: expected: quit without error\n\tresult: %v, %v", input.Quit(), err)
+		}
+	})
+	t.Run(">>> input: scenario 5 - rewind key held", func(t *testing.T) {
+
+		input := NewInput()
+		input.Feed([]byte("r"))
+
+		frames := 0
+		for input.Rewinding() {
+			frames++
+		}
+		if frames != KEY_HOLD_FRAMES || input.Buttons(0) != 0 {
+			t.Errorf("failed holding rewind: expected: %d frames and no buttons\n\tresult: %d frames", KEY_HOLD_FRAMES, frames)
+		}
+	})
+}
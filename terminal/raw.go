@@ -0,0 +1,46 @@
+////////////////////////////////////////////////////////////////////////////////
+//	raw.go - Oct-19-2026 by aldebap
+//
+//	switch a terminal into raw mode using stty
+////////////////////////////////////////////////////////////////////////////////
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// run stty on a terminal
+func stty(tty *os.File, args ...string) (string, error) {
+	command := exec.Command("stty", args...)
+	command.Stdin = tty
+
+	output, err := command.Output()
+	if err != nil {
+		return "", fmt.Errorf("stty %s: %w", strings.Join(args, " "), err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// put a terminal in raw mode (no echo, no line buffering), returning a function restoring its settings
+func MakeRaw(tty *os.File) (func() error, error) {
+
+	settings, err := stty(tty, "-g")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = stty(tty, "raw", "-echo")
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		_, err := stty(tty, settings)
+		return err
+	}, nil
+}
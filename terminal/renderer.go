@@ -0,0 +1,122 @@
+////////////////////////////////////////////////////////////////////////////////
+//	renderer.go - Oct-19-2026 by aldebap
+//
+//	draw the screen in a terminal with half-block characters and 24-bit color
+////////////////////////////////////////////////////////////////////////////////
+
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+)
+
+// ANSI escape sequences
+const (
+	ANSI_CLEAR_SCREEN = "\x1b[2J"
+	ANSI_HIDE_CURSOR  = "\x1b[?25l"
+	ANSI_SHOW_CURSOR  = "\x1b[?25h"
+	ANSI_RESET        = "\x1b[0m"
+	ANSI_CURSOR_FMT   = "\x1b[%d;%dH"
+	ANSI_COLORS_FMT   = "\x1b[38;2;%d;%d;%d;48;2;%d;%d;%dm"
+	UPPER_HALF_BLOCK  = "▀"
+)
+
+// terminal cell: the upper half block shows the top pixel as foreground and the bottom pixel as background
+type cell struct {
+	top    color.RGBA
+	bottom color.RGBA
+}
+
+// draw images in a terminal, every cell holds two pixel rows; only the cells changed since the
+// previous frame are written
+type Renderer struct {
+	writer *bufio.Writer
+	width  int
+	rows   int
+	cells  []cell
+	drawn  bool
+}
+
+// create a new renderer for images of a size
+func NewRenderer(writer io.Writer, width int, height int) *Renderer {
+	var rows = (height + 1) / 2
+
+	return &Renderer{
+		writer: bufio.NewWriterSize(writer, 64*1024),
+		width:  width,
+		rows:   rows,
+		cells:  make([]cell, width*rows),
+	}
+}
+
+// clear the terminal and hide the cursor (the next frame is drawn in full)
+func (r *Renderer) Start() error {
+	r.drawn = false
+
+	fmt.Fprint(r.writer, ANSI_HIDE_CURSOR+ANSI_CLEAR_SCREEN)
+
+	return r.writer.Flush()
+}
+
+// restore the terminal colors and cursor, leaving it below the screen
+func (r *Renderer) Stop() error {
+	fmt.Fprintf(r.writer, ANSI_RESET+ANSI_CURSOR_FMT+ANSI_SHOW_CURSOR, r.rows+1, 1)
+
+	return r.writer.Flush()
+}
+
+// return the cell of the image at a column and row (rows below the image are black)
+func imageCell(img *image.RGBA, x int, row int) cell {
+	var c cell
+
+	c.top = img.RGBAAt(x, 2*row)
+	c.top.A = 0xff
+	if 2*row+1 < img.Bounds().Dy() {
+		c.bottom = img.RGBAAt(x, 2*row+1)
+	}
+	c.bottom.A = 0xff
+
+	return c
+}
+
+// draw an image, returning the number of cells written
+func (r *Renderer) Draw(img *image.RGBA) (int, error) {
+	var written int
+	var colors cell
+	var colorsSet bool
+
+	for row := range r.rows {
+		//	the cursor is moved only before the first changed cell of a run
+		cursorAt := -1
+
+		for x := range r.width {
+			c := imageCell(img, x, row)
+			index := row*r.width + x
+
+			if r.drawn && r.cells[index] == c {
+				continue
+			}
+			r.cells[index] = c
+
+			if cursorAt != x {
+				fmt.Fprintf(r.writer, ANSI_CURSOR_FMT, row+1, x+1)
+			}
+			if !colorsSet || colors != c {
+				fmt.Fprintf(r.writer, ANSI_COLORS_FMT, c.top.R, c.top.G, c.top.B, c.bottom.R, c.bottom.G, c.bottom.B)
+				colors = c
+				colorsSet = true
+			}
+			r.writer.WriteString(UPPER_HALF_BLOCK)
+
+			cursorAt = x + 1
+			written++
+		}
+	}
+	r.drawn = true
+
+	return written, r.writer.Flush()
+}
@@ -0,0 +1,86 @@
+////////////////////////////////////////////////////////////////////////////////
+//	renderer_test.go - Oct-19-2026 by aldebap
+//
+//	Test cases for the terminal renderer
+////////////////////////////////////////////////////////////////////////////////
+
+package terminal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+// terminal renderer unit tests
+func Test_Renderer(t *testing.T) {
+
+	t.Run(">>> renderer: scenario 1 - first frame draws every cell", func(t *testing.T) {
+
+		var output bytes.Buffer
+
+		img := image.NewRGBA(image.Rect(0, 0, 4, 3))
+		renderer := NewRenderer(&output, 4, 3)
+
+		written, err := renderer.Draw(img)
+		if err != nil {
+			t.Fatalf("failed drawing: %v", err)
+		}
+
+		if written != 8 || strings.Count(output.String(), UPPER_HALF_BLOCK) != 8 {
+			t.Errorf("failed drawing every cell: expected: 8 cells\n\tresult: %d cells", written)
+		}
+
+		//	a single colors sequence for a black screen and one cursor move per row
+		if strings.Count(output.String(), "48;2;") != 1 || strings.Count(output.String(), "H") != 2 {
+			t.Errorf("failed minimizing escape sequences: %q", output.String())
+		}
+	})
+
+	t.Run(">>> renderer: scenario 2 - only changed cells are redrawn", func(t *testing.T) {
+
+		var output bytes.Buffer
+
+		img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+		renderer := NewRenderer(&output, 4, 4)
+		renderer.Draw(img)
+
+		output.Reset()
+		written, _ := renderer.Draw(img)
+		if written != 0 || output.Len() != 0 {
+			t.Errorf("failed skipping an unchanged frame: expected: 0 cells\n\tresult: %d cells (%q)", written, output.String())
+		}
+
+		//	pixel (2, 3) is the bottom half of the cell at column 2, row 1
+		img.SetRGBA(2, 3, color.RGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff})
+		written, _ = renderer.Draw(img)
+
+		expected := "\x1b[2;3H\x1b[38;2;0;0;0;48;2;255;128;0m" + UPPER_HALF_BLOCK
+		if written != 1 || output.String() != expected {
+			t.Errorf("failed drawing a changed cell: expected: %q\n\tresult: %q", expected, output.String())
+		}
+	})
+
+	t.Run(">>> renderer: scenario 3 - start redraws the whole screen", func(t *testing.T) {
+
+		var output bytes.Buffer
+
+		img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		renderer := NewRenderer(&output, 2, 2)
+		renderer.Draw(img)
+
+		renderer.Start()
+		written, _ := renderer.Draw(img)
+		if written != 2 {
+			t.Errorf("failed redrawing after start: expected: 2 cells\n\tresult: %d cells", written)
+		}
+
+		output.Reset()
+		renderer.Stop()
+		if !strings.HasPrefix(output.String(), ANSI_RESET) || !strings.HasSuffix(output.String(), ANSI_SHOW_CURSOR) {
+			t.Errorf("failed restoring the terminal: %q", output.String())
+		}
+	})
+}
@@ -10,7 +10,8 @@ import (
 	"fmt"
 	"testing"
 
-	go_gbc "github.com/aldebap/go_gbc"
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // NOP instruction unit tests
@@ -18,29 +19,29 @@ func Test_NOP(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> NOP (0x%02x): scenario 1 - do nothing", go_gbc.NOP), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> NOP (0x%02x): scenario 1 - do nothing", cpu.NOP), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.NOP,
-			go_gbc.NOP,
+			cpu.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -50,10 +51,10 @@ func Test_NOP(t *testing.T) {
 
 		//	two cicles to execute the test program
 		for range 2 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -67,31 +68,31 @@ func Test_LD_BC_nn(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD BC, nn (0x%02x): scenario 1 - load BC 16 bits register", go_gbc.LD_BC_nn), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD BC, nn (0x%02x): scenario 1 - load BC 16 bits register", cpu.LD_BC_nn), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x52,
 			0xf0,
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -101,10 +102,10 @@ func Test_LD_BC_nn(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -118,51 +119,51 @@ func Test_LD_ADDR_BC_A(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD (BC), A (0x%02x): scenario 1 - write A into (BC)", go_gbc.LD_ADDR_BC_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD (BC), A (0x%02x): scenario 1 - write A into (BC)", cpu.LD_ADDR_BC_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x00,
 			0xC0,
-			go_gbc.LD_A_n,
+			cpu.LD_A_n,
 			0x6c,
-			go_gbc.LD_ADDR_BC_A,
-			go_gbc.LD_A_n,
+			cpu.LD_ADDR_BC_A,
+			cpu.LD_A_n,
 			0x00,
-			go_gbc.LD_A_ADDR_nn,
+			cpu.LD_A_ADDR_nn,
 			0x00,
 			0xC0,
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		//	create a new RAM memory bank
-		ram := go_gbc.NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
 
 		//	connect the RAM memory to the CPU
-		err = cpu.ConnectMemory(ram, 0xC000)
+		err = sm83.ConnectMemory(ram, 0xC000)
 		if err != nil {
 			t.Errorf("fail connecting RAM to CPU: %s", err.Error())
 		}
@@ -172,10 +173,10 @@ func Test_LD_ADDR_BC_A(t *testing.T) {
 
 		//	eight cicles to execute the test program
 		for range 14 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -189,32 +190,32 @@ func Test_INC_BC(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC BC (0x%02x): scenario 1 - increment without carry out", go_gbc.INC_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC BC (0x%02x): scenario 1 - increment without carry out", cpu.INC_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x00,
-			go_gbc.INC_BC,
-			go_gbc.NOP,
+			cpu.INC_BC,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -224,10 +225,10 @@ func Test_INC_BC(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -235,45 +236,45 @@ func Test_INC_BC(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC BC (0x%02x): scenario 2 - increment with carry out", go_gbc.INC_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC BC (0x%02x): scenario 2 - increment with carry out", cpu.INC_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0xff,
 			0xff,
-			go_gbc.INC_BC,
-			go_gbc.NOP,
+			cpu.INC_BC,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, go_gbc.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -287,32 +288,32 @@ func Test_INC_B(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC B (0x%02x): scenario 1 - increment without carry out", go_gbc.INC_B), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC B (0x%02x): scenario 1 - increment without carry out", cpu.INC_B), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x2c,
-			go_gbc.INC_B,
-			go_gbc.NOP,
+			cpu.INC_B,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -322,10 +323,10 @@ func Test_INC_B(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -333,45 +334,45 @@ func Test_INC_B(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC B (0x%02x): scenario 2 - increment with carry out", go_gbc.INC_B), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC B (0x%02x): scenario 2 - increment with carry out", cpu.INC_B), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0xff,
-			go_gbc.INC_B,
-			go_gbc.NOP,
+			cpu.INC_B,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, go_gbc.FLAG_Z, 0x00, 0x0007, 0x0000, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0007, 0x0000, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -385,32 +386,32 @@ func Test_DEC_B(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC B (0x%02x): scenario 1 - decrement without carry out", go_gbc.DEC_B), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC B (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_B), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x2c,
-			go_gbc.DEC_B,
-			go_gbc.NOP,
+			cpu.DEC_B,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -420,10 +421,10 @@ func Test_DEC_B(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -431,32 +432,32 @@ func Test_DEC_B(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC B (0x%02x): scenario 2 - decrement with carry out", go_gbc.DEC_B), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC B (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_B), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x00,
-			go_gbc.DEC_B,
-			go_gbc.NOP,
+			cpu.DEC_B,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -466,10 +467,10 @@ func Test_DEC_B(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -483,30 +484,30 @@ func Test_LD_B_n(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD B, n (0x%02x): scenario 1 - load B 8 bits register", go_gbc.LD_B_n), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD B, n (0x%02x): scenario 1 - load B 8 bits register", cpu.LD_B_n), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_B_n,
+			cpu.LD_B_n,
 			0x7e,
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -516,10 +517,10 @@ func Test_LD_B_n(t *testing.T) {
 
 		//	three cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -533,31 +534,31 @@ func Test_RLCA(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> RLCA (0x%02x): scenario 1 - no circular bit", go_gbc.RLCA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RLCA (0x%02x): scenario 1 - no circular bit", cpu.RLCA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_A_n,
+			cpu.LD_A_n,
 			0x40,
-			go_gbc.RLCA,
-			go_gbc.NOP,
+			cpu.RLCA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -567,10 +568,10 @@ func Test_RLCA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -578,31 +579,31 @@ func Test_RLCA(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> RLCA (0x%02x): scenario 2 - circular bit", go_gbc.RLCA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RLCA (0x%02x): scenario 2 - circular bit", cpu.RLCA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_A_n,
+			cpu.LD_A_n,
 			0xc0,
-			go_gbc.RLCA,
-			go_gbc.NOP,
+			cpu.RLCA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -612,10 +613,10 @@ func Test_RLCA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -629,49 +630,49 @@ func Test_LD_ADDR_nn_SP(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD (nn), SP (0x%02x): scenario 1 - write SP into (nn)", go_gbc.LD_ADDR_nn_SP), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD (nn), SP (0x%02x): scenario 1 - write SP into (nn)", cpu.LD_ADDR_nn_SP), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_SP_nn,
+			cpu.LD_SP_nn,
 			0x42,
 			0xC7,
-			go_gbc.LD_ADDR_nn_SP,
+			cpu.LD_ADDR_nn_SP,
 			0x00,
 			0xc0,
-			go_gbc.LD_A_ADDR_nn,
+			cpu.LD_A_ADDR_nn,
 			0x00,
 			0xc0,
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		//	create a new RAM memory bank
-		ram := go_gbc.NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
 
 		//	connect the RAM memory to the CPU
-		err = cpu.ConnectMemory(ram, 0xC000)
+		err = sm83.ConnectMemory(ram, 0xC000)
 		if err != nil {
 			t.Errorf("fail connecting RAM to CPU: %s", err.Error())
 		}
@@ -681,10 +682,10 @@ func Test_LD_ADDR_nn_SP(t *testing.T) {
 
 		//	eight cicles to execute the test program
 		for range 13 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -700,28 +701,28 @@ func Test_ADD_HL_BC(t *testing.T) {
 
 	err = nil
 
-	t.Run(fmt.Sprintf(">>> ADD HL, BC (0x%02x): scenario 1 - adding BC to HL without carry", go_gbc.ADD_HL_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> ADD HL, BC (0x%02x): scenario 1 - adding BC to HL without carry", cpu.ADD_HL_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -734,25 +735,25 @@ func Test_LD_A_ADDR_BC(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD A, (BC) (0x%02x): scenario 1 - load acumulator from memory", go_gbc.LD_A_ADDR_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD A, (BC) (0x%02x): scenario 1 - load acumulator from memory", cpu.LD_A_ADDR_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x05,
 			0x00,
-			go_gbc.LD_A_ADDR_BC,
-			go_gbc.NOP,
+			cpu.LD_A_ADDR_BC,
+			cpu.NOP,
 			0x75,
 		})
 		if err != nil {
@@ -760,7 +761,7 @@ func Test_LD_A_ADDR_BC(t *testing.T) {
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -770,10 +771,10 @@ func Test_LD_A_ADDR_BC(t *testing.T) {
 
 		//	six cicles to execute the test program
 		for range 6 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -787,32 +788,32 @@ func Test_DEC_BC(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC BC (0x%02x): scenario 1 - decrement without carry out", go_gbc.DEC_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC BC (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x00,
-			go_gbc.DEC_BC,
-			go_gbc.NOP,
+			cpu.DEC_BC,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -822,10 +823,10 @@ func Test_DEC_BC(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -833,32 +834,32 @@ func Test_DEC_BC(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC BC (0x%02x): scenario 2 - decrement with carry out", go_gbc.DEC_BC), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC BC (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_BC), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x00,
 			0x00,
-			go_gbc.DEC_BC,
-			go_gbc.NOP,
+			cpu.DEC_BC,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -868,10 +869,10 @@ func Test_DEC_BC(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -885,32 +886,32 @@ func Test_INC_C(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC C (0x%02x): scenario 1 - increment without carry out", go_gbc.INC_C), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC C (0x%02x): scenario 1 - increment without carry out", cpu.INC_C), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x2c,
-			go_gbc.INC_C,
-			go_gbc.NOP,
+			cpu.INC_C,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -920,10 +921,10 @@ func Test_INC_C(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -931,45 +932,45 @@ func Test_INC_C(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC C (0x%02x): scenario 2 - increment with carry out", go_gbc.INC_C), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC C (0x%02x): scenario 2 - increment with carry out", cpu.INC_C), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0xff,
 			0x07,
-			go_gbc.INC_C,
-			go_gbc.NOP,
+			cpu.INC_C,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, go_gbc.FLAG_Z, 0x00, 0x0700, 0x0000, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0700, 0x0000, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -983,32 +984,32 @@ func Test_DEC_C(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC C (0x%02x): scenario 1 - decrement without carry out", go_gbc.DEC_C), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC C (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_C), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x07,
 			0x2c,
-			go_gbc.DEC_C,
-			go_gbc.NOP,
+			cpu.DEC_C,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -1018,10 +1019,10 @@ func Test_DEC_C(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -1029,32 +1030,32 @@ func Test_DEC_C(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC C (0x%02x): scenario 2 - decrement with carry out", go_gbc.DEC_C), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC C (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_C), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_BC_nn,
+			cpu.LD_BC_nn,
 			0x00,
 			0x07,
-			go_gbc.DEC_C,
-			go_gbc.NOP,
+			cpu.DEC_C,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -1064,10 +1065,10 @@ func Test_DEC_C(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -1081,30 +1082,30 @@ func Test_LD_C_n(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD C, n (0x%02x): scenario 1 - load C 8 bits register", go_gbc.LD_C_n), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD C, n (0x%02x): scenario 1 - load C 8 bits register", cpu.LD_C_n), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_C_n,
+			cpu.LD_C_n,
 			0xe7,
-			go_gbc.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -1114,10 +1115,10 @@ func Test_LD_C_n(t *testing.T) {
 
 		//	three cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -1131,31 +1132,31 @@ func Test_RRCA(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> RRCA (0x%02x): scenario 1 - no circular bit", go_gbc.RRCA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RRCA (0x%02x): scenario 1 - no circular bit", cpu.RRCA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_A_n,
+			cpu.LD_A_n,
 			0x40,
-			go_gbc.RRCA,
-			go_gbc.NOP,
+			cpu.RRCA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -1165,10 +1166,10 @@ func Test_RRCA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -1176,31 +1177,31 @@ func Test_RRCA(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> RRCA (0x%02x): scenario 2 - circular bit", go_gbc.RRCA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RRCA (0x%02x): scenario 2 - circular bit", cpu.RRCA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := go_gbc.NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &go_gbc.ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			go_gbc.LD_A_n,
+			cpu.LD_A_n,
 			0x11,
-			go_gbc.RRCA,
-			go_gbc.NOP,
+			cpu.RRCA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -1210,10 +1211,10 @@ func Test_RRCA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -9,6 +9,9 @@ package test
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // STOP instruction unit tests
@@ -16,22 +19,22 @@ func Test_STOP(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> STOP (0x%02x): scenario 1 - stop CPU", STOP), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> STOP (0x%02x): scenario 1 - stop CPU", cpu.STOP), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			NOP,
-			STOP,
+			cpu.NOP,
+			cpu.STOP,
 			//	TODO: need to create this test scenario
 		})
 		if err != nil {
@@ -39,7 +42,7 @@ func Test_STOP(t *testing.T) {
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -49,10 +52,10 @@ func Test_STOP(t *testing.T) {
 
 		//	two cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -66,31 +69,31 @@ func Test_LD_DE_nn(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD DE, nn (0x%02x): scenario 1 - load DE 16 bits register", LD_DE_nn), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD DE, nn (0x%02x): scenario 1 - load DE 16 bits register", cpu.LD_DE_nn), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0x83,
 			0x7f,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -100,10 +103,10 @@ func Test_LD_DE_nn(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -117,51 +120,51 @@ func Test_LD_ADDR_DE_A(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD (DE), A (0x%02x): scenario 1 - write A into (DE)", LD_ADDR_DE_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD (DE), A (0x%02x): scenario 1 - write A into (DE)", cpu.LD_ADDR_DE_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0x00,
 			0xC0,
-			LD_A_n,
+			cpu.LD_A_n,
 			0xa8,
-			LD_ADDR_DE_A,
-			LD_A_n,
+			cpu.LD_ADDR_DE_A,
+			cpu.LD_A_n,
 			0x00,
-			LD_A_ADDR_nn,
+			cpu.LD_A_ADDR_nn,
 			0x00,
 			0xC0,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
 
 		//	connect the RAM memory to the CPU
-		err = cpu.ConnectMemory(ram, 0xC000)
+		err = sm83.ConnectMemory(ram, 0xC000)
 		if err != nil {
 			t.Errorf("fail connecting RAM to CPU: %s", err.Error())
 		}
@@ -171,10 +174,10 @@ func Test_LD_ADDR_DE_A(t *testing.T) {
 
 		//	eight cicles to execute the test program
 		for range 14 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -188,32 +191,32 @@ func Test_INC_DE(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC DE (0x%02x): scenario 1 - increment without carry out", INC_DE), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC DE (0x%02x): scenario 1 - increment without carry out", cpu.INC_DE), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0x05,
 			0x21,
-			INC_DE,
-			NOP,
+			cpu.INC_DE,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -223,10 +226,10 @@ func Test_INC_DE(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -234,45 +237,45 @@ func Test_INC_DE(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC DE (0x%02x): scenario 2 - increment with carry out", INC_DE), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC DE (0x%02x): scenario 2 - increment with carry out", cpu.INC_DE), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0xff,
 			0xff,
-			INC_DE,
-			NOP,
+			cpu.INC_DE,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -286,32 +289,32 @@ func Test_INC_D(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC D (0x%02x): scenario 1 - increment without carry out", INC_D), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC D (0x%02x): scenario 1 - increment without carry out", cpu.INC_D), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0xf1,
 			0x40,
-			INC_D,
-			NOP,
+			cpu.INC_D,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -321,10 +324,10 @@ func Test_INC_D(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -332,45 +335,45 @@ func Test_INC_D(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC D (0x%02x): scenario 2 - increment with carry out", INC_D), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC D (0x%02x): scenario 2 - increment with carry out", cpu.INC_D), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0x44,
 			0xff,
-			INC_D,
-			NOP,
+			cpu.INC_D,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0044, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0044, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -384,32 +387,32 @@ func Test_DEC_D(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC D (0x%02x): scenario 1 - decrement without carry out", DEC_D), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC D (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_D), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0xf1,
 			0x40,
-			DEC_D,
-			NOP,
+			cpu.DEC_D,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -419,10 +422,10 @@ func Test_DEC_D(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -430,32 +433,32 @@ func Test_DEC_D(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC D (0x%02x): scenario 2 - decrement with carry out", DEC_D), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC D (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_D), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0xf1,
 			0x00,
-			DEC_D,
-			NOP,
+			cpu.DEC_D,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -465,10 +468,10 @@ func Test_DEC_D(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -482,30 +485,30 @@ func Test_LD_D_n(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD D, n (0x%02x): scenario 1 - load D 8 bits register", LD_D_n), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD D, n (0x%02x): scenario 1 - load D 8 bits register", cpu.LD_D_n), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_D_n,
+			cpu.LD_D_n,
 			0x49,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -515,10 +518,10 @@ func Test_LD_D_n(t *testing.T) {
 
 		//	three cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -532,31 +535,31 @@ func Test_RLA(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> RLA (0x%02x): scenario 1 - no overflow", RLA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RLA (0x%02x): scenario 1 - no overflow", cpu.RLA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0x40,
-			RLA,
-			NOP,
+			cpu.RLA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -566,10 +569,10 @@ func Test_RLA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -577,31 +580,31 @@ func Test_RLA(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> RLA (0x%02x): scenario 2 - overflow", RLA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> RLA (0x%02x): scenario 2 - overflow", cpu.RLA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0xc0,
-			RLA,
-			NOP,
+			cpu.RLA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -611,10 +614,10 @@ func Test_RLA(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
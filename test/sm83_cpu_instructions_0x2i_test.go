@@ -9,6 +9,9 @@ package test
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // STOP instruction unit tests
@@ -16,47 +19,47 @@ func Test_JR_NZ_e(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 1 - no jump (Z is 1)", JR_NZ_e), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 1 - no jump (Z is 1)", cpu.JR_NZ_e), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_B_n,
+			cpu.LD_B_n,
 			0xFF,
-			INC_B,
-			JR_NZ_e,
+			cpu.INC_B,
+			cpu.JR_NZ_e,
 			0x05,
-			NOP,
-			NOP,
+			cpu.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0006, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
+			0x0006, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
 
 		//	two cicles to execute the test program
 		for range 6 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -64,39 +67,39 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 2 - jump forward", JR_NZ_e), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 2 - jump forward", cpu.JR_NZ_e), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_B_n,
+			cpu.LD_B_n,
 			0xFE,
-			INC_B,
-			JR_NZ_e,
+			cpu.INC_B,
+			cpu.JR_NZ_e,
 			0x05,
-			NOP,
-			NOP,
-			NOP,
-			NOP,
-			NOP,
-			NOP,
-			NOP,
+			cpu.NOP,
+			cpu.NOP,
+			cpu.NOP,
+			cpu.NOP,
+			cpu.NOP,
+			cpu.NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -106,10 +109,10 @@ func Test_JR_NZ_e(t *testing.T) {
 
 		//	two cicles to execute the test program
 		for range 6 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -117,33 +120,33 @@ func Test_JR_NZ_e(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 3 - jump backwards", JR_NZ_e), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> JR_NZ_e (0x%02x): scenario 3 - jump backwards", cpu.JR_NZ_e), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_B_n,
+			cpu.LD_B_n,
 			0xFE,
-			INC_B,
-			JR_NZ_e,
+			cpu.INC_B,
+			cpu.JR_NZ_e,
 			0xFB, // 0xFB = -5
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -153,10 +156,10 @@ func Test_JR_NZ_e(t *testing.T) {
 
 		//	two cicles to execute the test program
 		for range 6 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -170,31 +173,31 @@ func Test_LD_HL_nn(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD HL, nn (0x%02x): scenario 1 - load HL 16 bits register", LD_HL_nn), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD HL, nn (0x%02x): scenario 1 - load HL 16 bits register", cpu.LD_HL_nn), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0x25,
 			0x84,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -204,10 +207,10 @@ func Test_LD_HL_nn(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -221,52 +224,52 @@ func Test_LD_ADDR_HL_PLUS_A(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD (HL+), A (0x%02x): scenario 1 - write A into (HL)", LD_ADDR_HL_PLUS_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD (HL+), A (0x%02x): scenario 1 - write A into (HL)", cpu.LD_ADDR_HLI_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
 			//	TODO: need to create this test scenario
-			LD_DE_nn,
+			cpu.LD_DE_nn,
 			0x00,
 			0xC0,
-			LD_A_n,
+			cpu.LD_A_n,
 			0xa8,
-			LD_ADDR_DE_A,
-			LD_A_n,
+			cpu.LD_ADDR_DE_A,
+			cpu.LD_A_n,
 			0x00,
-			LD_A_ADDR_nn,
+			cpu.LD_A_ADDR_nn,
 			0x00,
 			0xC0,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		//	create a new RAM memory bank
-		ram := NewRAM_memory(8)
+		ram := memory.NewRAM_memory(8)
 		if ram == nil {
 			t.Errorf("fail creating new RAM memory")
 		}
 
 		//	connect the RAM memory to the CPU
-		err = cpu.ConnectMemory(ram, 0xC000)
+		err = sm83.ConnectMemory(ram, 0xC000)
 		if err != nil {
 			t.Errorf("fail connecting RAM to CPU: %s", err.Error())
 		}
@@ -276,10 +279,10 @@ func Test_LD_ADDR_HL_PLUS_A(t *testing.T) {
 
 		//	eight cicles to execute the test program
 		for range 14 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -293,32 +296,32 @@ func Test_INC_HL(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC HL (0x%02x): scenario 1 - increment without carry out", INC_HL), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC HL (0x%02x): scenario 1 - increment without carry out", cpu.INC_HL), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0x05,
 			0x21,
-			INC_HL,
-			NOP,
+			cpu.INC_HL,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -328,10 +331,10 @@ func Test_INC_HL(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -339,45 +342,45 @@ func Test_INC_HL(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC HL (0x%02x): scenario 2 - increment with carry out", INC_HL), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC HL (0x%02x): scenario 2 - increment with carry out", cpu.INC_HL), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0xff,
 			0xff,
-			INC_HL,
-			NOP,
+			cpu.INC_HL,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -391,32 +394,32 @@ func Test_INC_H(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC H (0x%02x): scenario 1 - increment without carry out", INC_H), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC H (0x%02x): scenario 1 - increment without carry out", cpu.INC_H), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0xf1,
 			0x40,
-			INC_H,
-			NOP,
+			cpu.INC_H,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -426,10 +429,10 @@ func Test_INC_H(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -437,45 +440,45 @@ func Test_INC_H(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC H (0x%02x): scenario 2 - increment with carry out", INC_H), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC H (0x%02x): scenario 2 - increment with carry out", cpu.INC_H), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0x44,
 			0xff,
-			INC_H,
-			NOP,
+			cpu.INC_H,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0005, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0044)
+			0x0005, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0044)
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -489,32 +492,32 @@ func Test_DEC_H(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC H (0x%02x): scenario 1 - decrement without carry out", DEC_H), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC H (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_H), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0xf1,
 			0x40,
-			DEC_H,
-			NOP,
+			cpu.DEC_H,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -524,10 +527,10 @@ func Test_DEC_H(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -535,32 +538,32 @@ func Test_DEC_H(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC H (0x%02x): scenario 2 - decrement with carry out", DEC_H), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC H (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_H), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_HL_nn,
+			cpu.LD_HL_nn,
 			0xf1,
 			0x00,
-			DEC_H,
-			NOP,
+			cpu.DEC_H,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -570,10 +573,10 @@ func Test_DEC_H(t *testing.T) {
 
 		//	five cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -587,30 +590,30 @@ func Test_LD_H_n(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD H, n (0x%02x): scenario 1 - load H 8 bits register", LD_H_n), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD H, n (0x%02x): scenario 1 - load H 8 bits register", cpu.LD_H_n), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_H_n,
+			cpu.LD_H_n,
 			0x74,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -620,10 +623,10 @@ func Test_LD_H_n(t *testing.T) {
 
 		//	three cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -637,32 +640,32 @@ func Test_DAA(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DAA (0x%02x): scenario 1 - no overflow", DAA), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DAA (0x%02x): scenario 1 - no overflow", cpu.DAA), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
 			//	TODO: need to create this test scenario
-			LD_A_n,
+			cpu.LD_A_n,
 			0x40,
-			DAA,
-			NOP,
+			cpu.DAA,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -671,11 +674,11 @@ func Test_DAA(t *testing.T) {
 			0x0004, 0x0000, 0x00, 0x40, 0x0000, 0x0000, 0x0000)
 
 		//	four cicles to execute the test program
-		for range 7 {
-			cpu.MachineCycle()
+		for range 4 {
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
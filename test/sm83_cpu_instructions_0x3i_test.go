@@ -9,6 +9,9 @@ package test
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // LD_SP_nn instruction unit tests
@@ -16,31 +19,31 @@ func Test_LD_SP_nn(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD SP, nn (0x%02x): scenario 1 - load SP 16 bits register", LD_SP_nn), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD SP, nn (0x%02x): scenario 1 - load SP 16 bits register", cpu.LD_SP_nn), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_SP_nn,
+			cpu.LD_SP_nn,
 			0x0c,
 			0x61,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -50,10 +53,10 @@ func Test_LD_SP_nn(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -67,31 +70,31 @@ func Test_INC_A(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> INC A (0x%02x): scenario 1 - increment without carry out", INC_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC A (0x%02x): scenario 1 - increment without carry out", cpu.INC_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0x7e,
-			INC_A,
-			NOP,
+			cpu.INC_A,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -101,10 +104,10 @@ func Test_INC_A(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -112,44 +115,44 @@ func Test_INC_A(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> INC A (0x%02x): scenario 2 - increment with carry out", INC_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> INC A (0x%02x): scenario 2 - increment with carry out", cpu.INC_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0xff,
-			INC_A,
-			NOP,
+			cpu.INC_A,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
 
 		want := fmt.Sprintf("PC: 0x%04x; SP: 0x%04x; Flags: 0x%02x; A: 0x%02x; BC: 0x%04x; DE: 0x%04x; HL: 0x%04x",
-			0x0004, 0x0000, FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
+			0x0004, 0x0000, cpu.FLAG_Z, 0x00, 0x0000, 0x0000, 0x0000)
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -163,31 +166,31 @@ func Test_DEC_A(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> DEC A (0x%02x): scenario 1 - decrement without carry out", DEC_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC A (0x%02x): scenario 1 - decrement without carry out", cpu.DEC_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0x7e,
-			DEC_A,
-			NOP,
+			cpu.DEC_A,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -197,10 +200,10 @@ func Test_DEC_A(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -208,31 +211,31 @@ func Test_DEC_A(t *testing.T) {
 		}
 	})
 
-	t.Run(fmt.Sprintf(">>> DEC A (0x%02x): scenario 2 - decrement with carry out", DEC_A), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> DEC A (0x%02x): scenario 2 - decrement with carry out", cpu.DEC_A), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0x00,
-			DEC_A,
-			NOP,
+			cpu.DEC_A,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -242,10 +245,10 @@ func Test_DEC_A(t *testing.T) {
 
 		//	four cicles to execute the test program
 		for range 4 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -259,30 +262,30 @@ func Test_LD_A_n(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD A, n (0x%02x): scenario 1 - load acumulator", LD_A_n), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD A, n (0x%02x): scenario 1 - load acumulator", cpu.LD_A_n), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_n,
+			cpu.LD_A_n,
 			0x7e,
-			NOP,
+			cpu.NOP,
 		})
 		if err != nil {
 			t.Errorf("fail loading test program: %s", err.Error())
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -292,10 +295,10 @@ func Test_LD_A_n(t *testing.T) {
 
 		//	three cicles to execute the test program
 		for range 3 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {
@@ -9,6 +9,9 @@ package test
 import (
 	"fmt"
 	"testing"
+
+	"github.com/aldebap/go_gbc/cpu"
+	"github.com/aldebap/go_gbc/memory"
 )
 
 // LD_A_ADDR_nn instruction unit tests
@@ -16,24 +19,24 @@ func Test_LD_A_ADDR_nn(t *testing.T) {
 
 	var err error
 
-	t.Run(fmt.Sprintf(">>> LD A, (nn) (0x%02x): scenario 1 - load acumulator from memory", LD_A_ADDR_nn), func(t *testing.T) {
+	t.Run(fmt.Sprintf(">>> LD A, (nn) (0x%02x): scenario 1 - load acumulator from memory", cpu.LD_A_ADDR_nn), func(t *testing.T) {
 
 		//	create a new SM83 CPU
-		cpu := NewSM83_CPU(trace)
-		if cpu == nil {
+		sm83 := cpu.NewSM83_CPU(trace)
+		if sm83 == nil {
 			t.Errorf("fail creating new SM83 CPU")
 		}
 
 		//	create a new ROM memory and load it with the test program
-		rom := &ROM_memory{}
+		rom := &memory.ROM_memory{}
 		if rom == nil {
 			t.Errorf("fail creating new ROM memory")
 		}
 		err = rom.Load([]uint8{
-			LD_A_ADDR_nn,
+			cpu.LD_A_ADDR_nn,
 			0x04,
 			0x00,
-			NOP,
+			cpu.NOP,
 			0x9a,
 		})
 		if err != nil {
@@ -41,7 +44,7 @@ func Test_LD_A_ADDR_nn(t *testing.T) {
 		}
 
 		//	connect the ROM memory to the CPU
-		err = cpu.ConnectMemory(rom, 0x0000)
+		err = sm83.ConnectMemory(rom, 0x0000)
 		if err != nil {
 			t.Errorf("fail connecting ROM to CPU: %s", err.Error())
 		}
@@ -51,10 +54,10 @@ func Test_LD_A_ADDR_nn(t *testing.T) {
 
 		//	eight cicles to execute the test program
 		for range 5 {
-			cpu.MachineCycle()
+			sm83.MachineCycle()
 		}
 
-		got := cpu.DumpRegisters()
+		got := sm83.DumpRegisters()
 
 		//	check the invocation result
 		if want != got {